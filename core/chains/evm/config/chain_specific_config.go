@@ -4,6 +4,8 @@ import (
 	"math/big"
 	"time"
 
+	"gopkg.in/guregu/null.v4"
+
 	"github.com/smartcontractkit/chainlink/core/assets"
 )
 
@@ -15,6 +17,7 @@ var (
 
 type (
 	// chainSpecificConfigDefaultSet lists the config defaults specific to a particular chain ID
+	// The keeper defaults are null unless a chain needs a value other than the global keeper default
 	chainSpecificConfigDefaultSet struct {
 		balanceMonitorEnabled                      bool
 		balanceMonitorBlockDelay                   uint16
@@ -40,6 +43,10 @@ type (
 		headTrackerHistoryDepth                    uint32
 		headTrackerMaxBufferSize                   uint32
 		headTrackerSamplingInterval                time.Duration
+		keeperGasPriceBufferPercent                null.Int
+		keeperGasPriceSpeed                        null.String
+		keeperRegistryCheckGasOverhead             null.Int
+		keeperRegistryPerformGasOverhead           null.Int
 		linkContractAddress                        string
 		logBackfillBatchSize                       uint32
 		maxGasPriceWei                             big.Int
@@ -186,6 +193,7 @@ func setChainSpecificConfigDefaultSets() {
 	polygonMainnet.linkContractAddress = "0xb0897686c545045afc77cf20ec7a532e3120e0f1"
 	polygonMainnet.minIncomingConfirmations = 5
 	polygonMainnet.minRequiredOutgoingConfirmations = 12
+	polygonMainnet.keeperGasPriceSpeed = null.StringFrom("fast") // Gas prices spike often, standard estimates regularly leave performs stuck
 	polygonMumbai := polygonMainnet
 	polygonMumbai.linkContractAddress = "0x326C977E6efc84E512bB9C30f76E30c160eD06FB"
 
@@ -201,6 +209,7 @@ func setChainSpecificConfigDefaultSets() {
 	arbitrumMainnet.blockHistoryEstimatorBlockHistorySize = 0 // Force an error if someone set GAS_UPDATER_ENABLED=true by accident; we never want to run the block history estimator on arbitrum
 	arbitrumMainnet.linkContractAddress = "0xf97f4df75117a78c1A5a0DBb814Af92458539FB4"
 	arbitrumMainnet.ocrContractConfirmations = 1
+	arbitrumMainnet.keeperGasPriceBufferPercent = null.IntFrom(0) // The gas price is fixed, any buffer would exceed maxGasPriceWei
 	arbitrumRinkeby := arbitrumMainnet
 	arbitrumRinkeby.linkContractAddress = "0x615fBe6372676474d9e6933d310469c9b68e9726"
 
@@ -254,6 +263,7 @@ func setChainSpecificConfigDefaultSets() {
 	avalancheMainnet.minIncomingConfirmations = 1
 	avalancheMainnet.minRequiredOutgoingConfirmations = 1
 	avalancheMainnet.ocrContractConfirmations = 1
+	avalancheMainnet.keeperGasPriceBufferPercent = null.IntFrom(0) // The gas price is fixed, any buffer would exceed maxGasPriceWei
 
 	avalancheFuji := avalancheMainnet
 	avalancheFuji.linkContractAddress = "0x0b9d5D9136855f6FEc3c0993feE6E9CE8a297846"
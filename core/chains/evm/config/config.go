@@ -847,6 +847,62 @@ func (c *chainScopedConfig) BalanceMonitorEnabled() bool {
 	return c.defaultSet.balanceMonitorEnabled
 }
 
+// KeeperGasPriceBufferPercent adds the given percentage to the gas price of keeper performs,
+// layering an explicitly set value over the chain's keeper default and the global default
+func (c *chainScopedConfig) KeeperGasPriceBufferPercent() uint32 {
+	val, ok := c.GeneralConfig.GlobalKeeperGasPriceBufferPercent()
+	if ok {
+		c.logEnvOverrideOnce("KeeperGasPriceBufferPercent", val)
+		return val
+	}
+	if c.defaultSet.keeperGasPriceBufferPercent.Valid {
+		return uint32(c.defaultSet.keeperGasPriceBufferPercent.Int64)
+	}
+	return c.GeneralConfig.KeeperGasPriceBufferPercent()
+}
+
+// KeeperGasPriceSpeed is the gas price speed keeper performs are estimated at,
+// layering an explicitly set value over the chain's keeper default and the global default
+func (c *chainScopedConfig) KeeperGasPriceSpeed() string {
+	val, ok := c.GeneralConfig.GlobalKeeperGasPriceSpeed()
+	if ok {
+		c.logEnvOverrideOnce("KeeperGasPriceSpeed", val)
+		return val
+	}
+	if c.defaultSet.keeperGasPriceSpeed.Valid {
+		return c.defaultSet.keeperGasPriceSpeed.String
+	}
+	return c.GeneralConfig.KeeperGasPriceSpeed()
+}
+
+// KeeperRegistryCheckGasOverhead is the amount of extra gas to provide checkUpkeep() calls,
+// layering an explicitly set value over the chain's keeper default and the global default
+func (c *chainScopedConfig) KeeperRegistryCheckGasOverhead() uint64 {
+	val, ok := c.GeneralConfig.GlobalKeeperRegistryCheckGasOverhead()
+	if ok {
+		c.logEnvOverrideOnce("KeeperRegistryCheckGasOverhead", val)
+		return val
+	}
+	if c.defaultSet.keeperRegistryCheckGasOverhead.Valid {
+		return uint64(c.defaultSet.keeperRegistryCheckGasOverhead.Int64)
+	}
+	return c.GeneralConfig.KeeperRegistryCheckGasOverhead()
+}
+
+// KeeperRegistryPerformGasOverhead is the amount of extra gas to provide performUpkeep() calls,
+// layering an explicitly set value over the chain's keeper default and the global default
+func (c *chainScopedConfig) KeeperRegistryPerformGasOverhead() uint64 {
+	val, ok := c.GeneralConfig.GlobalKeeperRegistryPerformGasOverhead()
+	if ok {
+		c.logEnvOverrideOnce("KeeperRegistryPerformGasOverhead", val)
+		return val
+	}
+	if c.defaultSet.keeperRegistryPerformGasOverhead.Valid {
+		return uint64(c.defaultSet.keeperRegistryPerformGasOverhead.Int64)
+	}
+	return c.GeneralConfig.KeeperRegistryPerformGasOverhead()
+}
+
 func lookupEnv(k string, parse func(string) (interface{}, error)) (interface{}, bool) {
 	s, ok := os.LookupEnv(k)
 	if ok {
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/guregu/null.v4"
 
 	"github.com/smartcontractkit/chainlink/core/assets"
 	evmconfig "github.com/smartcontractkit/chainlink/core/chains/evm/config"
@@ -125,3 +126,31 @@ func TestChainScopedConfig_Profiles(t *testing.T) {
 		})
 	}
 }
+
+func TestChainScopedConfig_KeeperProfiles(t *testing.T) {
+	t.Run("uses the global default on chains without a keeper default", func(t *testing.T) {
+		gcfg := configtest.NewTestGeneralConfig(t)
+		config := evmconfig.NewChainScopedConfig(nil, gcfg.CreateProductionLogger(), gcfg, evmtypes.Chain{ID: *utils.NewBigI(1)})
+
+		assert.Equal(t, uint32(20), config.KeeperGasPriceBufferPercent())
+		assert.Equal(t, "standard", config.KeeperGasPriceSpeed())
+	})
+
+	t.Run("uses the chain keeper default", func(t *testing.T) {
+		gcfg := configtest.NewTestGeneralConfig(t)
+		arbitrum := evmconfig.NewChainScopedConfig(nil, gcfg.CreateProductionLogger(), gcfg, evmtypes.Chain{ID: *utils.NewBigI(42161)})
+		polygon := evmconfig.NewChainScopedConfig(nil, gcfg.CreateProductionLogger(), gcfg, evmtypes.Chain{ID: *utils.NewBigI(137)})
+
+		assert.Equal(t, uint32(0), arbitrum.KeeperGasPriceBufferPercent())
+		assert.Equal(t, "fast", polygon.KeeperGasPriceSpeed())
+		assert.Equal(t, gcfg.KeeperRegistryPerformGasOverhead(), arbitrum.KeeperRegistryPerformGasOverhead())
+	})
+
+	t.Run("uses an explicitly set value over the chain keeper default", func(t *testing.T) {
+		gcfg := configtest.NewTestGeneralConfig(t)
+		gcfg.Overrides.GlobalKeeperGasPriceBufferPercent = null.IntFrom(35)
+		config := evmconfig.NewChainScopedConfig(nil, gcfg.CreateProductionLogger(), gcfg, evmtypes.Chain{ID: *utils.NewBigI(42161)})
+
+		assert.Equal(t, uint32(35), config.KeeperGasPriceBufferPercent())
+	})
+}
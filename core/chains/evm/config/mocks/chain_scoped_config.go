@@ -1766,6 +1766,90 @@ func (_m *ChainScopedConfig) GlobalGasEstimatorMode() (string, bool) {
 	return r0, r1
 }
 
+// GlobalKeeperGasPriceBufferPercent provides a mock function with given fields:
+func (_m *ChainScopedConfig) GlobalKeeperGasPriceBufferPercent() (uint32, bool) {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func() bool); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// GlobalKeeperGasPriceSpeed provides a mock function with given fields:
+func (_m *ChainScopedConfig) GlobalKeeperGasPriceSpeed() (string, bool) {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func() bool); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// GlobalKeeperRegistryCheckGasOverhead provides a mock function with given fields:
+func (_m *ChainScopedConfig) GlobalKeeperRegistryCheckGasOverhead() (uint64, bool) {
+	ret := _m.Called()
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func() bool); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// GlobalKeeperRegistryPerformGasOverhead provides a mock function with given fields:
+func (_m *ChainScopedConfig) GlobalKeeperRegistryPerformGasOverhead() (uint64, bool) {
+	ret := _m.Called()
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func() bool); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
 // GlobalLinkContractAddress provides a mock function with given fields:
 func (_m *ChainScopedConfig) GlobalLinkContractAddress() (string, bool) {
 	ret := _m.Called()
@@ -1999,8 +2083,36 @@ func (_m *ChainScopedConfig) JobPipelineResultWriteQueueDepth() uint64 {
 	return r0
 }
 
-// KeeperDefaultTransactionQueueDepth provides a mock function with given fields:
-func (_m *ChainScopedConfig) KeeperDefaultTransactionQueueDepth() uint32 {
+// KeeperAuditDecisions provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperAuditDecisions() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// KeeperAverageBlockTime provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperAverageBlockTime() time.Duration {
+	ret := _m.Called()
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
+// KeeperBaseFeeOverpaymentFactor provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperBaseFeeOverpaymentFactor() uint32 {
 	ret := _m.Called()
 
 	var r0 uint32
@@ -2013,8 +2125,22 @@ func (_m *ChainScopedConfig) KeeperDefaultTransactionQueueDepth() uint32 {
 	return r0
 }
 
-// KeeperGasPriceBufferPercent provides a mock function with given fields:
-func (_m *ChainScopedConfig) KeeperGasPriceBufferPercent() uint32 {
+// KeeperBatchCheckUpkeep provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperBatchCheckUpkeep() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// KeeperBatchCheckUpkeepSize provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperBatchCheckUpkeepSize() uint32 {
 	ret := _m.Called()
 
 	var r0 uint32
@@ -2027,22 +2153,22 @@ func (_m *ChainScopedConfig) KeeperGasPriceBufferPercent() uint32 {
 	return r0
 }
 
-// KeeperMaximumGracePeriod provides a mock function with given fields:
-func (_m *ChainScopedConfig) KeeperMaximumGracePeriod() int64 {
+// KeeperBatchMetricUpdates provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperBatchMetricUpdates() bool {
 	ret := _m.Called()
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func() int64); ok {
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
 		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(bool)
 	}
 
 	return r0
 }
 
-// KeeperMinimumRequiredConfirmations provides a mock function with given fields:
-func (_m *ChainScopedConfig) KeeperMinimumRequiredConfirmations() uint64 {
+// KeeperBlockGasLimit provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperBlockGasLimit() uint64 {
 	ret := _m.Called()
 
 	var r0 uint64
@@ -2055,36 +2181,134 @@ func (_m *ChainScopedConfig) KeeperMinimumRequiredConfirmations() uint64 {
 	return r0
 }
 
-// KeeperRegistryCheckGasOverhead provides a mock function with given fields:
-func (_m *ChainScopedConfig) KeeperRegistryCheckGasOverhead() uint64 {
+// KeeperCheckBlockTag provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperCheckBlockTag() string {
 	ret := _m.Called()
 
-	var r0 uint64
-	if rf, ok := ret.Get(0).(func() uint64); ok {
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
 		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(uint64)
+		r0 = ret.Get(0).(string)
 	}
 
 	return r0
 }
 
-// KeeperRegistryPerformGasOverhead provides a mock function with given fields:
-func (_m *ChainScopedConfig) KeeperRegistryPerformGasOverhead() uint64 {
+// KeeperClampToRegistryMaxGasPrice provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperClampToRegistryMaxGasPrice() bool {
 	ret := _m.Called()
 
-	var r0 uint64
-	if rf, ok := ret.Get(0).(func() uint64); ok {
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
 		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(uint64)
+		r0 = ret.Get(0).(bool)
 	}
 
 	return r0
 }
 
-// KeeperRegistrySyncInterval provides a mock function with given fields:
-func (_m *ChainScopedConfig) KeeperRegistrySyncInterval() time.Duration {
+// KeeperClockSkewTolerance provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperClockSkewTolerance() time.Duration {
+	ret := _m.Called()
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
+// KeeperCompactHeadLog provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperCompactHeadLog() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// KeeperDefaultTransactionQueueDepth provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperDefaultTransactionQueueDepth() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+// KeeperDryRun provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperDryRun() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// KeeperEligibilityConfirmations provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperEligibilityConfirmations() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+// KeeperEligibilityQueryRPS provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperEligibilityQueryRPS() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+// KeeperEligibilityQueryRetries provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperEligibilityQueryRetries() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+// KeeperEligibilityQueryTimeout provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperEligibilityQueryTimeout() time.Duration {
 	ret := _m.Called()
 
 	var r0 time.Duration
@@ -2097,6 +2321,828 @@ func (_m *ChainScopedConfig) KeeperRegistrySyncInterval() time.Duration {
 	return r0
 }
 
+// KeeperEligibilityStaleFallback provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperEligibilityStaleFallback() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// KeeperEstimateConfirmationBlocks provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperEstimateConfirmationBlocks() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// KeeperExcludePendingPerforms provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperExcludePendingPerforms() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// KeeperExecutionChunkSize provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperExecutionChunkSize() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+// KeeperExecutionTimeout provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperExecutionTimeout() time.Duration {
+	ret := _m.Called()
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
+// KeeperGasEstimationRetries provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperGasEstimationRetries() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+// KeeperGasEstimationRetryBackoff provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperGasEstimationRetryBackoff() time.Duration {
+	ret := _m.Called()
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
+// KeeperGasPriceBufferPercent provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperGasPriceBufferPercent() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+// KeeperGasPriceSanityMaxWei provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperGasPriceSanityMaxWei() (*big.Int, bool) {
+	ret := _m.Called()
+
+	var r0 *big.Int
+	if rf, ok := ret.Get(0).(func() *big.Int); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func() bool); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// KeeperGasPriceSanityMinWei provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperGasPriceSanityMinWei() (*big.Int, bool) {
+	ret := _m.Called()
+
+	var r0 *big.Int
+	if rf, ok := ret.Get(0).(func() *big.Int); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func() bool); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// KeeperGasPriceSpeed provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperGasPriceSpeed() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// KeeperGasReconcileEstimatorWeight provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperGasReconcileEstimatorWeight() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+// KeeperGasReconcileStrategy provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperGasReconcileStrategy() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// KeeperGracePeriodLagMultiplier provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperGracePeriodLagMultiplier() float32 {
+	ret := _m.Called()
+
+	var r0 float32
+	if rf, ok := ret.Get(0).(func() float32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(float32)
+	}
+
+	return r0
+}
+
+// KeeperGracePeriodLagThreshold provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperGracePeriodLagThreshold() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+// KeeperHeadPollFallbackInterval provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperHeadPollFallbackInterval() time.Duration {
+	ret := _m.Called()
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
+// KeeperIdleHeadThreshold provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperIdleHeadThreshold() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+// KeeperIntervalCooldown provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperIntervalCooldown() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// KeeperLogTriggerBatchSize provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperLogTriggerBatchSize() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+// KeeperLogTriggerBatchWindow provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperLogTriggerBatchWindow() time.Duration {
+	ret := _m.Called()
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
+// KeeperLowBalanceProjectionWindow provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperLowBalanceProjectionWindow() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+// KeeperLowBalanceThreshold provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperLowBalanceThreshold() *big.Int {
+	ret := _m.Called()
+
+	var r0 *big.Int
+	if rf, ok := ret.Get(0).(func() *big.Int); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+
+	return r0
+}
+
+// KeeperMailboxCapacity provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperMailboxCapacity() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+// KeeperMaxIndexLag provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperMaxIndexLag() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+// KeeperMaxPerformDataSize provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperMaxPerformDataSize() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+// KeeperMaxPerformGasLimit provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperMaxPerformGasLimit() uint64 {
+	ret := _m.Called()
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	return r0
+}
+
+// KeeperMaxProcessedHeadAge provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperMaxProcessedHeadAge() time.Duration {
+	ret := _m.Called()
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
+// KeeperMaxUpkeepStateEntries provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperMaxUpkeepStateEntries() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+// KeeperMaxUpkeepsPerBlock provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperMaxUpkeepsPerBlock() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+// KeeperMaximumBackoffBlocks provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperMaximumBackoffBlocks() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+// KeeperMaximumConcurrentExecutions provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperMaximumConcurrentExecutions() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+// KeeperMaximumGasPrice provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperMaximumGasPrice() *big.Int {
+	ret := _m.Called()
+
+	var r0 *big.Int
+	if rf, ok := ret.Get(0).(func() *big.Int); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+
+	return r0
+}
+
+// KeeperMaximumGracePeriod provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperMaximumGracePeriod() int64 {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// KeeperMaximumGracePeriodDuration provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperMaximumGracePeriodDuration() time.Duration {
+	ret := _m.Called()
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
+// KeeperMinConfirmations provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperMinConfirmations() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+// KeeperMinHeadInterval provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperMinHeadInterval() time.Duration {
+	ret := _m.Called()
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
+// KeeperMinimumBalance provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperMinimumBalance() *big.Int {
+	ret := _m.Called()
+
+	var r0 *big.Int
+	if rf, ok := ret.Get(0).(func() *big.Int); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+
+	return r0
+}
+
+// KeeperMinimumRequiredConfirmations provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperMinimumRequiredConfirmations() uint64 {
+	ret := _m.Called()
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	return r0
+}
+
+// KeeperNonceGapTolerance provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperNonceGapTolerance() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+// KeeperOverdueOrdering provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperOverdueOrdering() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// KeeperOverdueWarnHeads provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperOverdueWarnHeads() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+// KeeperPerformCadenceBlocks provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperPerformCadenceBlocks() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+// KeeperRecoverPipelinePanics provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperRecoverPipelinePanics() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// KeeperRegistryCheckGasOverhead provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperRegistryCheckGasOverhead() uint64 {
+	ret := _m.Called()
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	return r0
+}
+
+// KeeperRegistryConcurrency provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperRegistryConcurrency() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+// KeeperRegistryPerformGasOverhead provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperRegistryPerformGasOverhead() uint64 {
+	ret := _m.Called()
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	return r0
+}
+
+// KeeperRegistrySyncInterval provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperRegistrySyncInterval() time.Duration {
+	ret := _m.Called()
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
+// KeeperReportMaxBlocks provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperReportMaxBlocks() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+// KeeperShareHeadGasPrice provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperShareHeadGasPrice() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// KeeperShutdownDeadline provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperShutdownDeadline() time.Duration {
+	ret := _m.Called()
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
+// KeeperSimRevertAutoDisable provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperSimRevertAutoDisable() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// KeeperSimRevertStreakThreshold provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperSimRevertStreakThreshold() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+// KeeperSimulatePerformUpkeep provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperSimulatePerformUpkeep() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// KeeperSimulationConcurrency provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperSimulationConcurrency() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+// KeeperSkipDuplicateHeads provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperSkipDuplicateHeads() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// KeeperUseEstimatedGasLimit provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperUseEstimatedGasLimit() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// KeeperValueWeightedOrdering provides a mock function with given fields:
+func (_m *ChainScopedConfig) KeeperValueWeightedOrdering() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // KeyFile provides a mock function with given fields:
 func (_m *ChainScopedConfig) KeyFile() string {
 	ret := _m.Called()
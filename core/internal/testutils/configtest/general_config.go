@@ -70,12 +70,62 @@ type GeneralConfigOverrides struct {
 	GlobalEvmRPCDefaultBatchSize              null.Int
 	GlobalFlagsContractAddress                null.String
 	GlobalGasEstimatorMode                    null.String
+	GlobalKeeperGasPriceBufferPercent         null.Int
 	GlobalMinIncomingConfirmations            null.Int
 	GlobalMinRequiredOutgoingConfirmations    null.Int
 	GlobalMinimumContractPayment              *assets.Link
+	KeeperAuditDecisions                      null.Bool
+	KeeperAverageBlockTime                    *time.Duration
+	KeeperBatchCheckUpkeep                    null.Bool
+	KeeperBatchCheckUpkeepSize                null.Int
+	KeeperCheckBlockTag                       null.String
+	KeeperClampToRegistryMaxGasPrice          null.Bool
+	KeeperClockSkewTolerance                  *time.Duration
+	KeeperCompactHeadLog                      null.Bool
+	KeeperDryRun                              null.Bool
+	KeeperEligibilityConfirmations            null.Int
+	KeeperEligibilityQueryRetries             null.Int
+	KeeperEligibilityQueryTimeout             *time.Duration
+	KeeperEligibilityStaleFallback            null.Bool
+	KeeperExecutionChunkSize                  null.Int
+	KeeperExecutionTimeout                    *time.Duration
+	KeeperGasEstimationRetries                null.Int
+	KeeperGasEstimationRetryBackoff           *time.Duration
+	KeeperGasPriceSanityMaxWei                *big.Int
+	KeeperGasPriceSanityMinWei                *big.Int
+	KeeperGasReconcileEstimatorWeight         null.Int
+	KeeperGasReconcileStrategy                null.String
+	KeeperGracePeriodLagMultiplier            null.Float
+	KeeperGracePeriodLagThreshold             null.Int
+	KeeperHeadPollFallbackInterval            *time.Duration
+	KeeperIdleHeadThreshold                   null.Int
+	KeeperLogTriggerBatchSize                 null.Int
+	KeeperMailboxCapacity                     null.Int
+	KeeperMaxPerformDataSize                  null.Int
+	KeeperMaxPerformGasLimit                  null.Int
+	KeeperMaxProcessedHeadAge                 *time.Duration
+	KeeperMaxUpkeepStateEntries               null.Int
+	KeeperMaxUpkeepsPerBlock                  null.Int
+	KeeperMaximumBackoffBlocks                null.Int
+	KeeperMaximumConcurrentExecutions         null.Int
+	KeeperMaximumGasPrice                     *big.Int
 	KeeperMaximumGracePeriod                  null.Int
+	KeeperMaximumGracePeriodDuration          *time.Duration
+	KeeperMinHeadInterval                     *time.Duration
+	KeeperMinimumBalance                      *big.Int
 	KeeperMinimumRequiredConfirmations        null.Int
+	KeeperOverdueWarnHeads                    null.Int
+	KeeperRecoverPipelinePanics               null.Bool
+	KeeperRegistryConcurrency                 null.Int
 	KeeperRegistrySyncInterval                *time.Duration
+	KeeperReportMaxBlocks                     null.Int
+	KeeperShareHeadGasPrice                   null.Bool
+	KeeperShutdownDeadline                    *time.Duration
+	KeeperSimRevertAutoDisable                null.Bool
+	KeeperSimRevertStreakThreshold            null.Int
+	KeeperSimulatePerformUpkeep               null.Bool
+	KeeperSimulationConcurrency               null.Int
+	KeeperUseEstimatedGasLimit                null.Bool
 	LogLevel                                  *config.LogLevel
 	LogSQLStatements                          null.Bool
 	LogToDisk                                 null.Bool
@@ -374,6 +424,13 @@ func (c *TestGeneralConfig) BlockBackfillDepth() uint64 {
 	return c.GeneralConfig.BlockBackfillDepth()
 }
 
+func (c *TestGeneralConfig) KeeperOverdueWarnHeads() uint32 {
+	if c.Overrides.KeeperOverdueWarnHeads.Valid {
+		return uint32(c.Overrides.KeeperOverdueWarnHeads.Int64)
+	}
+	return c.GeneralConfig.KeeperOverdueWarnHeads()
+}
+
 func (c *TestGeneralConfig) KeeperMinimumRequiredConfirmations() uint64 {
 	if c.Overrides.KeeperMinimumRequiredConfirmations.Valid {
 		return uint64(c.Overrides.KeeperMinimumRequiredConfirmations.Int64)
@@ -388,6 +445,342 @@ func (c *TestGeneralConfig) KeeperMaximumGracePeriod() int64 {
 	return c.GeneralConfig.KeeperMaximumGracePeriod()
 }
 
+func (c *TestGeneralConfig) KeeperIdleHeadThreshold() uint32 {
+	if c.Overrides.KeeperIdleHeadThreshold.Valid {
+		return uint32(c.Overrides.KeeperIdleHeadThreshold.Int64)
+	}
+	return c.GeneralConfig.KeeperIdleHeadThreshold()
+}
+
+func (c *TestGeneralConfig) KeeperMaximumBackoffBlocks() uint32 {
+	if c.Overrides.KeeperMaximumBackoffBlocks.Valid {
+		return uint32(c.Overrides.KeeperMaximumBackoffBlocks.Int64)
+	}
+	return c.GeneralConfig.KeeperMaximumBackoffBlocks()
+}
+
+func (c *TestGeneralConfig) KeeperMaximumConcurrentExecutions() uint32 {
+	if c.Overrides.KeeperMaximumConcurrentExecutions.Valid {
+		return uint32(c.Overrides.KeeperMaximumConcurrentExecutions.Int64)
+	}
+	return c.GeneralConfig.KeeperMaximumConcurrentExecutions()
+}
+
+func (c *TestGeneralConfig) KeeperExecutionTimeout() time.Duration {
+	if c.Overrides.KeeperExecutionTimeout != nil {
+		return *c.Overrides.KeeperExecutionTimeout
+	}
+	return c.GeneralConfig.KeeperExecutionTimeout()
+}
+
+func (c *TestGeneralConfig) KeeperSimulatePerformUpkeep() bool {
+	if c.Overrides.KeeperSimulatePerformUpkeep.Valid {
+		return c.Overrides.KeeperSimulatePerformUpkeep.Bool
+	}
+	return c.GeneralConfig.KeeperSimulatePerformUpkeep()
+}
+
+func (c *TestGeneralConfig) KeeperSimulationConcurrency() uint32 {
+	if c.Overrides.KeeperSimulationConcurrency.Valid {
+		return uint32(c.Overrides.KeeperSimulationConcurrency.Int64)
+	}
+	return c.GeneralConfig.KeeperSimulationConcurrency()
+}
+
+func (c *TestGeneralConfig) KeeperMaximumGasPrice() *big.Int {
+	if c.Overrides.KeeperMaximumGasPrice != nil {
+		return c.Overrides.KeeperMaximumGasPrice
+	}
+	return c.GeneralConfig.KeeperMaximumGasPrice()
+}
+
+func (c *TestGeneralConfig) KeeperBatchCheckUpkeep() bool {
+	if c.Overrides.KeeperBatchCheckUpkeep.Valid {
+		return c.Overrides.KeeperBatchCheckUpkeep.Bool
+	}
+	return c.GeneralConfig.KeeperBatchCheckUpkeep()
+}
+
+func (c *TestGeneralConfig) KeeperBatchCheckUpkeepSize() uint32 {
+	if c.Overrides.KeeperBatchCheckUpkeepSize.Valid {
+		return uint32(c.Overrides.KeeperBatchCheckUpkeepSize.Int64)
+	}
+	return c.GeneralConfig.KeeperBatchCheckUpkeepSize()
+}
+
+func (c *TestGeneralConfig) KeeperUseEstimatedGasLimit() bool {
+	if c.Overrides.KeeperUseEstimatedGasLimit.Valid {
+		return c.Overrides.KeeperUseEstimatedGasLimit.Bool
+	}
+	return c.GeneralConfig.KeeperUseEstimatedGasLimit()
+}
+
+func (c *TestGeneralConfig) KeeperMailboxCapacity() uint32 {
+	if c.Overrides.KeeperMailboxCapacity.Valid {
+		return uint32(c.Overrides.KeeperMailboxCapacity.Int64)
+	}
+	return c.GeneralConfig.KeeperMailboxCapacity()
+}
+
+func (c *TestGeneralConfig) KeeperMaxUpkeepStateEntries() uint32 {
+	if c.Overrides.KeeperMaxUpkeepStateEntries.Valid {
+		return uint32(c.Overrides.KeeperMaxUpkeepStateEntries.Int64)
+	}
+	return c.GeneralConfig.KeeperMaxUpkeepStateEntries()
+}
+
+func (c *TestGeneralConfig) KeeperMaxPerformDataSize() uint32 {
+	if c.Overrides.KeeperMaxPerformDataSize.Valid {
+		return uint32(c.Overrides.KeeperMaxPerformDataSize.Int64)
+	}
+	return c.GeneralConfig.KeeperMaxPerformDataSize()
+}
+
+func (c *TestGeneralConfig) KeeperMaxPerformGasLimit() uint64 {
+	if c.Overrides.KeeperMaxPerformGasLimit.Valid {
+		return uint64(c.Overrides.KeeperMaxPerformGasLimit.Int64)
+	}
+	return c.GeneralConfig.KeeperMaxPerformGasLimit()
+}
+
+func (c *TestGeneralConfig) KeeperGasPriceSanityMaxWei() (*big.Int, bool) {
+	if c.Overrides.KeeperGasPriceSanityMaxWei != nil {
+		return c.Overrides.KeeperGasPriceSanityMaxWei, true
+	}
+	return c.GeneralConfig.KeeperGasPriceSanityMaxWei()
+}
+
+func (c *TestGeneralConfig) KeeperGasPriceSanityMinWei() (*big.Int, bool) {
+	if c.Overrides.KeeperGasPriceSanityMinWei != nil {
+		return c.Overrides.KeeperGasPriceSanityMinWei, true
+	}
+	return c.GeneralConfig.KeeperGasPriceSanityMinWei()
+}
+
+func (c *TestGeneralConfig) KeeperShutdownDeadline() time.Duration {
+	if c.Overrides.KeeperShutdownDeadline != nil {
+		return *c.Overrides.KeeperShutdownDeadline
+	}
+	return c.GeneralConfig.KeeperShutdownDeadline()
+}
+
+func (c *TestGeneralConfig) KeeperMaxUpkeepsPerBlock() uint32 {
+	if c.Overrides.KeeperMaxUpkeepsPerBlock.Valid {
+		return uint32(c.Overrides.KeeperMaxUpkeepsPerBlock.Int64)
+	}
+	return c.GeneralConfig.KeeperMaxUpkeepsPerBlock()
+}
+
+func (c *TestGeneralConfig) KeeperMaxProcessedHeadAge() time.Duration {
+	if c.Overrides.KeeperMaxProcessedHeadAge != nil {
+		return *c.Overrides.KeeperMaxProcessedHeadAge
+	}
+	return c.GeneralConfig.KeeperMaxProcessedHeadAge()
+}
+
+func (c *TestGeneralConfig) KeeperRecoverPipelinePanics() bool {
+	if c.Overrides.KeeperRecoverPipelinePanics.Valid {
+		return c.Overrides.KeeperRecoverPipelinePanics.Bool
+	}
+	return c.GeneralConfig.KeeperRecoverPipelinePanics()
+}
+
+func (c *TestGeneralConfig) KeeperDryRun() bool {
+	if c.Overrides.KeeperDryRun.Valid {
+		return c.Overrides.KeeperDryRun.Bool
+	}
+	return c.GeneralConfig.KeeperDryRun()
+}
+
+func (c *TestGeneralConfig) KeeperShareHeadGasPrice() bool {
+	if c.Overrides.KeeperShareHeadGasPrice.Valid {
+		return c.Overrides.KeeperShareHeadGasPrice.Bool
+	}
+	return c.GeneralConfig.KeeperShareHeadGasPrice()
+}
+
+func (c *TestGeneralConfig) KeeperGasEstimationRetries() uint32 {
+	if c.Overrides.KeeperGasEstimationRetries.Valid {
+		return uint32(c.Overrides.KeeperGasEstimationRetries.Int64)
+	}
+	return c.GeneralConfig.KeeperGasEstimationRetries()
+}
+
+func (c *TestGeneralConfig) KeeperGasEstimationRetryBackoff() time.Duration {
+	if c.Overrides.KeeperGasEstimationRetryBackoff != nil {
+		return *c.Overrides.KeeperGasEstimationRetryBackoff
+	}
+	return c.GeneralConfig.KeeperGasEstimationRetryBackoff()
+}
+
+func (c *TestGeneralConfig) KeeperClampToRegistryMaxGasPrice() bool {
+	if c.Overrides.KeeperClampToRegistryMaxGasPrice.Valid {
+		return c.Overrides.KeeperClampToRegistryMaxGasPrice.Bool
+	}
+	return c.GeneralConfig.KeeperClampToRegistryMaxGasPrice()
+}
+
+func (c *TestGeneralConfig) KeeperEligibilityQueryTimeout() time.Duration {
+	if c.Overrides.KeeperEligibilityQueryTimeout != nil {
+		return *c.Overrides.KeeperEligibilityQueryTimeout
+	}
+	return c.GeneralConfig.KeeperEligibilityQueryTimeout()
+}
+
+func (c *TestGeneralConfig) KeeperMaximumGracePeriodDuration() time.Duration {
+	if c.Overrides.KeeperMaximumGracePeriodDuration != nil {
+		return *c.Overrides.KeeperMaximumGracePeriodDuration
+	}
+	return c.GeneralConfig.KeeperMaximumGracePeriodDuration()
+}
+
+func (c *TestGeneralConfig) KeeperAverageBlockTime() time.Duration {
+	if c.Overrides.KeeperAverageBlockTime != nil {
+		return *c.Overrides.KeeperAverageBlockTime
+	}
+	return c.GeneralConfig.KeeperAverageBlockTime()
+}
+
+func (c *TestGeneralConfig) KeeperMinimumBalance() *big.Int {
+	if c.Overrides.KeeperMinimumBalance != nil {
+		return c.Overrides.KeeperMinimumBalance
+	}
+	return c.GeneralConfig.KeeperMinimumBalance()
+}
+
+func (c *TestGeneralConfig) KeeperLogTriggerBatchSize() uint32 {
+	if c.Overrides.KeeperLogTriggerBatchSize.Valid {
+		return uint32(c.Overrides.KeeperLogTriggerBatchSize.Int64)
+	}
+	return c.GeneralConfig.KeeperLogTriggerBatchSize()
+}
+
+func (c *TestGeneralConfig) KeeperRegistryConcurrency() uint32 {
+	if c.Overrides.KeeperRegistryConcurrency.Valid {
+		return uint32(c.Overrides.KeeperRegistryConcurrency.Int64)
+	}
+	return c.GeneralConfig.KeeperRegistryConcurrency()
+}
+
+func (c *TestGeneralConfig) KeeperGasReconcileStrategy() string {
+	if c.Overrides.KeeperGasReconcileStrategy.Valid {
+		return c.Overrides.KeeperGasReconcileStrategy.String
+	}
+	return c.GeneralConfig.KeeperGasReconcileStrategy()
+}
+
+func (c *TestGeneralConfig) KeeperGasReconcileEstimatorWeight() uint32 {
+	if c.Overrides.KeeperGasReconcileEstimatorWeight.Valid {
+		return uint32(c.Overrides.KeeperGasReconcileEstimatorWeight.Int64)
+	}
+	return c.GeneralConfig.KeeperGasReconcileEstimatorWeight()
+}
+
+func (c *TestGeneralConfig) KeeperEligibilityConfirmations() uint32 {
+	if c.Overrides.KeeperEligibilityConfirmations.Valid {
+		return uint32(c.Overrides.KeeperEligibilityConfirmations.Int64)
+	}
+	return c.GeneralConfig.KeeperEligibilityConfirmations()
+}
+
+func (c *TestGeneralConfig) KeeperHeadPollFallbackInterval() time.Duration {
+	if c.Overrides.KeeperHeadPollFallbackInterval != nil {
+		return *c.Overrides.KeeperHeadPollFallbackInterval
+	}
+	return c.GeneralConfig.KeeperHeadPollFallbackInterval()
+}
+
+func (c *TestGeneralConfig) KeeperClockSkewTolerance() time.Duration {
+	if c.Overrides.KeeperClockSkewTolerance != nil {
+		return *c.Overrides.KeeperClockSkewTolerance
+	}
+	return c.GeneralConfig.KeeperClockSkewTolerance()
+}
+
+func (c *TestGeneralConfig) KeeperCompactHeadLog() bool {
+	if c.Overrides.KeeperCompactHeadLog.Valid {
+		return c.Overrides.KeeperCompactHeadLog.Bool
+	}
+	return c.GeneralConfig.KeeperCompactHeadLog()
+}
+
+func (c *TestGeneralConfig) KeeperSimRevertStreakThreshold() uint32 {
+	if c.Overrides.KeeperSimRevertStreakThreshold.Valid {
+		return uint32(c.Overrides.KeeperSimRevertStreakThreshold.Int64)
+	}
+	return c.GeneralConfig.KeeperSimRevertStreakThreshold()
+}
+
+func (c *TestGeneralConfig) KeeperSimRevertAutoDisable() bool {
+	if c.Overrides.KeeperSimRevertAutoDisable.Valid {
+		return c.Overrides.KeeperSimRevertAutoDisable.Bool
+	}
+	return c.GeneralConfig.KeeperSimRevertAutoDisable()
+}
+
+func (c *TestGeneralConfig) KeeperMinHeadInterval() time.Duration {
+	if c.Overrides.KeeperMinHeadInterval != nil {
+		return *c.Overrides.KeeperMinHeadInterval
+	}
+	return c.GeneralConfig.KeeperMinHeadInterval()
+}
+
+func (c *TestGeneralConfig) KeeperCheckBlockTag() string {
+	if c.Overrides.KeeperCheckBlockTag.Valid {
+		return c.Overrides.KeeperCheckBlockTag.String
+	}
+	return c.GeneralConfig.KeeperCheckBlockTag()
+}
+
+func (c *TestGeneralConfig) KeeperAuditDecisions() bool {
+	if c.Overrides.KeeperAuditDecisions.Valid {
+		return c.Overrides.KeeperAuditDecisions.Bool
+	}
+	return c.GeneralConfig.KeeperAuditDecisions()
+}
+
+func (c *TestGeneralConfig) KeeperEligibilityQueryRetries() uint32 {
+	if c.Overrides.KeeperEligibilityQueryRetries.Valid {
+		return uint32(c.Overrides.KeeperEligibilityQueryRetries.Int64)
+	}
+	return c.GeneralConfig.KeeperEligibilityQueryRetries()
+}
+
+func (c *TestGeneralConfig) KeeperEligibilityStaleFallback() bool {
+	if c.Overrides.KeeperEligibilityStaleFallback.Valid {
+		return c.Overrides.KeeperEligibilityStaleFallback.Bool
+	}
+	return c.GeneralConfig.KeeperEligibilityStaleFallback()
+}
+
+func (c *TestGeneralConfig) KeeperGracePeriodLagThreshold() uint32 {
+	if c.Overrides.KeeperGracePeriodLagThreshold.Valid {
+		return uint32(c.Overrides.KeeperGracePeriodLagThreshold.Int64)
+	}
+	return c.GeneralConfig.KeeperGracePeriodLagThreshold()
+}
+
+func (c *TestGeneralConfig) KeeperGracePeriodLagMultiplier() float32 {
+	if c.Overrides.KeeperGracePeriodLagMultiplier.Valid {
+		return float32(c.Overrides.KeeperGracePeriodLagMultiplier.Float64)
+	}
+	return c.GeneralConfig.KeeperGracePeriodLagMultiplier()
+}
+
+func (c *TestGeneralConfig) KeeperExecutionChunkSize() uint32 {
+	if c.Overrides.KeeperExecutionChunkSize.Valid {
+		return uint32(c.Overrides.KeeperExecutionChunkSize.Int64)
+	}
+	return c.GeneralConfig.KeeperExecutionChunkSize()
+}
+
+func (c *TestGeneralConfig) KeeperReportMaxBlocks() uint32 {
+	if c.Overrides.KeeperReportMaxBlocks.Valid {
+		return uint32(c.Overrides.KeeperReportMaxBlocks.Int64)
+	}
+	return c.GeneralConfig.KeeperReportMaxBlocks()
+}
+
 func (c *TestGeneralConfig) BlockBackfillSkip() bool {
 	if c.Overrides.BlockBackfillSkip.Valid {
 		return c.Overrides.BlockBackfillSkip.Bool
@@ -430,6 +823,13 @@ func (c *TestGeneralConfig) GlobalGasEstimatorMode() (string, bool) {
 	return c.GeneralConfig.GlobalGasEstimatorMode()
 }
 
+func (c *TestGeneralConfig) GlobalKeeperGasPriceBufferPercent() (uint32, bool) {
+	if c.Overrides.GlobalKeeperGasPriceBufferPercent.Valid {
+		return uint32(c.Overrides.GlobalKeeperGasPriceBufferPercent.Int64), true
+	}
+	return c.GeneralConfig.GlobalKeeperGasPriceBufferPercent()
+}
+
 func (c *TestGeneralConfig) GlobalEvmNonceAutoSync() (bool, bool) {
 	if c.Overrides.GlobalEvmNonceAutoSync.Valid {
 		return c.Overrides.GlobalEvmNonceAutoSync.Bool, true
@@ -332,3 +332,46 @@ func TestEthClient_SendTransaction_WithSecondaryURLs(t *testing.T) {
 		return len(requests)
 	}).Should(gomega.Equal(2))
 }
+
+func TestEthClient_SendTransaction_FallsBackToSecondaryURLs(t *testing.T) {
+	t.Parallel()
+
+	tx := types.NewTransaction(uint64(42), cltest.NewAddress(), big.NewInt(142), 242, big.NewInt(342), []byte{1, 2, 3})
+
+	_, wsUrl, cleanup := cltest.NewWSServer(`{
+  "id": 1,
+  "jsonrpc": "2.0",
+  "error": {"code": -32000, "message": "upstream request timeout"}
+}`, func(data []byte) {})
+	defer cleanup()
+
+	newSendonlyURL := func(response string) url.URL {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := w.Write([]byte(response))
+			require.NoError(t, err)
+		})
+		server := httptest.NewServer(handler)
+		t.Cleanup(server.Close)
+		return *cltest.MustParseURL(server.URL)
+	}
+
+	t.Run("succeeds if a secondary node accepts the transaction", func(t *testing.T) {
+		sendonlyUrl := newSendonlyURL(`{"id": 1, "jsonrpc": "2.0", "result": "` + tx.Hash().Hex() + `"}`)
+		ethClient, err := eth.NewClient(logger.Default, wsUrl, nil, []url.URL{sendonlyUrl}, nil)
+		require.NoError(t, err)
+		require.NoError(t, ethClient.Dial(context.Background()))
+
+		assert.NoError(t, ethClient.SendTransaction(context.Background(), tx))
+	})
+
+	t.Run("returns the main node error if no secondary node accepts the transaction", func(t *testing.T) {
+		sendonlyUrl := newSendonlyURL(`{"id": 1, "jsonrpc": "2.0", "error": {"code": -32000, "message": "upstream request timeout"}}`)
+		ethClient, err := eth.NewClient(logger.Default, wsUrl, nil, []url.URL{sendonlyUrl}, nil)
+		require.NoError(t, err)
+		require.NoError(t, ethClient.Dial(context.Background()))
+
+		err = ethClient.SendTransaction(context.Background(), tx)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "upstream request timeout")
+	})
+}
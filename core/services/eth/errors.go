@@ -114,6 +114,19 @@ func (s *SendError) is(errorType int) bool {
 	return false
 }
 
+// isClassified returns true if the error matches any known node rejection reason
+func (s *SendError) isClassified() bool {
+	if s.Fatal() {
+		return true
+	}
+	for errorType := NonceTooLow; errorType <= Fatal; errorType++ {
+		if s.is(errorType) {
+			return true
+		}
+	}
+	return false
+}
+
 var hexDataRegex = regexp.MustCompile(`0x\w+$`)
 
 // IsReplacementUnderpriced indicates that a transaction already exists in the mempool with this nonce but a different gas price or payload
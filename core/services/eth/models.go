@@ -28,6 +28,9 @@ type Head struct {
 	EVMChainID    *utils.Big `gorm:"column:evm_chain_id"`
 	Timestamp     time.Time
 	CreatedAt     time.Time
+	BaseFeePerGas *utils.Big
+	// GasLimit is the block gas limit, it isn't persisted so heads loaded from the db have zero
+	GasLimit uint64 `gorm:"-"`
 }
 
 // NewHead returns a Head instance.
@@ -154,6 +157,8 @@ func (h *Head) UnmarshalJSON(bs []byte) error {
 		ParentHash    common.Hash    `json:"parentHash"`
 		Timestamp     hexutil.Uint64 `json:"timestamp"`
 		L1BlockNumber *hexutil.Big   `json:"l1BlockNumber"`
+		BaseFeePerGas *hexutil.Big   `json:"baseFeePerGas"`
+		GasLimit      hexutil.Uint64 `json:"gasLimit"`
 	}
 
 	var jsonHead head
@@ -174,15 +179,21 @@ func (h *Head) UnmarshalJSON(bs []byte) error {
 	if jsonHead.L1BlockNumber != nil {
 		h.L1BlockNumber = null.Int64From((*big.Int)(jsonHead.L1BlockNumber).Int64())
 	}
+	if jsonHead.BaseFeePerGas != nil {
+		h.BaseFeePerGas = utils.NewBig((*big.Int)(jsonHead.BaseFeePerGas))
+	}
+	h.GasLimit = uint64(jsonHead.GasLimit)
 	return nil
 }
 
 func (h *Head) MarshalJSON() ([]byte, error) {
 	type head struct {
-		Hash       *common.Hash    `json:"hash,omitempty"`
-		Number     *hexutil.Big    `json:"number,omitempty"`
-		ParentHash *common.Hash    `json:"parentHash,omitempty"`
-		Timestamp  *hexutil.Uint64 `json:"timestamp,omitempty"`
+		Hash          *common.Hash    `json:"hash,omitempty"`
+		Number        *hexutil.Big    `json:"number,omitempty"`
+		ParentHash    *common.Hash    `json:"parentHash,omitempty"`
+		Timestamp     *hexutil.Uint64 `json:"timestamp,omitempty"`
+		BaseFeePerGas *hexutil.Big    `json:"baseFeePerGas,omitempty"`
+		GasLimit      *hexutil.Uint64 `json:"gasLimit,omitempty"`
 	}
 
 	var jsonHead head
@@ -197,6 +208,13 @@ func (h *Head) MarshalJSON() ([]byte, error) {
 		t := hexutil.Uint64(h.Timestamp.UTC().Unix())
 		jsonHead.Timestamp = &t
 	}
+	if h.BaseFeePerGas != nil {
+		jsonHead.BaseFeePerGas = (*hexutil.Big)(h.BaseFeePerGas.ToInt())
+	}
+	if h.GasLimit != 0 {
+		gasLimit := hexutil.Uint64(h.GasLimit)
+		jsonHead.GasLimit = &gasLimit
+	}
 	return json.Marshal(jsonHead)
 }
 
@@ -93,6 +93,7 @@ func (p *Pool) SendTransaction(ctx context.Context, tx *types.Transaction) error
 	defer wg.Wait()
 
 	main := p.getRoundRobin()
+	var accepted atomic.Bool
 	var all []SendOnlyNode
 	for _, n := range p.nodes {
 		all = append(all, n)
@@ -108,6 +109,9 @@ func (p *Pool) SendTransaction(ctx context.Context, tx *types.Transaction) error
 		go func(n SendOnlyNode) {
 			defer wg.Done()
 			err := NewSendError(n.SendTransaction(ctx, tx))
+			if err == nil || err.IsTransactionAlreadyInMempool() {
+				accepted.Store(true)
+			}
 			if err == nil || err.IsNonceTooLowError() || err.IsTransactionAlreadyInMempool() {
 				// Nonce too low or transaction known errors are expected since
 				// the primary SendTransaction may well have succeeded already
@@ -117,7 +121,18 @@ func (p *Pool) SendTransaction(ctx context.Context, tx *types.Transaction) error
 		}(n)
 	}
 
-	return main.SendTransaction(ctx, tx)
+	err := main.SendTransaction(ctx, tx)
+	if sendErr := NewSendError(err); sendErr != nil && !sendErr.isClassified() {
+		// The main node did not reject the transaction but failed to send it, e.g. because the
+		// connection dropped or timed out. If any other node accepted it, the broadcast succeeded.
+		wg.Wait()
+		if accepted.Load() {
+			p.logger.Warnw("eth client failed to send transaction through main node, but another node accepted it",
+				"name", main.String(), "err", err, "tx", tx)
+			return nil
+		}
+	}
+	return err
 }
 
 func (p *Pool) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
@@ -3,6 +3,7 @@ package gas
 import (
 	"context"
 	"fmt"
+	"math"
 	"math/big"
 	"sort"
 	"sync"
@@ -17,6 +18,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/services/eth"
 	"github.com/smartcontractkit/chainlink/core/utils"
+	bigmath "github.com/smartcontractkit/chainlink/core/utils/big_math"
 )
 
 const (
@@ -45,7 +47,12 @@ var (
 	)
 )
 
-var _ Estimator = &BlockHistoryEstimator{}
+var (
+	_ Estimator             = &BlockHistoryEstimator{}
+	_ TieredEstimator       = &BlockHistoryEstimator{}
+	_ ConfidenceEstimator   = &BlockHistoryEstimator{}
+	_ ConfirmationEstimator = &BlockHistoryEstimator{}
+)
 
 //go:generate mockery --name Config --output ./mocks/ --case=underscore
 type (
@@ -60,8 +67,12 @@ type (
 		ctx                 context.Context
 		ctxCancel           context.CancelFunc
 
-		gasPrice   *big.Int
-		gasPriceMu sync.RWMutex
+		gasPrice      *big.Int
+		tierGasPrices map[Speed]*big.Int
+		confidence    *float64
+		// blockMinGasPrices holds the lowest gas price included in each block of the history
+		blockMinGasPrices []*big.Int
+		gasPriceMu        sync.RWMutex
 
 		logger logger.Logger
 	}
@@ -83,6 +94,9 @@ func NewBlockHistoryEstimator(lggr logger.Logger, ethClient eth.Client, config C
 		ctx,
 		cancel,
 		nil,
+		nil,
+		nil,
+		nil,
 		sync.RWMutex{},
 		lggr.With("id", "block_history_estimator"),
 	}
@@ -145,6 +159,50 @@ func (b *BlockHistoryEstimator) EstimateGas(_ []byte, gasLimit uint64, _ ...Opt)
 	return
 }
 
+// EstimateGasForSpeed returns the gas price for the given speed tier, falling back to the
+// standard price if the tier has not been calculated yet
+func (b *BlockHistoryEstimator) EstimateGasForSpeed(calldata []byte, gasLimit uint64, speed Speed, opts ...Opt) (gasPrice *big.Int, chainSpecificGasLimit uint64, err error) {
+	gasPrice, chainSpecificGasLimit, err = b.EstimateGas(calldata, gasLimit, opts...)
+	if err != nil || speed == SpeedStandard {
+		return
+	}
+	b.gasPriceMu.RLock()
+	defer b.gasPriceMu.RUnlock()
+	if tierGasPrice, exists := b.tierGasPrices[speed]; exists {
+		gasPrice = tierGasPrice
+	}
+	return
+}
+
+// GasPriceConfidence is derived from the spread of gas prices in the block history,
+// and is lower the more prices vary relative to their mean
+func (b *BlockHistoryEstimator) GasPriceConfidence() (float64, bool) {
+	b.gasPriceMu.RLock()
+	defer b.gasPriceMu.RUnlock()
+	if b.confidence == nil {
+		return 0, false
+	}
+	return *b.confidence, true
+}
+
+// EstimateConfirmationBlocks is derived from the share of blocks in the history that included a transaction
+// paying no more than gasPrice: if one in n blocks did, a transaction paying gasPrice is expected to be included
+// within n blocks. It returns false if no block in the history included a transaction that cheap.
+func (b *BlockHistoryEstimator) EstimateConfirmationBlocks(gasPrice *big.Int) (uint64, bool) {
+	b.gasPriceMu.RLock()
+	defer b.gasPriceMu.RUnlock()
+	included := 0
+	for _, minGasPrice := range b.blockMinGasPrices {
+		if minGasPrice.Cmp(gasPrice) <= 0 {
+			included++
+		}
+	}
+	if included == 0 {
+		return 0, false
+	}
+	return uint64(math.Ceil(float64(len(b.blockMinGasPrices)) / float64(included))), true
+}
+
 func (b *BlockHistoryEstimator) BumpGas(originalGasPrice *big.Int, gasLimit uint64) (bumpedGasPrice *big.Int, chainSpecificGasLimit uint64, err error) {
 	return BumpGasPriceOnly(b.config, originalGasPrice, gasLimit)
 }
@@ -217,6 +275,19 @@ func (b *BlockHistoryEstimator) Recalculate(head eth.Head) {
 	)
 	b.setPercentileGasPrice(percentileGasPrice)
 	promBlockHistoryEstimatorSetGasPrice.WithLabelValues(fmt.Sprintf("%v%%", percentile), b.chainID.String()).Set(float64(percentileGasPrice.Int64()))
+
+	tierGasPrices := make(map[Speed]*big.Int)
+	for speed, tierPercentile := range map[Speed]int{
+		SpeedEconomy: percentile / 2,
+		SpeedFast:    percentile + (100-percentile)/2,
+	} {
+		if tierGasPrice, err := b.percentileGasPrice(tierPercentile); err == nil {
+			tierGasPrices[speed] = tierGasPrice
+		}
+	}
+	b.setTierGasPrices(tierGasPrices)
+	b.setConfidence(gasPriceConfidence(b.usableGasPrices()))
+	b.setBlockMinGasPrices(b.minGasPricesPerBlock())
 }
 
 func (b *BlockHistoryEstimator) FetchBlocks(ctx context.Context, head eth.Head) error {
@@ -340,7 +411,7 @@ var (
 	ErrNoSuitableTransactions = errors.New("no suitable transactions")
 )
 
-func (b *BlockHistoryEstimator) percentileGasPrice(percentile int) (*big.Int, error) {
+func (b *BlockHistoryEstimator) usableGasPrices() []*big.Int {
 	minGasPriceWei := b.config.EvmMinGasPriceWei()
 	gasPrices := make([]*big.Int, 0)
 	for _, block := range b.rollingBlockHistory {
@@ -356,6 +427,11 @@ func (b *BlockHistoryEstimator) percentileGasPrice(percentile int) (*big.Int, er
 			}
 		}
 	}
+	return gasPrices
+}
+
+func (b *BlockHistoryEstimator) percentileGasPrice(percentile int) (*big.Int, error) {
+	gasPrices := b.usableGasPrices()
 	if len(gasPrices) == 0 {
 		return big.NewInt(0), ErrNoSuitableTransactions
 	}
@@ -385,6 +461,79 @@ func (b *BlockHistoryEstimator) setPercentileGasPrice(gasPrice *big.Int) {
 	}
 }
 
+// setTierGasPrices stores the economy and fast gas prices, bounded by the same
+// limits as the standard gas price
+func (b *BlockHistoryEstimator) setTierGasPrices(tierGasPrices map[Speed]*big.Int) {
+	max := b.config.EvmMaxGasPriceWei()
+	min := b.config.EvmMinGasPriceWei()
+	for speed, gasPrice := range tierGasPrices {
+		tierGasPrices[speed] = bigmath.Max(bigmath.Min(gasPrice, max), min)
+	}
+
+	b.gasPriceMu.Lock()
+	defer b.gasPriceMu.Unlock()
+	b.tierGasPrices = tierGasPrices
+}
+
+func (b *BlockHistoryEstimator) setConfidence(confidence *float64) {
+	b.gasPriceMu.Lock()
+	defer b.gasPriceMu.Unlock()
+	b.confidence = confidence
+}
+
+func (b *BlockHistoryEstimator) setBlockMinGasPrices(blockMinGasPrices []*big.Int) {
+	b.gasPriceMu.Lock()
+	defer b.gasPriceMu.Unlock()
+	b.blockMinGasPrices = blockMinGasPrices
+}
+
+// minGasPricesPerBlock returns the lowest usable gas price of each block in the history,
+// skipping blocks without usable transactions
+func (b *BlockHistoryEstimator) minGasPricesPerBlock() []*big.Int {
+	minGasPriceWei := b.config.EvmMinGasPriceWei()
+	var minGasPrices []*big.Int
+	for _, block := range b.rollingBlockHistory {
+		var blockMin *big.Int
+		for _, tx := range block.Transactions {
+			if !isUsableTx(tx, minGasPriceWei, &b.chainID) {
+				continue
+			}
+			if gasPrice := b.EffectiveGasPrice(block, tx); gasPrice != nil && (blockMin == nil || gasPrice.Cmp(blockMin) < 0) {
+				blockMin = gasPrice
+			}
+		}
+		if blockMin != nil {
+			minGasPrices = append(minGasPrices, blockMin)
+		}
+	}
+	return minGasPrices
+}
+
+// gasPriceConfidence maps the coefficient of variation of gasPrices onto (0, 1],
+// returning nil if there are no prices to judge
+func gasPriceConfidence(gasPrices []*big.Int) *float64 {
+	if len(gasPrices) == 0 {
+		return nil
+	}
+	values := make([]float64, len(gasPrices))
+	var mean float64
+	for i, gasPrice := range gasPrices {
+		values[i], _ = new(big.Float).SetInt(gasPrice).Float64()
+		mean += values[i]
+	}
+	mean /= float64(len(values))
+	confidence := 1.0
+	if mean > 0 {
+		var variance float64
+		for _, v := range values {
+			variance += (v - mean) * (v - mean)
+		}
+		variance /= float64(len(values))
+		confidence = 1 / (1 + math.Sqrt(variance)/mean)
+	}
+	return &confidence
+}
+
 func (b *BlockHistoryEstimator) RollingBlockHistory() []Block {
 	return b.rollingBlockHistory
 }
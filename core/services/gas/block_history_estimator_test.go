@@ -664,6 +664,139 @@ func TestBlockHistoryEstimator_Recalculate(t *testing.T) {
 		ethClient.AssertExpectations(t)
 		config.AssertExpectations(t)
 	})
+
+	t.Run("reports lower confidence the more gas prices vary", func(t *testing.T) {
+		ethClient := cltest.NewEthClientMockWithDefaultChain(t)
+		config := new(gumocks.Config)
+
+		config.On("EvmMaxGasPriceWei").Return(maxGasPrice)
+		config.On("EvmMinGasPriceWei").Return(minGasPrice)
+		config.On("BlockHistoryEstimatorTransactionPercentile").Return(uint16(35))
+
+		ibhe := newBlockHistoryEstimator(ethClient, config)
+		bhe := gas.BlockHistoryEstimatorFromInterface(ibhe)
+
+		_, ok := bhe.GasPriceConfidence()
+		require.False(t, ok)
+
+		gas.SetRollingBlockHistory(bhe, []gas.Block{{
+			Number:       0,
+			Hash:         utils.NewHash(),
+			Transactions: cltest.TransactionsFromGasPrices(50, 50, 50),
+		}})
+		bhe.Recalculate(*cltest.Head(0))
+
+		confidence, ok := bhe.GasPriceConfidence()
+		require.True(t, ok)
+		require.Equal(t, float64(1), confidence)
+
+		gas.SetRollingBlockHistory(bhe, []gas.Block{{
+			Number:       0,
+			Hash:         utils.NewHash(),
+			Transactions: cltest.TransactionsFromGasPrices(20, 50, 90),
+		}})
+		bhe.Recalculate(*cltest.Head(0))
+
+		dispersedConfidence, ok := bhe.GasPriceConfidence()
+		require.True(t, ok)
+		require.Less(t, dispersedConfidence, confidence)
+		require.Greater(t, dispersedConfidence, float64(0))
+
+		ethClient.AssertExpectations(t)
+	})
+
+	t.Run("estimates confirmation blocks from the cheapest transaction of each block", func(t *testing.T) {
+		ethClient := cltest.NewEthClientMockWithDefaultChain(t)
+		config := new(gumocks.Config)
+
+		config.On("EvmMaxGasPriceWei").Return(maxGasPrice)
+		config.On("EvmMinGasPriceWei").Return(minGasPrice)
+		config.On("BlockHistoryEstimatorTransactionPercentile").Return(uint16(35))
+
+		ibhe := newBlockHistoryEstimator(ethClient, config)
+		bhe := gas.BlockHistoryEstimatorFromInterface(ibhe)
+
+		_, ok := bhe.EstimateConfirmationBlocks(big.NewInt(100))
+		require.False(t, ok)
+
+		gas.SetRollingBlockHistory(bhe, []gas.Block{
+			{Number: 0, Hash: utils.NewHash(), Transactions: cltest.TransactionsFromGasPrices(20, 90)},
+			{Number: 1, Hash: utils.NewHash(), Transactions: cltest.TransactionsFromGasPrices(40, 60)},
+			{Number: 2, Hash: utils.NewHash(), Transactions: cltest.TransactionsFromGasPrices(60, 80)},
+			{Number: 3, Hash: utils.NewHash(), Transactions: cltest.TransactionsFromGasPrices(80, 100)},
+		})
+		bhe.Recalculate(*cltest.Head(3))
+
+		blocks, ok := bhe.EstimateConfirmationBlocks(big.NewInt(80))
+		require.True(t, ok)
+		require.Equal(t, uint64(1), blocks)
+
+		blocks, ok = bhe.EstimateConfirmationBlocks(big.NewInt(40))
+		require.True(t, ok)
+		require.Equal(t, uint64(2), blocks)
+
+		blocks, ok = bhe.EstimateConfirmationBlocks(big.NewInt(30))
+		require.True(t, ok)
+		require.Equal(t, uint64(4), blocks)
+
+		_, ok = bhe.EstimateConfirmationBlocks(big.NewInt(10))
+		require.False(t, ok)
+
+		ethClient.AssertExpectations(t)
+	})
+}
+
+func TestBlockHistoryEstimator_RecalculateTierGasPrices(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                    string
+		gasPrices               [][]int64
+		maxGasPrice             int64
+		economy, standard, fast int64
+	}{
+		{"spread prices", [][]int64{{10, 20, 30}, {40, 50, 60}, {70, 80, 90, 100}}, 1000, 20, 40, 70},
+		{"uniform prices", [][]int64{{50, 50}, {50, 50}}, 1000, 50, 50, 50},
+		{"single transaction", [][]int64{{42}}, 1000, 42, 42, 42},
+		{"prices above the maximum", [][]int64{{200, 300}, {400, 500}}, 250, 200, 250, 250},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			ethClient := cltest.NewEthClientMockWithDefaultChain(t)
+			config := new(gumocks.Config)
+
+			config.On("EvmMaxGasPriceWei").Return(big.NewInt(test.maxGasPrice))
+			config.On("EvmMinGasPriceWei").Return(big.NewInt(1))
+			config.On("BlockHistoryEstimatorTransactionPercentile").Return(uint16(35))
+
+			bhe := gas.BlockHistoryEstimatorFromInterface(newBlockHistoryEstimator(ethClient, config))
+
+			var blocks []gas.Block
+			for i, prices := range test.gasPrices {
+				blocks = append(blocks, gas.Block{
+					Number:       int64(i),
+					Hash:         utils.NewHash(),
+					Transactions: cltest.TransactionsFromGasPrices(prices...),
+				})
+			}
+			gas.SetRollingBlockHistory(bhe, blocks)
+			bhe.Recalculate(*cltest.Head(len(blocks) - 1))
+
+			economy := gas.GetTierGasPrice(bhe, gas.SpeedEconomy)
+			standard := gas.GetGasPrice(bhe)
+			fast := gas.GetTierGasPrice(bhe, gas.SpeedFast)
+			require.NotNil(t, economy)
+			require.NotNil(t, fast)
+
+			assert.Equal(t, big.NewInt(test.economy), economy)
+			assert.Equal(t, big.NewInt(test.standard), standard)
+			assert.Equal(t, big.NewInt(test.fast), fast)
+			assert.LessOrEqual(t, economy.Cmp(standard), 0, "economy must not exceed standard")
+			assert.LessOrEqual(t, standard.Cmp(fast), 0, "standard must not exceed fast")
+		})
+	}
 }
 
 func TestBlockHistoryEstimator_EffectiveGasPrice(t *testing.T) {
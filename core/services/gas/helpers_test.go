@@ -15,3 +15,9 @@ func GetGasPrice(b *BlockHistoryEstimator) *big.Int {
 	defer b.gasPriceMu.Unlock()
 	return b.gasPrice
 }
+
+func GetTierGasPrice(b *BlockHistoryEstimator, speed Speed) *big.Int {
+	b.gasPriceMu.RLock()
+	defer b.gasPriceMu.RUnlock()
+	return b.tierGasPrices[speed]
+}
@@ -46,6 +46,47 @@ type Estimator interface {
 	BumpGas(originalGasPrice *big.Int, gasLimit uint64) (bumpedGasPrice *big.Int, chainSpecificGasLimit uint64, err error)
 }
 
+// Speed is the gas price tier an estimator can be asked to quote for
+type Speed string
+
+const (
+	// SpeedEconomy quotes a gas price below the configured percentile, for transactions that can wait
+	SpeedEconomy Speed = "economy"
+	// SpeedStandard quotes the regular gas price returned by EstimateGas
+	SpeedStandard Speed = "standard"
+	// SpeedFast quotes a gas price above the configured percentile, for transactions that must land quickly
+	SpeedFast Speed = "fast"
+)
+
+// IsValid returns true if s is a known speed tier
+func (s Speed) IsValid() bool {
+	switch s {
+	case SpeedEconomy, SpeedStandard, SpeedFast:
+		return true
+	default:
+		return false
+	}
+}
+
+// TieredEstimator is implemented by estimators that can quote a different gas price per speed tier
+type TieredEstimator interface {
+	EstimateGasForSpeed(calldata []byte, gasLimit uint64, speed Speed, opts ...Opt) (gasPrice *big.Int, chainSpecificGasLimit uint64, err error)
+}
+
+// ConfidenceEstimator is implemented by estimators that can report how reliable their current estimate is
+type ConfidenceEstimator interface {
+	// GasPriceConfidence returns a value between 0 (no confidence) and 1 (full confidence),
+	// or false if the estimator has no estimate yet
+	GasPriceConfidence() (confidence float64, ok bool)
+}
+
+// ConfirmationEstimator is implemented by estimators that can estimate how soon a transaction is confirmed
+type ConfirmationEstimator interface {
+	// EstimateConfirmationBlocks returns the expected number of blocks until a transaction paying gasPrice
+	// is included, or false if the estimator can't tell
+	EstimateConfirmationBlocks(gasPrice *big.Int) (blocks uint64, ok bool)
+}
+
 // Opt is an option for a gas estimator
 type Opt int
 
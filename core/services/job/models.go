@@ -1,6 +1,8 @@
 package job
 
 import (
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -8,6 +10,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/lib/pq"
+	"github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
 	"gopkg.in/guregu/null.v4"
 	"gorm.io/gorm"
@@ -348,10 +351,104 @@ type FluxMonitorSpec struct {
 type KeeperSpec struct {
 	ID              int32               `toml:"-" gorm:"primary_key"`
 	ContractAddress ethkey.EIP55Address `toml:"contractAddress"`
-	FromAddress     ethkey.EIP55Address `toml:"fromAddress"`
-	EVMChainID      *utils.Big          `toml:"evmChainID" gorm:"column:evm_chain_id"`
-	CreatedAt       time.Time           `toml:"-"`
-	UpdatedAt       time.Time           `toml:"-"`
+	// ContractAddresses lists further registries serviced by the job along with ContractAddress
+	ContractAddresses KeeperRegistryAddresses `toml:"contractAddresses" gorm:"type:jsonb"`
+	FromAddress       ethkey.EIP55Address     `toml:"fromAddress"`
+	EVMChainID        *utils.Big              `toml:"evmChainID" gorm:"column:evm_chain_id"`
+	UpkeepOverrides   KeeperUpkeepOverrides   `toml:"upkeepOverrides" gorm:"type:jsonb"`
+	// RegistryCheckGasOverhead and RegistryPerformGasOverhead override the node wide
+	// KEEPER_REGISTRY_*_GAS_OVERHEAD values for registries whose version needs different overheads
+	RegistryCheckGasOverhead   *uint64 `toml:"registryCheckGasOverhead"`
+	RegistryPerformGasOverhead *uint64 `toml:"registryPerformGasOverhead"`
+	// RegistryVersion is the KeeperRegistry version of the registries of the job, e.g. "1.2".
+	// If empty the registries are assumed to be KeeperRegistry 1.1.
+	RegistryVersion string    `toml:"registryVersion"`
+	CreatedAt       time.Time `toml:"-"`
+	UpdatedAt       time.Time `toml:"-"`
+}
+
+// RegistryAddresses returns the addresses of every registry serviced by the job, ContractAddress first
+func (s KeeperSpec) RegistryAddresses() []ethkey.EIP55Address {
+	addresses := make([]ethkey.EIP55Address, 0, len(s.ContractAddresses)+1)
+	addresses = append(addresses, s.ContractAddress)
+	for _, address := range s.ContractAddresses {
+		if address.Address() != s.ContractAddress.Address() {
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses
+}
+
+// KeeperRegistryAddresses holds the addresses of the additional registries of a keeper job
+type KeeperRegistryAddresses []ethkey.EIP55Address
+
+func (a *KeeperRegistryAddresses) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.Errorf("KeeperRegistryAddresses#Scan received a value of type %T", value)
+	}
+	return json.Unmarshal(bytes, a)
+}
+
+func (a KeeperRegistryAddresses) Value() (driver.Value, error) {
+	if len(a) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(a)
+}
+
+// KeeperUpkeepOverrides holds operator supplied settings for individual upkeeps, keyed by upkeep ID
+type KeeperUpkeepOverrides map[string]KeeperUpkeepOverride
+
+// KeeperUpkeepOverride customises how a keeper job services a single upkeep
+type KeeperUpkeepOverride struct {
+	GasPriceSpeed string `toml:"gasPriceSpeed" json:"gasPriceSpeed,omitempty"`
+	// PerformWindows restricts performing to the given daily UTC time ranges, formatted as "HH:MM-HH:MM"
+	PerformWindows []string `toml:"performWindows" json:"performWindows,omitempty"`
+	// MaxGasPriceWei caps the gas price paid for performing the upkeep, below KEEPER_MAXIMUM_GAS_PRICE.
+	// If SkipAboveMaxGasPrice is set, the upkeep isn't performed while the unbuffered estimate exceeds it.
+	MaxGasPriceWei       *uint64 `toml:"maxGasPriceWei" json:"maxGasPriceWei,omitempty"`
+	SkipAboveMaxGasPrice bool    `toml:"skipAboveMaxGasPrice" json:"skipAboveMaxGasPrice,omitempty"`
+	// GasPriceBufferPercent replaces KEEPER_GAS_PRICE_BUFFER_PERCENT for the upkeep, e.g. to bid more
+	// aggressively for latency critical upkeeps
+	GasPriceBufferPercent *uint32 `toml:"gasPriceBufferPercent" json:"gasPriceBufferPercent,omitempty"`
+	// LogTrigger makes the upkeep log-triggered: besides being checked on new heads, it's executed
+	// whenever a log matching the filter is emitted
+	LogTrigger *KeeperLogTrigger `toml:"logTrigger" json:"logTrigger,omitempty"`
+}
+
+// KeeperLogTrigger is the log filter of a log-triggered upkeep. Topics lists the hex encoded topics
+// the log must have, by position, starting with the event signature.
+type KeeperLogTrigger struct {
+	Address ethkey.EIP55Address `toml:"address" json:"address"`
+	Topics  []string            `toml:"topics" json:"topics,omitempty"`
+}
+
+// ForUpkeep returns the override configured for the given upkeep, if any
+func (o KeeperUpkeepOverrides) ForUpkeep(upkeepID int64) (KeeperUpkeepOverride, bool) {
+	override, exists := o[strconv.FormatInt(upkeepID, 10)]
+	return override, exists
+}
+
+func (o *KeeperUpkeepOverrides) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.Errorf("KeeperUpkeepOverrides#Scan received a value of type %T", value)
+	}
+	return json.Unmarshal(bytes, o)
+}
+
+func (o KeeperUpkeepOverrides) Value() (driver.Value, error) {
+	if len(o) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(o)
 }
 
 type VRFSpec struct {
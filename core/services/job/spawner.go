@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"go.uber.org/multierr"
 	"gopkg.in/guregu/null.v4"
 
 	"github.com/smartcontractkit/chainlink/core/logger"
@@ -98,6 +99,30 @@ func (js *spawner) Close() error {
 	})
 }
 
+// Healthy returns an error if the spawner isn't running or a service of an
+// active job reports itself unhealthy, so that job health shows up in the
+// health checks of the node
+func (js *spawner) Healthy() error {
+	if err := js.StartStopOnce.Healthy(); err != nil {
+		return err
+	}
+	js.activeJobsMu.RLock()
+	defer js.activeJobsMu.RUnlock()
+	var merr error
+	for jobID, aj := range js.activeJobs {
+		for _, service := range aj.services {
+			checkable, ok := service.(interface{ Healthy() error })
+			if !ok {
+				continue
+			}
+			if err := checkable.Healthy(); err != nil {
+				merr = multierr.Append(merr, errors.Wrapf(err, "job %d", jobID))
+			}
+		}
+	}
+	return merr
+}
+
 func (js *spawner) startAllServices() {
 	// TODO: rename to find AllJobs
 	specs, _, err := js.orm.JobsV2(0, math.MaxUint32)
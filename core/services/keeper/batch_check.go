@@ -0,0 +1,145 @@
+package keeper
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+// batchCheckUpkeeps calls checkUpkeep for upkeeps on head in JSON-RPC batches of KeeperBatchCheckUpkeepSize calls,
+// each call priced at the estimated gas price of the upkeep's perform. It returns the upkeeps that passed the
+// check along with their results, keyed by upkeep. The upkeeps of a batch request that failed as a whole, the
+// upkeeps whose call failed at the RPC level and the upkeeps whose gas price couldn't be estimated are returned
+// unchecked, execute checks them on its own.
+func (ex *UpkeepExecuter) batchCheckUpkeeps(head eth.Head, upkeeps []UpkeepRegistration, gasPrices *headGasPrices) ([]UpkeepRegistration, map[upkeepKey]checkUpkeepResult) {
+	ctx, cancel := utils.ContextFromChan(ex.chStop)
+	defer cancel()
+
+	batchSize := int(ex.config.KeeperBatchCheckUpkeepSize())
+	if batchSize <= 0 {
+		batchSize = len(upkeeps)
+	}
+	blockTag := CheckBlockTagLatest
+	if ex.checkBlockNumber() != nil {
+		blockTag = CheckBlockTagPending
+	}
+
+	passed := make([]UpkeepRegistration, 0, len(upkeeps))
+	results := make(map[upkeepKey]checkUpkeepResult, len(upkeeps))
+	for start := 0; start < len(upkeeps); start += batchSize {
+		end := start + batchSize
+		if end > len(upkeeps) {
+			end = len(upkeeps)
+		}
+		passed = append(passed, ex.checkUpkeepBatch(ctx, head, upkeeps[start:end], blockTag, gasPrices, results)...)
+	}
+
+	if dropped := len(upkeeps) - len(passed); dropped > 0 {
+		ex.logger.Debugw("batched checkUpkeep excluded upkeeps not needing a perform",
+			"blockheight", head.Number, "upkeeps", len(upkeeps), "excluded", dropped)
+	}
+	return passed, results
+}
+
+// checkUpkeepBatch sends the checkUpkeep calls of batch in one batch request, adding the results of
+// the upkeeps that passed to results
+func (ex *UpkeepExecuter) checkUpkeepBatch(ctx context.Context, head eth.Head, batch []UpkeepRegistration, blockTag string, gasPrices *headGasPrices, results map[upkeepKey]checkUpkeepResult) []UpkeepRegistration {
+	var unchecked []UpkeepRegistration
+	upkeeps := make([]UpkeepRegistration, 0, len(batch))
+	versions := make([]registryVersion, 0, len(batch))
+	prices := make([]*big.Int, 0, len(batch))
+	reqs := make([]rpc.BatchElem, 0, len(batch))
+	for _, upkeep := range batch {
+		version, err := ex.registryVersion(upkeep)
+		if err != nil {
+			ex.logger.Errorw("unable to batch checkUpkeep", "upkeepID", upkeep.UpkeepID, "error", err)
+			continue
+		}
+		lggr := ex.logger.With("blockNum", head.Number, "upkeepID", upkeep.UpkeepID)
+		gasPrice, err := ex.estimateCheckGasPrice(ctx, upkeep, head, gasPrices, lggr)
+		if err != nil {
+			// execute estimates the gas price again and records why the upkeep was skipped
+			unchecked = append(unchecked, upkeep)
+			continue
+		}
+		msg, err := ex.checkUpkeepCallMsg(version, upkeep, gasPrice)
+		if err != nil {
+			ex.logger.Errorw("unable to batch checkUpkeep", "upkeepID", upkeep.UpkeepID, "error", err)
+			continue
+		}
+		upkeeps = append(upkeeps, upkeep)
+		versions = append(versions, version)
+		prices = append(prices, gasPrice)
+		reqs = append(reqs, rpc.BatchElem{
+			Method: "eth_call",
+			Args: []interface{}{
+				map[string]interface{}{
+					"to":       msg.To,
+					"gas":      hexutil.Uint64(msg.Gas),
+					"gasPrice": (*hexutil.Big)(msg.GasPrice),
+					"data":     hexutil.Bytes(msg.Data),
+				},
+				blockTag,
+			},
+			Result: new(hexutil.Bytes),
+		})
+	}
+	if len(reqs) == 0 {
+		return unchecked
+	}
+
+	release, err := ex.acquireSimulationSlot(ctx)
+	if err != nil {
+		ex.logger.Warnw("batched checkUpkeep aborted, checking the upkeeps individually",
+			"blockheight", head.Number, "upkeeps", len(upkeeps), "error", err)
+		return append(unchecked, upkeeps...)
+	}
+	err = ex.ethClient.BatchCallContext(ctx, reqs)
+	release()
+	if err != nil {
+		ex.logger.Warnw("batched checkUpkeep failed, checking the upkeeps individually",
+			"blockheight", head.Number, "upkeeps", len(upkeeps), "error", err)
+		return append(unchecked, upkeeps...)
+	}
+
+	passed := unchecked
+	for i, upkeep := range upkeeps {
+		result, err := checkUpkeepBatchElem(versions[i], reqs[i])
+		if errors.Is(err, errCheckUpkeepUnavailable) {
+			ex.logger.Debugw("batched checkUpkeep call failed, checking the upkeep individually",
+				"blockNum", head.Number, "upkeepID", upkeep.UpkeepID, "error", err)
+			passed = append(passed, upkeep)
+			continue
+		}
+		ex.recordSimulation(upkeep, head, err)
+		if err != nil {
+			lggr := ex.logger.With("blockNum", head.Number, "upkeepID", upkeep.UpkeepID)
+			lggr.Debugw("upkeep not eligible to perform", "error", err)
+			decision := upkeepDecision{reason: decisionCheckReverted, checkUpkeep: simulationReverted}
+			logDecision(lggr, executionSkipped, decision)
+			ex.recordDecision(upkeep, head, executionSkipped, decision)
+			ex.observeOverdue(upkeep, head, executionSkipped, decision)
+			continue
+		}
+		result.GasPrice = prices[i]
+		results[upkeep.key()] = result
+		passed = append(passed, upkeep)
+	}
+	return passed
+}
+
+// checkUpkeepBatchElem decodes the result of a batched checkUpkeep call.
+// A reverted call means the upkeep does not need to be performed, calls failing at the RPC level
+// return errCheckUpkeepUnavailable.
+func checkUpkeepBatchElem(version registryVersion, req rpc.BatchElem) (checkUpkeepResult, error) {
+	if req.Error != nil {
+		return checkUpkeepResult{}, checkUpkeepCallError(req.Error)
+	}
+	return version.unpackCheckUpkeep(*req.Result.(*hexutil.Bytes))
+}
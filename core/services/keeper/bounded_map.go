@@ -0,0 +1,94 @@
+package keeper
+
+import (
+	"container/list"
+)
+
+// upkeepStateMap holds one kind of per-upkeep state for at most maxEntries upkeeps, so that a registry
+// with a huge, churning upkeep set can't grow the memory of the executer without bound. Adding an upkeep
+// to a full map evicts the least recently used one. It isn't safe for concurrent use, callers guard it
+// with the mutex of the state it holds.
+type upkeepStateMap struct {
+	maxEntries int
+	entries    map[upkeepKey]*list.Element
+	// order holds the upkeepStateEntry of every upkeep, most recently used first
+	order   *list.List
+	onEvict func(upkeepKey)
+}
+
+type upkeepStateEntry struct {
+	key   upkeepKey
+	value interface{}
+}
+
+// newUpkeepStateMap returns an empty upkeepStateMap. A maxEntries of 0 disables the limit.
+// onEvict is called with the key of every evicted upkeep.
+func newUpkeepStateMap(maxEntries uint32, onEvict func(upkeepKey)) *upkeepStateMap {
+	return &upkeepStateMap{
+		maxEntries: int(maxEntries),
+		entries:    make(map[upkeepKey]*list.Element),
+		order:      list.New(),
+		onEvict:    onEvict,
+	}
+}
+
+// get returns the state of the upkeep and marks it as recently used
+func (m *upkeepStateMap) get(key upkeepKey) (interface{}, bool) {
+	elem, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	m.order.MoveToFront(elem)
+	return elem.Value.(*upkeepStateEntry).value, true
+}
+
+// peek returns the state of the upkeep without marking it as recently used, it doesn't modify the map
+func (m *upkeepStateMap) peek(key upkeepKey) (interface{}, bool) {
+	elem, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	return elem.Value.(*upkeepStateEntry).value, true
+}
+
+// set stores the state of the upkeep, evicting the least recently used upkeep if the map is full
+func (m *upkeepStateMap) set(key upkeepKey, value interface{}) {
+	if elem, ok := m.entries[key]; ok {
+		elem.Value.(*upkeepStateEntry).value = value
+		m.order.MoveToFront(elem)
+		return
+	}
+	m.entries[key] = m.order.PushFront(&upkeepStateEntry{key: key, value: value})
+	if m.maxEntries > 0 && m.order.Len() > m.maxEntries {
+		evicted := m.order.Remove(m.order.Back()).(*upkeepStateEntry)
+		delete(m.entries, evicted.key)
+		if m.onEvict != nil {
+			m.onEvict(evicted.key)
+		}
+	}
+}
+
+func (m *upkeepStateMap) delete(key upkeepKey) {
+	if elem, ok := m.entries[key]; ok {
+		m.order.Remove(elem)
+		delete(m.entries, key)
+	}
+}
+
+func (m *upkeepStateMap) len() int {
+	return m.order.Len()
+}
+
+// reset removes the state of all upkeeps, without counting them as evicted
+func (m *upkeepStateMap) reset() {
+	m.entries = make(map[upkeepKey]*list.Element)
+	m.order.Init()
+}
+
+// boundedUpkeepState returns an upkeepStateMap bounded by KeeperMaxUpkeepStateEntries,
+// counting the evictions from it under the name of the state
+func (ex *UpkeepExecuter) boundedUpkeepState(state string) *upkeepStateMap {
+	return newUpkeepStateMap(ex.config.KeeperMaxUpkeepStateEntries(), func(key upkeepKey) {
+		ex.metrics.IncUpkeepStateEvictions(key.registry.Hex(), state)
+	})
+}
@@ -0,0 +1,103 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/configtest"
+)
+
+func TestUpkeepStateMap(t *testing.T) {
+	t.Parallel()
+
+	key := func(upkeepID int64) upkeepKey {
+		return upkeepKey{registry: common.HexToAddress("0x1"), upkeepID: upkeepID}
+	}
+
+	t.Run("evicts the least recently used upkeep", func(t *testing.T) {
+		var evicted []int64
+		m := newUpkeepStateMap(2, func(key upkeepKey) { evicted = append(evicted, key.upkeepID) })
+
+		m.set(key(1), "a")
+		m.set(key(2), "b")
+		_, ok := m.get(key(1))
+		require.True(t, ok)
+		m.set(key(3), "c")
+
+		require.Equal(t, []int64{2}, evicted)
+		require.Equal(t, 2, m.len())
+		_, ok = m.get(key(2))
+		require.False(t, ok)
+		value, ok := m.get(key(1))
+		require.True(t, ok)
+		require.Equal(t, "a", value)
+	})
+
+	t.Run("peek doesn't mark the upkeep as used", func(t *testing.T) {
+		var evicted []int64
+		m := newUpkeepStateMap(2, func(key upkeepKey) { evicted = append(evicted, key.upkeepID) })
+
+		m.set(key(1), "a")
+		m.set(key(2), "b")
+		_, ok := m.peek(key(1))
+		require.True(t, ok)
+		m.set(key(3), "c")
+
+		require.Equal(t, []int64{1}, evicted)
+	})
+
+	t.Run("updating an upkeep doesn't evict", func(t *testing.T) {
+		var evicted []int64
+		m := newUpkeepStateMap(2, func(key upkeepKey) { evicted = append(evicted, key.upkeepID) })
+
+		m.set(key(1), "a")
+		m.set(key(2), "b")
+		m.set(key(1), "c")
+
+		require.Empty(t, evicted)
+		value, _ := m.get(key(1))
+		require.Equal(t, "c", value)
+	})
+
+	t.Run("deleted and reset upkeeps aren't evicted", func(t *testing.T) {
+		var evicted []int64
+		m := newUpkeepStateMap(2, func(key upkeepKey) { evicted = append(evicted, key.upkeepID) })
+
+		m.set(key(1), "a")
+		m.delete(key(1))
+		m.set(key(2), "b")
+		m.set(key(3), "c")
+		m.reset()
+		m.set(key(4), "d")
+
+		require.Empty(t, evicted)
+		require.Equal(t, 1, m.len())
+	})
+
+	t.Run("0 disables the limit", func(t *testing.T) {
+		m := newUpkeepStateMap(0, nil)
+		for i := int64(0); i < 100; i++ {
+			m.set(key(i), i)
+		}
+		require.Equal(t, 100, m.len())
+	})
+}
+
+func TestBoundedUpkeepState_CountsEvictions(t *testing.T) {
+	t.Parallel()
+
+	recorder := &recordingMetrics{}
+	ex := newTestExecuter(t, testExecuterDeps{metrics: recorder}, func(c *configtest.TestGeneralConfig) {
+		c.Overrides.KeeperMaxUpkeepStateEntries = null.IntFrom(1)
+	})
+
+	m := ex.boundedUpkeepState("lastPerformed")
+	m.set(upkeepKey{upkeepID: 1}, struct{}{})
+	m.set(upkeepKey{upkeepID: 2}, struct{}{})
+	m.set(upkeepKey{upkeepID: 3}, struct{}{})
+
+	require.Equal(t, []string{"lastPerformed", "lastPerformed"}, recorder.upkeepStateEvictions)
+}
@@ -0,0 +1,34 @@
+package keeper
+
+import (
+	"strings"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+)
+
+// errCheckUpkeepUnavailable is returned for checkUpkeep calls that failed at the RPC level, e.g. because the
+// eth node couldn't be reached. Unlike a revert, the failure says nothing about whether the upkeep needs a perform.
+var errCheckUpkeepUnavailable = errors.New("checkUpkeep call failed at the RPC level")
+
+// revertErrorCode is the JSON-RPC error code of an eth_call that reverted with revert data
+const revertErrorCode = 3
+
+// isCallRevert returns true if err is the error of an eth_call that reverted, as opposed to an RPC error.
+// Nodes only return the revert error code if the revert came with data, otherwise the message tells.
+func isCallRevert(err error) bool {
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == revertErrorCode {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "revert") || strings.Contains(msg, "vm execution error")
+}
+
+// checkUpkeepCallError wraps the error of a checkUpkeep call, with errCheckUpkeepUnavailable if it didn't revert
+func checkUpkeepCallError(err error) error {
+	if isCallRevert(err) {
+		return errors.Wrap(err, "checkUpkeep call failed")
+	}
+	return errors.Wrapf(errCheckUpkeepUnavailable, "%v", err)
+}
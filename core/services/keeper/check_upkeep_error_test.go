@@ -0,0 +1,122 @@
+package keeper
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/configtest"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+// codedError is a JSON-RPC error with an error code
+type codedError struct {
+	code int
+	msg  string
+}
+
+func (e codedError) Error() string  { return e.msg }
+func (e codedError) ErrorCode() int { return e.code }
+
+func TestIsCallRevert(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		err    error
+		revert bool
+	}{
+		{"geth revert", errors.New("execution reverted"), true},
+		{"revert with reason", errors.New("execution reverted: upkeep not needed"), true},
+		{"revert error code", codedError{code: 3, msg: "call failed"}, true},
+		{"parity revert", errors.New("VM execution error."), true},
+		{"wrapped revert", errors.Wrap(errors.New("Reverted 0x"), "eth_call"), true},
+		{"connection refused", errors.New("dial tcp 127.0.0.1:8545: connect: connection refused"), false},
+		{"missing header", codedError{code: -32000, msg: "header not found"}, false},
+		{"deadline", context.DeadlineExceeded, false},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.revert, isCallRevert(test.err), test.name)
+	}
+}
+
+// unavailableClient fails every eth_call at the RPC level. Its batch calls revert the upkeeps in reverted
+// and fail the others at the RPC level.
+type unavailableClient struct {
+	*eth.NullClient
+	reverted map[int]bool
+}
+
+func (c *unavailableClient) CallContract(context.Context, ethereum.CallMsg, *big.Int) ([]byte, error) {
+	return nil, codedError{code: -32000, msg: "header not found"}
+}
+
+func (c *unavailableClient) BatchCallContext(_ context.Context, b []rpc.BatchElem) error {
+	for i := range b {
+		if c.reverted[i] {
+			b[i].Error = errors.New("execution reverted")
+			continue
+		}
+		b[i].Error = codedError{code: -32000, msg: "header not found"}
+	}
+	return nil
+}
+
+func TestUpkeepExecuter_CheckUpkeepRPCErrors(t *testing.T) {
+	t.Parallel()
+
+	head := eth.Head{Number: 20}
+	newExecuter := func(t *testing.T, client eth.Client) (*UpkeepExecuter, *recordingEventSink) {
+		sink := &recordingEventSink{}
+		ex := newTestExecuter(t, testExecuterDeps{ethClient: client, events: sink}, func(c *configtest.TestGeneralConfig) {
+			c.Overrides.KeeperAuditDecisions = null.BoolFrom(true)
+			c.Overrides.KeeperSimRevertStreakThreshold = null.IntFrom(1)
+		})
+		return ex, sink
+	}
+
+	t.Run("aren't counted as reverts", func(t *testing.T) {
+		ex, sink := newExecuter(t, &unavailableClient{NullClient: &eth.NullClient{CID: big.NewInt(1)}})
+
+		outcomes := make(chan executionOutcome, 1)
+		upkeep := UpkeepRegistration{UpkeepID: 1, ExecuteGas: 100_000}
+		go ex.execute(context.Background(), upkeep, head, time.Now(), false, nil, nil, nil, func(outcome executionOutcome) { outcomes <- outcome })
+		select {
+		case outcome := <-outcomes:
+			require.Equal(t, executionFailed, outcome)
+		case <-time.After(5 * time.Second):
+			t.Fatal("execution didn't end")
+		}
+
+		events := sink.ofType(EventDecision)
+		require.Len(t, events, 1)
+		require.Equal(t, decisionCheckFailed, events[0].Data["reason"])
+		// a streak threshold of 1 would have alerted on a revert
+		require.Empty(t, sink.ofType(EventSimRevertStreak))
+	})
+
+	t.Run("fall back to individual checks for batched calls", func(t *testing.T) {
+		ex, sink := newExecuter(t, &unavailableClient{NullClient: &eth.NullClient{CID: big.NewInt(1)}, reverted: map[int]bool{1: true}})
+
+		failed := UpkeepRegistration{UpkeepID: 1, ExecuteGas: 100_000}
+		reverted := UpkeepRegistration{UpkeepID: 2, ExecuteGas: 100_000}
+		results := map[upkeepKey]checkUpkeepResult{}
+		unchecked := ex.checkUpkeepBatch(context.Background(), head, []UpkeepRegistration{failed, reverted}, CheckBlockTagLatest, nil, results)
+
+		// only the failed call is returned, without a result for execute to check it on its own
+		require.Equal(t, []UpkeepRegistration{failed}, unchecked)
+		require.Empty(t, results)
+		events := sink.ofType(EventDecision)
+		require.Len(t, events, 1)
+		require.Equal(t, int64(2), events[0].UpkeepID)
+		require.Equal(t, decisionCheckReverted, events[0].Data["reason"])
+		require.Len(t, sink.ofType(EventSimRevertStreak), 1)
+	})
+}
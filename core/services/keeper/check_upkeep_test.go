@@ -0,0 +1,107 @@
+package keeper
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/configtest"
+)
+
+func TestUnpackCheckUpkeep(t *testing.T) {
+	t.Parallel()
+
+	// return data of a checkUpkeep call of an upkeep whose performData is abi.encode(42)
+	out := hexutil.MustDecode("0x" +
+		"00000000000000000000000000000000000000000000000000000000000000a0" + // offset of performData
+		"000000000000000000000000000000000000000000000000002386f26fc10000" + // maxLinkPayment, 0.01 LINK
+		"000000000000000000000000000000000000000000000000000000000007a120" + // gasLimit
+		"0000000000000000000000000000000000000000000000000000000a7a358200" + // adjustedGasWei, 45 gwei
+		"0000000000000000000000000000000000000000000000000011c37937e08000" + // linkEth
+		"0000000000000000000000000000000000000000000000000000000000000020" + // length of performData
+		"000000000000000000000000000000000000000000000000000000000000002a", // performData
+	)
+
+	result, err := registryVersions[DefaultRegistryVersion].unpackCheckUpkeep(out)
+	require.NoError(t, err)
+	require.Equal(t, common.LeftPadBytes([]byte{42}, 32), result.PerformData)
+	require.Equal(t, big.NewInt(10_000_000_000_000_000).String(), result.MaxLinkPayment.String())
+	require.Equal(t, big.NewInt(500_000).String(), result.GasLimit.String())
+	require.Equal(t, assets.GWei(45).String(), result.MaxValidGasPrice.String())
+
+	_, err = registryVersions[DefaultRegistryVersion].unpackCheckUpkeep(out[:64])
+	require.Error(t, err)
+}
+
+func TestUnpackCheckUpkeep_MaxValidGasPrice(t *testing.T) {
+	t.Parallel()
+
+	pack := func(t *testing.T, adjustedGasWei *big.Int) []byte {
+		out, err := RegistryABI.Methods["checkUpkeep"].Outputs.Pack(
+			[]byte{0x12},
+			big.NewInt(1),
+			big.NewInt(2_000_000),
+			adjustedGasWei,
+			big.NewInt(1),
+		)
+		require.NoError(t, err)
+		return out
+	}
+
+	result, err := registryVersions[DefaultRegistryVersion].unpackCheckUpkeep(pack(t, assets.GWei(50)))
+	require.NoError(t, err)
+	require.Equal(t, assets.GWei(50).String(), result.MaxValidGasPrice.String())
+
+	// a zero adjustedGasWei doesn't cap the gas price
+	result, err = registryVersions[DefaultRegistryVersion].unpackCheckUpkeep(pack(t, big.NewInt(0)))
+	require.NoError(t, err)
+	require.Nil(t, result.MaxValidGasPrice)
+}
+
+// blockNumberClient is an eligibleClient recording the block number of its last call
+type blockNumberClient struct {
+	*eligibleClient
+	blockNumber *big.Int
+}
+
+func (c *blockNumberClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	c.blockNumber = blockNumber
+	return c.eligibleClient.CallContract(ctx, msg, blockNumber)
+}
+
+func TestUpkeepExecuter_CheckUpkeep_CheckBlockTag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		tag         string
+		blockNumber *big.Int
+	}{
+		{CheckBlockTagLatest, nil},
+		{CheckBlockTagPending, big.NewInt(int64(rpc.PendingBlockNumber))},
+		// unrecognised tags fall back to the latest block
+		{"safe", nil},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.tag, func(t *testing.T) {
+			t.Parallel()
+
+			client := &blockNumberClient{eligibleClient: newEligibleClient()}
+			ex := newTestExecuter(t, testExecuterDeps{ethClient: client}, func(c *configtest.TestGeneralConfig) {
+				c.Overrides.KeeperCheckBlockTag = null.StringFrom(test.tag)
+			})
+
+			_, err := ex.checkUpkeep(context.Background(), UpkeepRegistration{UpkeepID: 1}, assets.GWei(60))
+			require.NoError(t, err)
+			require.Equal(t, test.blockNumber, client.blockNumber)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package keeper
+
+import (
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+// skewedHeadsWarnThreshold is the number of consecutive heads with a skewed timestamp after which a warning is logged
+const skewedHeadsWarnThreshold = 5
+
+// headTime returns the timestamp of head, or the node clock if the head has none
+func headTime(head eth.Head) time.Time {
+	if head.Timestamp.IsZero() {
+		return time.Now()
+	}
+	return head.Timestamp
+}
+
+// observeClockSkew compares the head timestamp with the node clock and warns if they have
+// differed by more than KeeperClockSkewTolerance for skewedHeadsWarnThreshold consecutive heads.
+// It must only be called from the run goroutine.
+func (ex *UpkeepExecuter) observeClockSkew(head eth.Head) {
+	if head.Timestamp.IsZero() {
+		return
+	}
+	skew := time.Since(head.Timestamp)
+	tolerance := ex.config.KeeperClockSkewTolerance()
+	if skew <= tolerance && skew >= -tolerance {
+		ex.skewedHeads = 0
+		return
+	}
+	ex.skewedHeads++
+	if ex.skewedHeads == skewedHeadsWarnThreshold {
+		ex.logger.Warnw("head timestamps consistently differ from the node clock by more than KeeperClockSkewTolerance, check the node's clock",
+			"skew", skew, "tolerance", tolerance, "consecutiveHeads", ex.skewedHeads, "blockheight", head.Number)
+	}
+}
+
+// withinTolerance returns true if f holds for t shifted by up to KeeperClockSkewTolerance in either direction
+func (ex *UpkeepExecuter) withinTolerance(t time.Time, f func(time.Time) bool) bool {
+	tolerance := ex.config.KeeperClockSkewTolerance()
+	return f(t) || f(t.Add(-tolerance)) || f(t.Add(tolerance))
+}
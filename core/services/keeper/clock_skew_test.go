@@ -0,0 +1,73 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/configtest"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+)
+
+func TestUpkeepExecuter_WithinPerformWindow_ClockSkewTolerance(t *testing.T) {
+	t.Parallel()
+
+	spec := &job.KeeperSpec{UpkeepOverrides: job.KeeperUpkeepOverrides{
+		"1": {PerformWindows: []string{"09:00-10:00"}},
+	}}
+	upkeep := UpkeepRegistration{UpkeepID: 1}
+	at := func(hour, minute, second int) eth.Head {
+		return eth.Head{Number: 20, Timestamp: time.Date(2021, 9, 1, hour, minute, second, 0, time.UTC)}
+	}
+
+	tests := []struct {
+		name      string
+		tolerance time.Duration
+		head      eth.Head
+		within    bool
+	}{
+		{"inside the window", 0, at(9, 30, 0), true},
+		{"before the window", 0, at(8, 59, 50), false},
+		{"before the window, within tolerance", 30 * time.Second, at(8, 59, 50), true},
+		{"after the window, within tolerance", 30 * time.Second, at(10, 0, 20), true},
+		{"after the window, beyond tolerance", 30 * time.Second, at(10, 0, 40), false},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ex := newTestExecuter(t, testExecuterDeps{keeperSpec: spec}, func(c *configtest.TestGeneralConfig) {
+				c.Overrides.KeeperClockSkewTolerance = &test.tolerance
+			})
+			require.Equal(t, test.within, ex.withinPerformWindow(upkeep, test.head))
+		})
+	}
+
+	t.Run("upkeeps without perform windows", func(t *testing.T) {
+		ex := newTestExecuter(t, testExecuterDeps{keeperSpec: spec})
+		require.True(t, ex.withinPerformWindow(UpkeepRegistration{UpkeepID: 2}, at(12, 0, 0)))
+	})
+}
+
+func TestUpkeepExecuter_ObserveClockSkew(t *testing.T) {
+	t.Parallel()
+
+	tolerance := 30 * time.Second
+	ex := newTestExecuter(t, testExecuterDeps{}, func(c *configtest.TestGeneralConfig) {
+		c.Overrides.KeeperClockSkewTolerance = &tolerance
+	})
+
+	ex.observeClockSkew(eth.Head{Number: 1, Timestamp: time.Now().Add(-time.Minute)})
+	ex.observeClockSkew(eth.Head{Number: 2, Timestamp: time.Now().Add(time.Minute)})
+	require.Equal(t, 2, ex.skewedHeads)
+
+	// heads without a timestamp are ignored
+	ex.observeClockSkew(eth.Head{Number: 3})
+	require.Equal(t, 2, ex.skewedHeads)
+
+	ex.observeClockSkew(eth.Head{Number: 4, Timestamp: time.Now().Add(-10 * time.Second)})
+	require.Zero(t, ex.skewedHeads)
+}
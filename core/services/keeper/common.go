@@ -1,6 +1,7 @@
 package keeper
 
 import (
+	"math/big"
 	"time"
 
 	"github.com/smartcontractkit/chainlink/core/internal/gethwrappers/generated/keeper_registry_wrapper"
@@ -10,11 +11,78 @@ import (
 var RegistryABI = eth.MustGetABI(keeper_registry_wrapper.KeeperRegistryABI)
 
 type Config interface {
+	KeeperAuditDecisions() bool
+	KeeperAverageBlockTime() time.Duration
+	KeeperBaseFeeOverpaymentFactor() uint32
+	KeeperBatchCheckUpkeep() bool
+	KeeperBatchCheckUpkeepSize() uint32
+	KeeperBatchMetricUpdates() bool
+	KeeperBlockGasLimit() uint64
+	KeeperCheckBlockTag() string
+	KeeperClampToRegistryMaxGasPrice() bool
+	KeeperClockSkewTolerance() time.Duration
+	KeeperCompactHeadLog() bool
 	KeeperDefaultTransactionQueueDepth() uint32
+	KeeperDryRun() bool
+	KeeperEligibilityConfirmations() uint32
+	KeeperEligibilityQueryRPS() uint32
+	KeeperEligibilityQueryRetries() uint32
+	KeeperEligibilityQueryTimeout() time.Duration
+	KeeperEligibilityStaleFallback() bool
+	KeeperEstimateConfirmationBlocks() bool
+	KeeperExcludePendingPerforms() bool
+	KeeperExecutionChunkSize() uint32
+	KeeperExecutionTimeout() time.Duration
+	KeeperGasEstimationRetries() uint32
+	KeeperGasEstimationRetryBackoff() time.Duration
 	KeeperGasPriceBufferPercent() uint32
+	KeeperGasPriceSanityMaxWei() (*big.Int, bool)
+	KeeperGasPriceSanityMinWei() (*big.Int, bool)
+	KeeperGasPriceSpeed() string
+	KeeperGasReconcileEstimatorWeight() uint32
+	KeeperGasReconcileStrategy() string
+	KeeperGracePeriodLagMultiplier() float32
+	KeeperGracePeriodLagThreshold() uint32
+	KeeperHeadPollFallbackInterval() time.Duration
+	KeeperIdleHeadThreshold() uint32
+	KeeperIntervalCooldown() bool
+	KeeperLogTriggerBatchSize() uint32
+	KeeperLogTriggerBatchWindow() time.Duration
+	KeeperLowBalanceProjectionWindow() uint32
+	KeeperLowBalanceThreshold() *big.Int
+	KeeperMailboxCapacity() uint32
+	KeeperMaxIndexLag() uint32
+	KeeperMaxPerformDataSize() uint32
+	KeeperMaxPerformGasLimit() uint64
+	KeeperMaxProcessedHeadAge() time.Duration
+	KeeperMaxUpkeepStateEntries() uint32
+	KeeperMaxUpkeepsPerBlock() uint32
+	KeeperMaximumBackoffBlocks() uint32
+	KeeperMaximumConcurrentExecutions() uint32
+	KeeperMaximumGasPrice() *big.Int
 	KeeperMaximumGracePeriod() int64
+	KeeperMaximumGracePeriodDuration() time.Duration
+	KeeperMinConfirmations() uint32
+	KeeperMinHeadInterval() time.Duration
+	KeeperMinimumBalance() *big.Int
 	KeeperMinimumRequiredConfirmations() uint64
+	KeeperNonceGapTolerance() uint32
+	KeeperOverdueOrdering() bool
+	KeeperOverdueWarnHeads() uint32
+	KeeperPerformCadenceBlocks() uint32
+	KeeperRecoverPipelinePanics() bool
 	KeeperRegistryCheckGasOverhead() uint64
+	KeeperRegistryConcurrency() uint32
 	KeeperRegistryPerformGasOverhead() uint64
 	KeeperRegistrySyncInterval() time.Duration
+	KeeperReportMaxBlocks() uint32
+	KeeperShareHeadGasPrice() bool
+	KeeperShutdownDeadline() time.Duration
+	KeeperSimRevertAutoDisable() bool
+	KeeperSimRevertStreakThreshold() uint32
+	KeeperSimulatePerformUpkeep() bool
+	KeeperSimulationConcurrency() uint32
+	KeeperSkipDuplicateHeads() bool
+	KeeperUseEstimatedGasLimit() bool
+	KeeperValueWeightedOrdering() bool
 }
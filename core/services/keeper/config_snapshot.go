@@ -0,0 +1,70 @@
+package keeper
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/smartcontractkit/chainlink/core/services/postgres"
+)
+
+// configValues returns the formatted value of every method of the keeper Config interface
+func configValues(config Config) ConfigValues {
+	values := make(ConfigValues)
+	configType := reflect.TypeOf((*Config)(nil)).Elem()
+	v := reflect.ValueOf(config)
+	for i := 0; i < configType.NumMethod(); i++ {
+		name := configType.Method(i).Name
+		out := v.MethodByName(name).Call(nil)
+		values[name] = fmt.Sprint(out[0].Interface())
+	}
+	return values
+}
+
+// configChange is a config value that differs between two snapshots
+type configChange struct {
+	Name     string
+	Previous string
+	Current  string
+}
+
+// diffConfigValues returns the changes from previous to current, sorted by name.
+// Values missing from either side are reported with an empty string.
+func diffConfigValues(previous, current ConfigValues) []configChange {
+	var changes []configChange
+	for name, value := range current {
+		if prev, exists := previous[name]; !exists || prev != value {
+			changes = append(changes, configChange{Name: name, Previous: prev, Current: value})
+		}
+	}
+	for name, prev := range previous {
+		if _, exists := current[name]; !exists {
+			changes = append(changes, configChange{Name: name, Previous: prev})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+	return changes
+}
+
+// logConfigChanges logs every config value that changed since the job was last started,
+// then saves the current values for the next start
+func (ex *UpkeepExecuter) logConfigChanges() {
+	ctx, cancel := postgres.DefaultQueryCtx()
+	defer cancel()
+
+	current := configValues(ex.config)
+	previous, err := ex.orm.ConfigSnapshotForJob(ctx, ex.job.ID)
+	if err != nil {
+		ex.logger.Warnw("unable to load keeper config snapshot", "error", err)
+		return
+	}
+	if previous != nil {
+		for _, change := range diffConfigValues(previous, current) {
+			ex.logger.Warnw("keeper config changed since the job was last started",
+				"name", change.Name, "previous", change.Previous, "current", change.Current)
+		}
+	}
+	if err := ex.orm.UpsertConfigSnapshot(ctx, &ConfigSnapshot{JobID: ex.job.ID, Snapshot: current}); err != nil {
+		ex.logger.Warnw("unable to save keeper config snapshot", "error", err)
+	}
+}
@@ -0,0 +1,112 @@
+package keeper
+
+import (
+	"math/big"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+// decision reasons record why an upkeep was skipped, or how its execution ended
+const (
+	decisionDisabled             = "disabled"
+	decisionOutsidePerformWindow = "outside-perform-window"
+	decisionIntervalCooldown     = "interval-cooldown"
+	decisionCheckReverted        = "check-reverted"
+	decisionCheckFailed          = "check-failed"
+	decisionPerformDataTooLarge  = "perform-data-too-large"
+	decisionPerformCadence       = "perform-cadence"
+	decisionBlockGasLimit        = "exceeds-block-gas-limit"
+	decisionGasEstimationFailed  = "gas-estimation-failed"
+	decisionGasPriceAboveMaximum = "gas-price-above-maximum"
+	decisionNonceGap             = "nonce-gap"
+	decisionPerformReverted      = "perform-simulation-reverted"
+	decisionTimedOut             = "timed-out"
+	decisionShutdown             = "shutdown"
+	decisionReorged              = "reorged"
+	decisionRunFailed            = "run-failed"
+	decisionRunErrored           = "run-errored"
+	decisionPerformed            = "performed"
+	decisionDryRun               = "dry-run"
+)
+
+// simulation results record whether the checkUpkeep and performUpkeep simulations of an upkeep passed
+const (
+	simulationNotRun    = "not-run"
+	simulationSucceeded = "succeeded"
+	simulationReverted  = "reverted"
+)
+
+// upkeepDecision collects what the executer decided for an upkeep on a head
+type upkeepDecision struct {
+	reason            string
+	gasPrice          *big.Int
+	gasLimit          uint64
+	pipelineRunID     int64
+	checkUpkeep       string
+	performSimulation string
+}
+
+// logFields returns the key and value pairs summarizing the decision, ending with outcome
+func (d upkeepDecision) logFields(outcome executionOutcome) []interface{} {
+	fields := []interface{}{
+		"eligible", true,
+		"checkUpkeep", simulationResult(d.checkUpkeep),
+		"performSimulation", simulationResult(d.performSimulation),
+	}
+	if d.gasLimit > 0 {
+		fields = append(fields, "gasLimit", d.gasLimit)
+	}
+	if d.gasPrice != nil {
+		fields = append(fields, "gasPrice", d.gasPrice.String())
+	}
+	if d.pipelineRunID != 0 {
+		fields = append(fields, "pipelineRunID", d.pipelineRunID)
+	}
+	return append(fields, "reason", d.reason, "action", outcome.String())
+}
+
+func simulationResult(result string) string {
+	if result == "" {
+		return simulationNotRun
+	}
+	return result
+}
+
+// logDecision logs the decision for an upkeep in a single debug line, lggr carries the
+// upkeepID and blockNum of the execution
+func logDecision(lggr logger.Logger, outcome executionOutcome, decision upkeepDecision) {
+	lggr.Debugw("upkeep decision", decision.logFields(outcome)...)
+}
+
+// recordDecision emits the decision for the upkeep on head if KeeperAuditDecisions is enabled.
+// Upkeeps only reach execution when the eligibility query found them eligible on the keeper's turn.
+// The perform transaction is broadcast asynchronously, the pipeline run ID links to it.
+func (ex *UpkeepExecuter) recordDecision(upkeep UpkeepRegistration, head eth.Head, outcome executionOutcome, decision upkeepDecision) {
+	if !ex.config.KeeperAuditDecisions() {
+		return
+	}
+	data := map[string]interface{}{
+		"eligible": true,
+		"reason":   decision.reason,
+		"outcome":  outcome.String(),
+	}
+	if blockCountPerTurn := int64(upkeep.Registry.BlockCountPerTurn); blockCountPerTurn > 0 {
+		data["turn"] = head.Number / blockCountPerTurn
+	}
+	data = addTurnWindow(data, upkeep, head.Number)
+	if decision.gasPrice != nil {
+		data["gasPrice"] = decision.gasPrice.String()
+		data["gasLimit"] = decision.gasLimit
+	}
+	if decision.pipelineRunID != 0 {
+		data["pipelineRunID"] = decision.pipelineRunID
+	}
+	ex.events.Emit(Event{
+		Type:            EventDecision,
+		RegistryAddress: upkeep.Registry.ContractAddress.Address(),
+		UpkeepID:        upkeep.UpkeepID,
+		BlockNumber:     head.Number,
+		Data:            data,
+	})
+}
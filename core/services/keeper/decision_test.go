@@ -0,0 +1,138 @@
+package keeper
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/configtest"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+func TestUpkeepDecision_LogFields(t *testing.T) {
+	t.Parallel()
+
+	fieldsMap := func(t *testing.T, fields []interface{}) map[string]interface{} {
+		require.Zero(t, len(fields)%2)
+		m := make(map[string]interface{}, len(fields)/2)
+		for i := 0; i < len(fields); i += 2 {
+			key, ok := fields[i].(string)
+			require.True(t, ok)
+			m[key] = fields[i+1]
+		}
+		return m
+	}
+
+	t.Run("performed upkeep", func(t *testing.T) {
+		decision := upkeepDecision{
+			reason:            decisionPerformed,
+			gasPrice:          big.NewInt(60_000_000_000),
+			gasLimit:          500_000,
+			pipelineRunID:     42,
+			checkUpkeep:       simulationSucceeded,
+			performSimulation: simulationSucceeded,
+		}
+		require.Equal(t, map[string]interface{}{
+			"eligible":          true,
+			"checkUpkeep":       simulationSucceeded,
+			"performSimulation": simulationSucceeded,
+			"gasLimit":          uint64(500_000),
+			"gasPrice":          "60000000000",
+			"pipelineRunID":     int64(42),
+			"reason":            decisionPerformed,
+			"action":            "performed",
+		}, fieldsMap(t, decision.logFields(executionPerformed)))
+	})
+
+	t.Run("upkeep skipped before gas estimation", func(t *testing.T) {
+		decision := upkeepDecision{reason: decisionCheckReverted, checkUpkeep: simulationReverted}
+		require.Equal(t, map[string]interface{}{
+			"eligible":          true,
+			"checkUpkeep":       simulationReverted,
+			"performSimulation": simulationNotRun,
+			"reason":            decisionCheckReverted,
+			"action":            "skipped",
+		}, fieldsMap(t, decision.logFields(executionSkipped)))
+	})
+
+	t.Run("upkeep skipped after gas estimation", func(t *testing.T) {
+		decision := upkeepDecision{
+			reason:            decisionPerformReverted,
+			gasPrice:          big.NewInt(1),
+			gasLimit:          100_000,
+			checkUpkeep:       simulationSucceeded,
+			performSimulation: simulationReverted,
+		}
+		fields := fieldsMap(t, decision.logFields(executionSkipped))
+		require.Equal(t, "1", fields["gasPrice"])
+		require.Equal(t, uint64(100_000), fields["gasLimit"])
+		require.Equal(t, simulationReverted, fields["performSimulation"])
+		require.Equal(t, decisionPerformReverted, fields["reason"])
+		require.Equal(t, "skipped", fields["action"])
+		require.NotContains(t, fields, "pipelineRunID")
+	})
+
+	t.Run("failed execution", func(t *testing.T) {
+		decision := upkeepDecision{reason: decisionGasEstimationFailed, gasLimit: 100_000, checkUpkeep: simulationSucceeded}
+		fields := fieldsMap(t, decision.logFields(executionFailed))
+		require.Equal(t, decisionGasEstimationFailed, fields["reason"])
+		require.Equal(t, "failed", fields["action"])
+		require.NotContains(t, fields, "gasPrice")
+	})
+}
+
+func TestUpkeepExecuter_RecordDecision(t *testing.T) {
+	t.Parallel()
+
+	upkeep := UpkeepRegistration{UpkeepID: 1, ExecuteGas: 100_000, Registry: Registry{BlockCountPerTurn: 20}}
+	head := eth.Head{Number: 41}
+	newExecuter := func(t *testing.T, client eth.Client, audit bool) (*UpkeepExecuter, *recordingEventSink) {
+		sink := &recordingEventSink{}
+		ex := newTestExecuter(t, testExecuterDeps{ethClient: client, events: sink}, func(c *configtest.TestGeneralConfig) {
+			c.Overrides.KeeperAuditDecisions = null.BoolFrom(audit)
+			c.Overrides.KeeperDryRun = null.BoolFrom(true)
+		})
+		return ex, sink
+	}
+	execute := func(ex *UpkeepExecuter) {
+		ex.executeUpkeeps(context.Background(), head, time.Now(), false, []UpkeepRegistration{upkeep}, nil, new(headSummary))
+	}
+
+	t.Run("eligible upkeep", func(t *testing.T) {
+		ex, sink := newExecuter(t, newEligibleClient(), true)
+		execute(ex)
+
+		events := sink.ofType(EventDecision)
+		require.Len(t, events, 1)
+		require.Equal(t, int64(1), events[0].UpkeepID)
+		require.Equal(t, int64(41), events[0].BlockNumber)
+		data := events[0].Data
+		require.Equal(t, decisionDryRun, data["reason"])
+		require.Equal(t, executionSkipped.String(), data["outcome"])
+		require.Equal(t, int64(2), data["turn"])
+		require.Contains(t, data, "gasPrice")
+		require.Equal(t, ex.performUpkeepGasLimit(upkeep, ex.logger), data["gasLimit"])
+		require.NotContains(t, data, "pipelineRunID")
+	})
+
+	t.Run("reverted check", func(t *testing.T) {
+		ex, sink := newExecuter(t, &eth.NullClient{CID: big.NewInt(1)}, true)
+		execute(ex)
+
+		events := sink.ofType(EventDecision)
+		require.Len(t, events, 1)
+		require.Equal(t, decisionCheckReverted, events[0].Data["reason"])
+		require.NotContains(t, events[0].Data, "gasPrice")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		ex, sink := newExecuter(t, newEligibleClient(), false)
+		execute(ex)
+
+		require.Empty(t, sink.ofType(EventDecision))
+	})
+}
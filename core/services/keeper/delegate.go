@@ -1,6 +1,8 @@
 package keeper
 
 import (
+	"strings"
+
 	"github.com/pkg/errors"
 	"gorm.io/gorm"
 
@@ -9,6 +11,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/services/bulletprooftxmanager"
 	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/services/keystore/keys/ethkey"
 	"github.com/smartcontractkit/chainlink/core/services/pipeline"
 )
 
@@ -25,6 +28,8 @@ type Delegate struct {
 	jrm      job.ORM
 	pr       pipeline.Runner
 	chainSet evm.ChainSet
+	// queryLimiter is shared by the executers of every keeper job
+	queryLimiter *QueryLimiter
 }
 
 // NewDelegate is the constructor of Delegate
@@ -36,11 +41,12 @@ func NewDelegate(
 	chainSet evm.ChainSet,
 ) *Delegate {
 	return &Delegate{
-		logger:   logger,
-		db:       db,
-		jrm:      jrm,
-		pr:       pr,
-		chainSet: chainSet,
+		logger:       logger,
+		db:           db,
+		jrm:          jrm,
+		pr:           pr,
+		chainSet:     chainSet,
+		queryLimiter: NewQueryLimiter(),
 	}
 }
 
@@ -66,33 +72,44 @@ func (d *Delegate) ServicesForSpec(spec job.Job) (services []job.Service, err er
 		return nil, err
 	}
 
-	contractAddress := spec.KeeperSpec.ContractAddress
-	contract, err := keeper_registry_wrapper.NewKeeperRegistry(
-		contractAddress.Address(),
-		chain.Client(),
-	)
-	if err != nil {
-		return nil, errors.Wrap(err, "unable to create keeper registry contract wrapper")
-	}
 	strategy := bulletprooftxmanager.NewQueueingTxStrategy(spec.ExternalJobID, chain.Config().KeeperDefaultTransactionQueueDepth())
 
 	orm := NewORM(d.db, chain.TxManager(), chain.Config(), strategy)
 
-	svcLogger := d.logger.With(
-		"jobID", spec.ID,
-		"registryAddress", contractAddress.Hex(),
-	)
+	registryAddresses := spec.KeeperSpec.RegistryAddresses()
+	svcLogger := d.logger.With("jobID", spec.ID)
+	if len(registryAddresses) == 1 {
+		svcLogger = svcLogger.With("registryAddress", registryAddresses[0].Hex())
+	} else {
+		svcLogger = svcLogger.With("registryAddresses", registryAddresses)
+	}
+	if !strings.Contains(spec.PipelineSpec.DotDagSource, directPerformData) {
+		// migration 0078 only rewrote the jobs performing the performData decoded in the pipeline
+		svcLogger.Warnw("keeper job doesn't perform $(jobSpec.performData), its upkeeps are checked again in the pipeline; "+
+			"recreate the job with the default observation source to check them once per head", "observationSource", spec.PipelineSpec.DotDagSource)
+	}
 
-	registrySynchronizer := NewRegistrySynchronizer(
-		spec,
-		contract,
-		orm,
-		d.jrm,
-		chain.LogBroadcaster(),
-		chain.Config().KeeperRegistrySyncInterval(),
-		chain.Config().KeeperMinimumRequiredConfirmations(),
-		svcLogger.Named("RegistrySynchronizer"),
-	)
+	// every registry is synced by its own synchronizer, the executer executes the upkeeps of all of them
+	for _, registryAddress := range registryAddresses {
+		contract, err := keeper_registry_wrapper.NewKeeperRegistry(
+			registryAddress.Address(),
+			chain.Client(),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to create keeper registry contract wrapper")
+		}
+		services = append(services, NewRegistrySynchronizer(
+			registryJob(spec, registryAddress),
+			contract,
+			chain.Client(),
+			orm,
+			d.jrm,
+			chain.LogBroadcaster(),
+			chain.Config().KeeperRegistrySyncInterval(),
+			chain.Config().KeeperMinimumRequiredConfirmations(),
+			d.logger.With("jobID", spec.ID, "registryAddress", registryAddress.Hex()).Named("RegistrySynchronizer"),
+		))
+	}
 	upkeepExecuter := NewUpkeepExecuter(
 		spec,
 		orm,
@@ -100,12 +117,23 @@ func (d *Delegate) ServicesForSpec(spec job.Job) (services []job.Service, err er
 		chain.Client(),
 		chain.HeadBroadcaster(),
 		chain.TxManager().GetGasEstimator(),
+		nil,
 		svcLogger.Named("UpkeepExecuter"),
 		chain.Config(),
+		d.queryLimiter,
+		nil,
+		nil,
 	)
 
-	return []job.Service{
-		registrySynchronizer,
-		upkeepExecuter,
-	}, nil
+	return append(services, upkeepExecuter), nil
+}
+
+// registryJob returns a copy of spec whose keeper spec has registryAddress as its contract address,
+// for the synchronizer of that registry
+func registryJob(spec job.Job, registryAddress ethkey.EIP55Address) job.Job {
+	keeperSpec := *spec.KeeperSpec
+	keeperSpec.ContractAddress = registryAddress
+	keeperSpec.ContractAddresses = nil
+	spec.KeeperSpec = &keeperSpec
+	return spec
 }
@@ -0,0 +1,90 @@
+package keeper
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/services/postgres"
+)
+
+// eligibilityQueryRetryBackoff is how long the executer waits before the first retry of a failed
+// eligibility query, each further retry waits one backoff longer
+const eligibilityQueryRetryBackoff = 250 * time.Millisecond
+
+// loadEligibleUpkeeps loads the upkeeps eligible on head, retrying a failed query up to
+// KeeperEligibilityQueryRetries times. If it keeps failing and KeeperEligibilityStaleFallback is
+// enabled, the upkeeps eligible on the previously processed head are loaded instead and
+// fallback is true.
+func (ex *UpkeepExecuter) loadEligibleUpkeeps(head eth.Head) (upkeeps []UpkeepRegistration, fallback bool, err error) {
+	upkeeps, err = ex.eligibleUpkeepsAt(head.Number)
+	for attempt := uint32(1); err != nil && attempt <= ex.config.KeeperEligibilityQueryRetries(); attempt++ {
+		ex.logger.Warnw("eligibility query failed, retrying",
+			"blockheight", head.Number, "attempt", attempt, "error", err)
+		select {
+		case <-time.After(time.Duration(attempt) * eligibilityQueryRetryBackoff):
+		case <-ex.chStop:
+			return nil, false, err
+		}
+		if !ex.queryLimiter.wait(ex.config.KeeperEligibilityQueryRPS(), ex.chStop) {
+			return nil, false, err
+		}
+		upkeeps, err = ex.eligibleUpkeepsAt(head.Number)
+	}
+	if err == nil || !ex.config.KeeperEligibilityStaleFallback() || ex.lastHead == nil {
+		return upkeeps, false, err
+	}
+
+	ex.logger.Warnw("eligibility query failed, falling back to the upkeeps eligible on the previously processed head",
+		"blockheight", head.Number, "fallbackBlockheight", ex.lastHead.Number, "error", err)
+	upkeeps, err = ex.eligibleUpkeepsAt(ex.lastHead.Number)
+	return upkeeps, err == nil, err
+}
+
+// eligibleUpkeepsAt loads the upkeeps of every registry of the job that are eligible on headNumber
+func (ex *UpkeepExecuter) eligibleUpkeepsAt(headNumber int64) ([]UpkeepRegistration, error) {
+	ctx, cancel := ex.eligibilityQueryCtx()
+	defer cancel()
+
+	eligibilityHeight := headNumber - int64(ex.config.KeeperEligibilityConfirmations())
+	if eligibilityHeight < 0 {
+		eligibilityHeight = 0
+	}
+
+	gracePeriod := ex.gracePeriod()
+	ex.lastGracePeriod.Store(gracePeriod)
+
+	registryAddresses := ex.job.KeeperSpec.RegistryAddresses()
+	registryUpkeeps := make([][]UpkeepRegistration, len(registryAddresses))
+	errs := make([]error, len(registryAddresses))
+	ex.forEachRegistry(len(registryAddresses), func(i int) {
+		registryUpkeeps[i], errs[i] = ex.orm.EligibleUpkeepsForRegistry(
+			ctx,
+			registryAddresses[i],
+			eligibilityHeight,
+			gracePeriod,
+			int64(ex.config.KeeperMinConfirmations()),
+		)
+	})
+
+	var upkeeps []UpkeepRegistration
+	for i, registryAddress := range registryAddresses {
+		if errs[i] != nil {
+			return nil, errors.Wrapf(errs[i], "failed to load the eligible upkeeps of registry %s", registryAddress.Hex())
+		}
+		upkeeps = append(upkeeps, registryUpkeeps[i]...)
+	}
+	return upkeeps, nil
+}
+
+// eligibilityQueryCtx returns the context of the eligibility query, timing out after
+// KeeperEligibilityQueryTimeout or the default query timeout if unset
+func (ex *UpkeepExecuter) eligibilityQueryCtx() (context.Context, context.CancelFunc) {
+	timeout := ex.config.KeeperEligibilityQueryTimeout()
+	if timeout <= 0 {
+		return postgres.DefaultQueryCtx()
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
@@ -0,0 +1,61 @@
+package keeper
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+)
+
+// EventType identifies the kind of a keeper Event
+type EventType string
+
+const (
+	// EventEligibleEntered is emitted when an upkeep becomes eligible on a head after not being eligible on the previous one
+	EventEligibleEntered EventType = "eligible-entered"
+	// EventEligibleLeft is emitted when an upkeep eligible on the previous head is no longer eligible
+	EventEligibleLeft EventType = "eligible-left"
+	// EventSimRevertStreak is emitted when the checkUpkeep simulation of an eligible upkeep has reverted
+	// for an unexpected reason on KeeperSimRevertStreakThreshold consecutive heads
+	EventSimRevertStreak EventType = "sim-revert-streak"
+	// EventLowBalance is emitted when the balance of an upkeep falls below KeeperLowBalanceThreshold
+	EventLowBalance EventType = "low-balance"
+	// EventDecision records what the executer decided for an upkeep on a head, if KeeperAuditDecisions is enabled
+	EventDecision EventType = "decision"
+	// EventOverdue is emitted when an upkeep has been eligible on KeeperOverdueWarnHeads consecutive heads
+	// without this node performing it
+	EventOverdue EventType = "overdue"
+)
+
+// Event is a structured notification about an upkeep
+type Event struct {
+	Type            EventType
+	RegistryAddress common.Address
+	UpkeepID        int64
+	BlockNumber     int64
+	Data            map[string]interface{}
+}
+
+// EventSink receives the events emitted by the upkeep executer. Emit must not block.
+type EventSink interface {
+	Emit(Event)
+}
+
+var _ EventSink = logEventSink{}
+
+// logEventSink is the default EventSink, it writes events to the log
+type logEventSink struct {
+	logger logger.Logger
+}
+
+func (s logEventSink) Emit(e Event) {
+	args := []interface{}{
+		"type", e.Type,
+		"registryAddress", e.RegistryAddress,
+		"upkeepID", e.UpkeepID,
+		"blockNum", e.BlockNumber,
+	}
+	for k, v := range e.Data {
+		args = append(args, k, v)
+	}
+	s.logger.Infow("keeper event", args...)
+}
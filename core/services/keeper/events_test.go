@@ -0,0 +1,49 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+func TestUpkeepExecuter_EmitEligibilityChanges(t *testing.T) {
+	t.Parallel()
+
+	sink := &recordingEventSink{}
+	ex := newTestExecuter(t, testExecuterDeps{events: sink})
+	upkeepIDs := func(events []Event) []int64 {
+		var ids []int64
+		for _, e := range events {
+			ids = append(ids, e.UpkeepID)
+		}
+		return ids
+	}
+	upkeeps := func(ids ...int64) []UpkeepRegistration {
+		var upkeeps []UpkeepRegistration
+		for _, id := range ids {
+			upkeeps = append(upkeeps, UpkeepRegistration{UpkeepID: id})
+		}
+		return upkeeps
+	}
+
+	ex.emitEligibilityChanges(eth.Head{Number: 20}, upkeeps(1, 2))
+	require.Equal(t, []int64{1, 2}, upkeepIDs(sink.ofType(EventEligibleEntered)))
+	require.Empty(t, sink.ofType(EventEligibleLeft))
+
+	ex.emitEligibilityChanges(eth.Head{Number: 21}, upkeeps(2, 3))
+	entered := sink.ofType(EventEligibleEntered)
+	require.Equal(t, []int64{1, 2, 3}, upkeepIDs(entered))
+	require.Equal(t, int64(21), entered[2].BlockNumber)
+	left := sink.ofType(EventEligibleLeft)
+	require.Equal(t, []int64{1}, upkeepIDs(left))
+	require.Equal(t, int64(21), left[0].BlockNumber)
+
+	// an unchanged eligible set emits nothing
+	ex.emitEligibilityChanges(eth.Head{Number: 22}, upkeeps(2, 3))
+	require.Len(t, sink.events, 4)
+
+	ex.emitEligibilityChanges(eth.Head{Number: 23}, nil)
+	require.Equal(t, []int64{1, 2, 3}, upkeepIDs(sink.ofType(EventEligibleLeft)))
+}
@@ -0,0 +1,84 @@
+package keeper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/configtest"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	pipelinemocks "github.com/smartcontractkit/chainlink/core/services/pipeline/mocks"
+)
+
+func TestUpkeepExecuter_ObservesQueueWait(t *testing.T) {
+	t.Parallel()
+
+	recorder := &recordingMetrics{}
+	ex := newTestExecuter(t, testExecuterDeps{ethClient: newEligibleClient(), metrics: recorder}, func(c *configtest.TestGeneralConfig) {
+		c.Overrides.KeeperMaximumConcurrentExecutions = null.IntFrom(1)
+		c.Overrides.KeeperDryRun = null.BoolFrom(true)
+	})
+
+	// the only execution slot is held for a while before the upkeeps are executed
+	wait := 50 * time.Millisecond
+	ex.executionQueue <- struct{}{}
+	time.AfterFunc(wait, func() { <-ex.executionQueue })
+
+	upkeeps := []UpkeepRegistration{
+		{UpkeepID: 1, ExecuteGas: 100_000},
+		{UpkeepID: 2, ExecuteGas: 100_000},
+	}
+	ex.executeUpkeeps(context.Background(), eth.Head{Number: 20}, time.Now(), false, upkeeps, nil, new(headSummary))
+
+	require.Len(t, recorder.queueWaits, 2)
+	require.GreaterOrEqual(t, int64(recorder.queueWaits[0]), int64(wait))
+}
+
+func TestUpkeepExecuter_ExecutesInChunks(t *testing.T) {
+	t.Parallel()
+
+	// pipeline runs block until Close cancels them
+	runner := new(pipelinemocks.Runner)
+	runner.On("Run", mock.Anything, mock.Anything, mock.Anything, true, mock.Anything).
+		Run(func(args mock.Arguments) { <-args.Get(0).(context.Context).Done() }).
+		Return(false, context.Canceled)
+	ex := newTestExecuter(t, testExecuterDeps{db: pgtest.NewGormDB(t), ethClient: newEligibleClient(), runner: runner}, func(c *configtest.TestGeneralConfig) {
+		c.Overrides.KeeperMaximumConcurrentExecutions = null.IntFrom(3)
+		c.Overrides.KeeperExecutionChunkSize = null.IntFrom(2)
+		var deadline time.Duration
+		c.Overrides.KeeperShutdownDeadline = &deadline
+	})
+	require.NoError(t, ex.StartOnce("UpkeepExecuter", func() error { return nil }))
+
+	upkeeps := []UpkeepRegistration{
+		{UpkeepID: 1, ExecuteGas: 100_000},
+		{UpkeepID: 2, ExecuteGas: 100_000},
+		{UpkeepID: 3, ExecuteGas: 100_000},
+	}
+	executed := make(chan struct{})
+	go func() {
+		defer close(executed)
+		ex.executeUpkeeps(context.Background(), eth.Head{Number: 20}, time.Now(), false, upkeeps, nil, new(headSummary))
+	}()
+
+	// the last upkeep waits for the first chunk to finish although a slot is free
+	require.Eventually(t, func() bool {
+		return ex.inFlight.Load() == 2 && ex.queued.Load() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Never(t, func() bool { return ex.inFlight.Load() > 2 }, 100*time.Millisecond, 10*time.Millisecond)
+
+	require.NoError(t, ex.Close())
+	select {
+	case <-executed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("upkeeps were still being executed after Close")
+	}
+	require.Zero(t, ex.inFlight.Load())
+	require.Zero(t, ex.queued.Load())
+	runner.AssertNumberOfCalls(t, "Run", 2)
+}
@@ -0,0 +1,46 @@
+package keeper
+
+import (
+	"github.com/smartcontractkit/chainlink/core/services/postgres"
+)
+
+// recordExecution counts an execution of upkeep with outcome, until the next flushExecutionCounts
+func (ex *UpkeepExecuter) recordExecution(upkeep UpkeepRegistration, outcome executionOutcome) {
+	ex.executionCountsMu.Lock()
+	defer ex.executionCountsMu.Unlock()
+	if ex.executionCounts == nil {
+		ex.executionCounts = make(map[int32]ExecutionCounts)
+	}
+	counts := ex.executionCounts[upkeep.ID]
+	counts.Checks++
+	switch outcome {
+	case executionPerformed:
+		counts.Performs++
+	case executionSkipped:
+		counts.Skips++
+	}
+	ex.executionCounts[upkeep.ID] = counts
+}
+
+// takeExecutionCounts returns the counted executions and resets them
+func (ex *UpkeepExecuter) takeExecutionCounts() map[int32]ExecutionCounts {
+	ex.executionCountsMu.Lock()
+	defer ex.executionCountsMu.Unlock()
+	counts := ex.executionCounts
+	ex.executionCounts = nil
+	return counts
+}
+
+// flushExecutionCounts adds the counted executions to the execution stats of the upkeeps in a single query,
+// the counts are dropped if it fails
+func (ex *UpkeepExecuter) flushExecutionCounts() {
+	counts := ex.takeExecutionCounts()
+	if len(counts) == 0 {
+		return
+	}
+	ctx, cancel := postgres.DefaultQueryCtx()
+	defer cancel()
+	if err := ex.orm.AddExecutionCounts(ctx, counts); err != nil {
+		ex.logger.Warnw("unable to save the execution counts of upkeeps", "upkeeps", len(counts), "error", err)
+	}
+}
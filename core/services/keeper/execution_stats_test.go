@@ -0,0 +1,24 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordExecution(t *testing.T) {
+	t.Parallel()
+
+	ex := newTestExecuter(t, testExecuterDeps{})
+	first, second := UpkeepRegistration{ID: 1}, UpkeepRegistration{ID: 2}
+	ex.recordExecution(first, executionPerformed)
+	ex.recordExecution(first, executionSkipped)
+	ex.recordExecution(first, executionFailed)
+	ex.recordExecution(second, executionSkipped)
+
+	require.Equal(t, map[int32]ExecutionCounts{
+		1: {Checks: 3, Performs: 1, Skips: 1},
+		2: {Checks: 1, Skips: 1},
+	}, ex.takeExecutionCounts())
+	require.Empty(t, ex.takeExecutionCounts())
+}
@@ -0,0 +1,128 @@
+package keeper
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/configtest"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	pipelinemocks "github.com/smartcontractkit/chainlink/core/services/pipeline/mocks"
+)
+
+func TestExecutionContext_UsesKeeperExecutionTimeout(t *testing.T) {
+	t.Parallel()
+
+	timeout := 100 * time.Millisecond
+	ex := newTestExecuter(t, testExecuterDeps{}, func(c *configtest.TestGeneralConfig) {
+		c.Overrides.KeeperExecutionTimeout = &timeout
+	})
+
+	before := time.Now()
+	ctx, cancel := ex.executionContext(context.Background())
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	require.True(t, ok)
+	require.False(t, deadline.Before(before.Add(timeout)))
+	require.True(t, deadline.Before(before.Add(time.Minute)), "deadline %v should derive from KeeperExecutionTimeout, not one minute", deadline)
+
+	select {
+	case <-ctx.Done():
+		require.Equal(t, context.DeadlineExceeded, ctx.Err())
+	case <-time.After(5 * time.Second):
+		t.Fatal("execution context wasn't cancelled at KeeperExecutionTimeout")
+	}
+}
+
+func TestExecutionContext_CancelledOnForceStop(t *testing.T) {
+	t.Parallel()
+
+	ex := newTestExecuter(t, testExecuterDeps{})
+
+	ctx, cancel := ex.executionContext(context.Background())
+	defer cancel()
+
+	close(ex.chForceStop)
+	select {
+	case <-ctx.Done():
+		require.Equal(t, context.Canceled, ctx.Err())
+	case <-time.After(5 * time.Second):
+		t.Fatal("execution context wasn't cancelled on force stop")
+	}
+}
+
+func TestUpkeepExecuter_Execute_TimeoutVersusShutdown(t *testing.T) {
+	t.Parallel()
+
+	// newExecuter returns an executer whose pipeline runs block until their context is done,
+	// the returned channel is signalled once a run started
+	newExecuter := func(t *testing.T, deps testExecuterDeps, overrides ...func(*configtest.TestGeneralConfig)) (*UpkeepExecuter, chan struct{}) {
+		started := make(chan struct{}, 1)
+		runner := new(pipelinemocks.Runner)
+		runner.On("Run", mock.Anything, mock.Anything, mock.Anything, true, mock.Anything).
+			Run(func(args mock.Arguments) {
+				started <- struct{}{}
+				<-args.Get(0).(context.Context).Done()
+			}).
+			Return(false, context.Canceled)
+		deps.runner = runner
+		return newTestExecuter(t, deps, overrides...), started
+	}
+	// execute executes an upkeep, returning a channel that receives its outcome
+	execute := func(ex *UpkeepExecuter) chan executionOutcome {
+		outcomes := make(chan executionOutcome, 1)
+		checked := &checkUpkeepResult{PerformData: []byte{}, MaxLinkPayment: big.NewInt(1)}
+		upkeep := UpkeepRegistration{UpkeepID: 1, ExecuteGas: 100_000}
+		go ex.execute(context.Background(), upkeep, eth.Head{Number: 20}, time.Now(), false, checked, nil, nil, func(outcome executionOutcome) { outcomes <- outcome })
+		return outcomes
+	}
+	awaitOutcome := func(t *testing.T, outcomes chan executionOutcome) executionOutcome {
+		select {
+		case outcome := <-outcomes:
+			return outcome
+		case <-time.After(5 * time.Second):
+			t.Fatal("execution didn't end")
+		}
+		return executionSkipped
+	}
+
+	t.Run("timed out executions fail", func(t *testing.T) {
+		recorder := &recordingMetrics{}
+		// the failure of a timed out execution is recorded on the upkeep
+		ex, _ := newExecuter(t, testExecuterDeps{db: pgtest.NewGormDB(t), metrics: recorder}, func(c *configtest.TestGeneralConfig) {
+			timeout := 50 * time.Millisecond
+			c.Overrides.KeeperExecutionTimeout = &timeout
+		})
+
+		require.Equal(t, executionFailed, awaitOutcome(t, execute(ex)))
+		require.Equal(t, 1, recorder.executionTimeouts)
+		require.Zero(t, ex.abandoned.Load())
+	})
+
+	t.Run("executions cancelled by Close are abandoned", func(t *testing.T) {
+		recorder := &recordingMetrics{}
+		ex, started := newExecuter(t, testExecuterDeps{metrics: recorder}, func(c *configtest.TestGeneralConfig) {
+			var deadline time.Duration
+			c.Overrides.KeeperShutdownDeadline = &deadline
+		})
+		require.NoError(t, ex.StartOnce("UpkeepExecuter", func() error { return nil }))
+
+		outcomes := execute(ex)
+		select {
+		case <-started:
+		case <-time.After(5 * time.Second):
+			t.Fatal("pipeline run wasn't started")
+		}
+		require.NoError(t, ex.Close())
+
+		require.Equal(t, executionSkipped, awaitOutcome(t, outcomes))
+		require.Zero(t, recorder.executionTimeouts)
+		require.Equal(t, int64(1), ex.abandoned.Load())
+	})
+}
@@ -0,0 +1,50 @@
+package keeper
+
+import (
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+// failureBackoff returns the number of blocks to wait after the last failed execution of an upkeep that failed
+// failureCount consecutive times: 1 block after the first failure, doubling with every further one up to maxBlocks
+func failureBackoff(failureCount int32, maxBlocks uint32) int64 {
+	if failureCount <= 0 {
+		return 0
+	}
+	backoff := int64(1)
+	for i := int32(1); i < failureCount && backoff < int64(maxBlocks); i++ {
+		backoff *= 2
+	}
+	if backoff > int64(maxBlocks) {
+		return int64(maxBlocks)
+	}
+	return backoff
+}
+
+// excludeBackedOff drops the upkeeps still backing off from their last failed execution on head,
+// as recorded by SetLastErrorForUpkeepOnJob
+func (ex *UpkeepExecuter) excludeBackedOff(head eth.Head, upkeeps []UpkeepRegistration) []UpkeepRegistration {
+	maxBlocks := ex.config.KeeperMaximumBackoffBlocks()
+	if maxBlocks == 0 {
+		return upkeeps
+	}
+
+	var excluded []int64
+	remaining := upkeeps[:0]
+	for _, upkeep := range upkeeps {
+		if backingOff(upkeep, head.Number, maxBlocks) {
+			excluded = append(excluded, upkeep.UpkeepID)
+			continue
+		}
+		remaining = append(remaining, upkeep)
+	}
+	if len(excluded) > 0 {
+		ex.logger.Debugw("excluding upkeeps backing off from failed executions", "blockheight", head.Number, "upkeepIDs", excluded)
+	}
+	return remaining
+}
+
+// backingOff returns true if upkeep is still backing off on blockNumber from its last failed execution
+func backingOff(upkeep UpkeepRegistration, blockNumber int64, maxBlocks uint32) bool {
+	return maxBlocks > 0 && upkeep.LastErrorBlockHeight.Valid &&
+		blockNumber < upkeep.LastErrorBlockHeight.Int64+failureBackoff(upkeep.FailureCount, maxBlocks)
+}
@@ -0,0 +1,66 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/configtest"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+func TestFailureBackoff(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, int64(0), failureBackoff(0, 8))
+	require.Equal(t, int64(1), failureBackoff(1, 8))
+	require.Equal(t, int64(2), failureBackoff(2, 8))
+	require.Equal(t, int64(4), failureBackoff(3, 8))
+	require.Equal(t, int64(8), failureBackoff(4, 8))
+	require.Equal(t, int64(8), failureBackoff(5, 8))
+	require.Equal(t, int64(8), failureBackoff(1000, 8))
+	require.Equal(t, int64(5), failureBackoff(4, 5))
+}
+
+func TestExcludeBackedOff(t *testing.T) {
+	t.Parallel()
+
+	newExecuter := func(t *testing.T, maxBlocks int64) *UpkeepExecuter {
+		return newTestExecuter(t, testExecuterDeps{}, func(c *configtest.TestGeneralConfig) {
+			c.Overrides.KeeperMaximumBackoffBlocks = null.IntFrom(maxBlocks)
+		})
+	}
+	failedAt := func(height int64, failureCount int32) UpkeepRegistration {
+		return UpkeepRegistration{UpkeepID: 1, LastErrorBlockHeight: null.IntFrom(height), FailureCount: failureCount}
+	}
+	// retriedAt returns the first head after which the upkeep is no longer excluded
+	retriedAt := func(ex *UpkeepExecuter, upkeep UpkeepRegistration) int64 {
+		for number := upkeep.LastErrorBlockHeight.Int64; ; number++ {
+			if len(ex.excludeBackedOff(eth.Head{Number: number}, []UpkeepRegistration{upkeep})) == 1 {
+				return number
+			}
+		}
+	}
+
+	ex := newExecuter(t, 4)
+	require.Equal(t, int64(101), retriedAt(ex, failedAt(100, 1)))
+	require.Equal(t, int64(102), retriedAt(ex, failedAt(100, 2)))
+	require.Equal(t, int64(104), retriedAt(ex, failedAt(100, 3)))
+	require.Equal(t, int64(104), retriedAt(ex, failedAt(100, 4)), "backoff is capped")
+	require.Equal(t, int64(104), retriedAt(ex, failedAt(100, 10)), "backoff is capped")
+
+	t.Run("succeeded since the last failure", func(t *testing.T) {
+		require.Equal(t, int64(100), retriedAt(ex, failedAt(100, 0)))
+	})
+
+	t.Run("never failed", func(t *testing.T) {
+		upkeeps := []UpkeepRegistration{{UpkeepID: 1}}
+		require.Len(t, ex.excludeBackedOff(eth.Head{Number: 100}, upkeeps), 1)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		ex := newExecuter(t, 0)
+		require.Equal(t, int64(100), retriedAt(ex, failedAt(100, 10)))
+	})
+}
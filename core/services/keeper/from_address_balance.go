@@ -0,0 +1,51 @@
+package keeper
+
+import (
+	"math/big"
+
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+// fromAddressBalanceCheck is the result of the KeeperMinimumBalance check for a head
+type fromAddressBalanceCheck struct {
+	headNumber int64
+	sufficient bool
+}
+
+// sufficientBalance returns false if the balance of the keeper fromAddress on head is below
+// KeeperMinimumBalance, in which case the perform transactions would fail to be submitted.
+// The balance is fetched once per head height, if it can't be determined performing goes ahead.
+// It must only be called from the run goroutine.
+func (ex *UpkeepExecuter) sufficientBalance(head eth.Head) bool {
+	minBalance := ex.config.KeeperMinimumBalance()
+	if minBalance == nil || minBalance.Sign() <= 0 {
+		return true
+	}
+	if ex.balanceCheck != nil && ex.balanceCheck.headNumber == head.Number {
+		return ex.balanceCheck.sufficient
+	}
+
+	fromAddress := ex.job.KeeperSpec.FromAddress.Address()
+	ctx, cancel := utils.ContextFromChan(ex.chStop)
+	defer cancel()
+	balance, err := ex.ethClient.BalanceAt(ctx, fromAddress, big.NewInt(head.Number))
+	if err != nil {
+		ex.logger.Warnw("unable to check the balance of the keeper fromAddress", "blockheight", head.Number, "error", err)
+		return true
+	}
+	sufficient := balance.Cmp(minBalance) >= 0
+	ex.balanceCheck = &fromAddressBalanceCheck{headNumber: head.Number, sufficient: sufficient}
+	if ex.insufficientBalance.CAS(sufficient, !sufficient) {
+		ex.metrics.SetInsufficientBalance(ex.job.KeeperSpec.ContractAddress.Hex(), !sufficient)
+		if sufficient {
+			ex.logger.Infow("balance of the keeper fromAddress is above KeeperMinimumBalance again, resuming upkeeps",
+				"blockheight", head.Number, "fromAddress", fromAddress.Hex(), "balance", balance, "minimumBalance", minBalance)
+		}
+	}
+	if !sufficient {
+		ex.logger.Errorw("skipping head, the balance of the keeper fromAddress is below KeeperMinimumBalance and its perform transactions would fail",
+			"blockheight", head.Number, "fromAddress", fromAddress.Hex(), "balance", balance, "minimumBalance", minBalance)
+	}
+	return sufficient
+}
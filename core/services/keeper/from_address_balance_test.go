@@ -0,0 +1,86 @@
+package keeper
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/configtest"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	ethmocks "github.com/smartcontractkit/chainlink/core/services/eth/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/services/keystore/keys/ethkey"
+)
+
+func TestUpkeepExecuter_SufficientBalance(t *testing.T) {
+	t.Parallel()
+
+	fromAddress := common.HexToAddress("0x2ab9a2Dc53736b361b72d900CdF9F78F9406fbbb")
+	newExecuter := func(t *testing.T, minBalance *big.Int) (*UpkeepExecuter, *ethmocks.Client, *recordingMetrics) {
+		ethClient := new(ethmocks.Client)
+		metrics := &recordingMetrics{}
+		spec := &job.KeeperSpec{FromAddress: ethkey.EIP55AddressFromAddress(fromAddress)}
+		ex := newTestExecuter(t, testExecuterDeps{keeperSpec: spec, ethClient: ethClient, metrics: metrics}, func(c *configtest.TestGeneralConfig) {
+			c.Overrides.KeeperMinimumBalance = minBalance
+		})
+		require.NoError(t, ex.StartOnce("UpkeepExecuter", func() error { return nil }))
+		return ex, ethClient, metrics
+	}
+	head := eth.Head{Number: 20}
+
+	t.Run("skips heads while the balance is below the minimum", func(t *testing.T) {
+		ex, ethClient, metrics := newExecuter(t, assets.Ether(1))
+		ethClient.On("BalanceAt", mock.Anything, fromAddress, big.NewInt(20)).Once().Return(new(big.Int).Sub(assets.Ether(1), big.NewInt(1)), nil)
+
+		require.False(t, ex.sufficientBalance(head))
+		require.Error(t, ex.Healthy())
+		require.Equal(t, []bool{true}, metrics.insufficientBalance)
+
+		// the balance is only fetched once per head
+		require.False(t, ex.sufficientBalance(head))
+		ethClient.AssertExpectations(t)
+	})
+
+	t.Run("proceeds while the balance covers the minimum", func(t *testing.T) {
+		ex, ethClient, metrics := newExecuter(t, assets.Ether(1))
+		ethClient.On("BalanceAt", mock.Anything, fromAddress, big.NewInt(20)).Once().Return(assets.Ether(1), nil)
+
+		require.True(t, ex.sufficientBalance(head))
+		require.True(t, ex.sufficientBalance(head))
+		require.NoError(t, ex.Healthy())
+		require.Empty(t, metrics.insufficientBalance)
+		ethClient.AssertExpectations(t)
+	})
+
+	t.Run("resumes once the balance is topped up", func(t *testing.T) {
+		ex, ethClient, metrics := newExecuter(t, assets.Ether(1))
+		ethClient.On("BalanceAt", mock.Anything, fromAddress, big.NewInt(20)).Once().Return(big.NewInt(0), nil)
+		ethClient.On("BalanceAt", mock.Anything, fromAddress, big.NewInt(21)).Once().Return(assets.Ether(2), nil)
+
+		require.False(t, ex.sufficientBalance(head))
+		require.True(t, ex.sufficientBalance(eth.Head{Number: 21}))
+		require.NoError(t, ex.Healthy())
+		require.Equal(t, []bool{true, false}, metrics.insufficientBalance)
+		ethClient.AssertExpectations(t)
+	})
+
+	t.Run("proceeds if the balance can't be fetched", func(t *testing.T) {
+		ex, ethClient, _ := newExecuter(t, assets.Ether(1))
+		ethClient.On("BalanceAt", mock.Anything, fromAddress, big.NewInt(20)).Return(nil, errors.New("connection refused"))
+
+		require.True(t, ex.sufficientBalance(head))
+		require.NoError(t, ex.Healthy())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		ex, ethClient, _ := newExecuter(t, big.NewInt(0))
+
+		require.True(t, ex.sufficientBalance(head))
+		ethClient.AssertNotCalled(t, "BalanceAt", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,41 @@
+package keeper
+
+import (
+	"context"
+	"io"
+	"math/big"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+// estimateGasPriceWithRetries is estimateGasPrice, retrying transient errors of the gas estimator up to
+// KeeperGasEstimationRetries times. It gives up early if ctx is done while waiting for a retry.
+func (ex *UpkeepExecuter) estimateGasPriceWithRetries(ctx context.Context, upkeep UpkeepRegistration, head eth.Head, performData []byte, prices *headGasPrices, lggr logger.Logger) (*big.Int, uint64, error) {
+	gasPrice, gasLimit, err := ex.estimateGasPrice(ctx, upkeep, head, performData, prices)
+	for attempt := uint32(1); err != nil && isTransientGasEstimationError(err) && attempt <= ex.config.KeeperGasEstimationRetries(); attempt++ {
+		lggr.Warnw("gas price estimate failed, retrying", "attempt", attempt, "error", err)
+		select {
+		case <-time.After(time.Duration(attempt) * ex.config.KeeperGasEstimationRetryBackoff()):
+		case <-ctx.Done():
+			return nil, 0, err
+		}
+		gasPrice, gasLimit, err = ex.estimateGasPrice(ctx, upkeep, head, performData, prices)
+	}
+	return gasPrice, gasLimit, err
+}
+
+// isTransientGasEstimationError returns true for the errors of a gas estimate that may not recur on retry,
+// i.e. network errors talking to the eth node. Errors building the perform transaction and gas prices
+// above the maximum are not transient.
+func isTransientGasEstimationError(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
@@ -0,0 +1,97 @@
+package keeper
+
+import (
+	"context"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/configtest"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	gasmocks "github.com/smartcontractkit/chainlink/core/services/gas/mocks"
+)
+
+func TestEstimateGasPriceWithRetries(t *testing.T) {
+	t.Parallel()
+
+	networkErr := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	newExecuter := func(t *testing.T, estimator *gasmocks.Estimator, maxGasPrice *big.Int) *UpkeepExecuter {
+		return newTestExecuter(t, testExecuterDeps{estimator: estimator}, func(c *configtest.TestGeneralConfig) {
+			c.Overrides.KeeperGasEstimationRetries = null.IntFrom(2)
+			backoff := time.Millisecond
+			c.Overrides.KeeperGasEstimationRetryBackoff = &backoff
+			c.Overrides.KeeperMaximumGasPrice = maxGasPrice
+		})
+	}
+	upkeep := UpkeepRegistration{UpkeepID: 1, ExecuteGas: 100_000}
+	head := eth.Head{Number: 20}
+
+	t.Run("retries transient errors", func(t *testing.T) {
+		estimator := new(gasmocks.Estimator)
+		estimator.On("EstimateGas", mock.Anything, mock.Anything).Return((*big.Int)(nil), uint64(0), errors.Wrap(networkErr, "fetching gas price")).Twice()
+		estimator.On("EstimateGas", mock.Anything, mock.Anything).Return(assets.GWei(60), uint64(0), nil).Once()
+		ex := newExecuter(t, estimator, nil)
+
+		gasPrice, _, err := ex.estimateGasPriceWithRetries(context.Background(), upkeep, head, testPerformData, nil, logger.Default)
+		require.NoError(t, err)
+		require.NotNil(t, gasPrice)
+		estimator.AssertNumberOfCalls(t, "EstimateGas", 3)
+	})
+
+	t.Run("gives up after KeeperGasEstimationRetries", func(t *testing.T) {
+		estimator := new(gasmocks.Estimator)
+		estimator.On("EstimateGas", mock.Anything, mock.Anything).Return((*big.Int)(nil), uint64(0), networkErr)
+		ex := newExecuter(t, estimator, nil)
+
+		_, _, err := ex.estimateGasPriceWithRetries(context.Background(), upkeep, head, testPerformData, nil, logger.Default)
+		require.True(t, errors.As(err, new(net.Error)))
+		estimator.AssertNumberOfCalls(t, "EstimateGas", 3)
+	})
+
+	t.Run("doesn't retry other errors", func(t *testing.T) {
+		estimator := new(gasmocks.Estimator)
+		estimator.On("EstimateGas", mock.Anything, mock.Anything).Return((*big.Int)(nil), uint64(0), errors.New("estimator not started"))
+		ex := newExecuter(t, estimator, nil)
+
+		_, _, err := ex.estimateGasPriceWithRetries(context.Background(), upkeep, head, testPerformData, nil, logger.Default)
+		require.Error(t, err)
+		estimator.AssertNumberOfCalls(t, "EstimateGas", 1)
+
+		estimator = new(gasmocks.Estimator)
+		estimator.On("EstimateGas", mock.Anything, mock.Anything).Return(assets.GWei(60), uint64(0), nil)
+		ex = newExecuter(t, estimator, assets.GWei(50))
+
+		_, _, err = ex.estimateGasPriceWithRetries(context.Background(), upkeep, head, testPerformData, nil, logger.Default)
+		require.True(t, errors.Is(err, errGasPriceAboveMaximum))
+		estimator.AssertNumberOfCalls(t, "EstimateGas", 1)
+	})
+
+	t.Run("stops retrying when the execution is cancelled", func(t *testing.T) {
+		estimator := new(gasmocks.Estimator)
+		estimator.On("EstimateGas", mock.Anything, mock.Anything).Return((*big.Int)(nil), uint64(0), networkErr)
+		ex := newExecuter(t, estimator, nil)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, _, err := ex.estimateGasPriceWithRetries(ctx, upkeep, head, testPerformData, nil, logger.Default)
+		require.Error(t, err)
+		estimator.AssertNumberOfCalls(t, "EstimateGas", 1)
+	})
+}
+
+func TestIsTransientGasEstimationError(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, isTransientGasEstimationError(errors.Wrap(&net.OpError{Op: "read", Err: errors.New("reset")}, "estimating")))
+	require.True(t, isTransientGasEstimationError(errors.Wrap(context.DeadlineExceeded, "estimating")))
+	require.False(t, isTransientGasEstimationError(errors.New("unable to construct performUpkeep data")))
+	require.False(t, isTransientGasEstimationError(errors.Wrap(errGasPriceAboveMaximum, "estimated 60 gwei")))
+}
@@ -0,0 +1,295 @@
+package keeper
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/configtest"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/services/gas"
+	gasmocks "github.com/smartcontractkit/chainlink/core/services/gas/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/utils"
+	bigmath "github.com/smartcontractkit/chainlink/core/utils/big_math"
+)
+
+// testPerformData is the performData returned by checkUpkeep for the upkeeps of the gas estimation tests
+var testPerformData = common.Hex2Bytes("1234")
+
+func TestEstimateGasPrice_UsesPerformData(t *testing.T) {
+	t.Parallel()
+
+	estimator := new(gasmocks.Estimator)
+	// price the perform by the size of its calldata, like an estimator accounting for L1 data costs would
+	estimator.On("EstimateGas", mock.Anything, mock.Anything).Return(
+		func(calldata []byte, _ uint64, _ ...gas.Opt) *big.Int {
+			return new(big.Int).Mul(big.NewInt(int64(len(calldata))), assets.GWei(1))
+		},
+		uint64(0),
+		nil,
+	)
+	ex := newTestExecuter(t, testExecuterDeps{estimator: estimator})
+	upkeep := UpkeepRegistration{UpkeepID: 1, ExecuteGas: 100_000}
+	head := eth.Head{Number: 20}
+
+	small, _, err := ex.estimateGasPrice(context.Background(), upkeep, head, make([]byte, 32), nil)
+	require.NoError(t, err)
+	large, _, err := ex.estimateGasPrice(context.Background(), upkeep, head, make([]byte, 3200), nil)
+	require.NoError(t, err)
+
+	// the large performData adds 3168 bytes of calldata over the small one
+	require.True(t, large.Cmp(bigmath.Mul(small, 10)) > 0, "small=%s large=%s", small, large)
+
+	calls := estimator.Calls
+	require.Len(t, calls, 2)
+	require.Less(t, len(calls[0].Arguments.Get(0).([]byte)), len(calls[1].Arguments.Get(0).([]byte)))
+}
+
+func TestEstimateGasPrice_RequiresPerformData(t *testing.T) {
+	t.Parallel()
+
+	estimator := new(gasmocks.Estimator)
+	ex := newTestExecuter(t, testExecuterDeps{estimator: estimator})
+
+	_, _, err := ex.estimateGasPrice(context.Background(), UpkeepRegistration{UpkeepID: 1, ExecuteGas: 100_000}, eth.Head{Number: 20}, nil, nil)
+	require.Error(t, err)
+	estimator.AssertNotCalled(t, "EstimateGas", mock.Anything, mock.Anything)
+}
+
+func TestEstimateGasPrice_KeeperMaximumGasPrice(t *testing.T) {
+	t.Parallel()
+
+	newExecuter := func(t *testing.T, maxGasPrice *big.Int) *UpkeepExecuter {
+		return newTestExecuter(t, testExecuterDeps{}, func(c *configtest.TestGeneralConfig) {
+			c.Overrides.KeeperMaximumGasPrice = maxGasPrice
+		})
+	}
+	upkeep := UpkeepRegistration{UpkeepID: 1, ExecuteGas: 100_000}
+	head := eth.Head{Number: 20}
+
+	t.Run("zero means no cap", func(t *testing.T) {
+		ex := newExecuter(t, big.NewInt(0))
+		gasPrice, _, err := ex.estimateGasPrice(context.Background(), upkeep, head, testPerformData, nil)
+		require.NoError(t, err)
+		buffered := bigmath.Div(bigmath.Mul(assets.GWei(60), 100+ex.config.KeeperGasPriceBufferPercent()), 100)
+		require.Equal(t, buffered.String(), gasPrice.String())
+	})
+
+	t.Run("clamps the buffered gas price", func(t *testing.T) {
+		// the default KeeperGasPriceBufferPercent buffers the 60 gwei estimate above the cap
+		gasPrice, _, err := newExecuter(t, assets.GWei(61)).estimateGasPrice(context.Background(), upkeep, head, testPerformData, nil)
+		require.NoError(t, err)
+		require.Equal(t, assets.GWei(61).String(), gasPrice.String())
+	})
+
+	t.Run("errors if the unbuffered estimate exceeds the cap", func(t *testing.T) {
+		_, _, err := newExecuter(t, assets.GWei(50)).estimateGasPrice(context.Background(), upkeep, head, testPerformData, nil)
+		require.True(t, errors.Is(err, errGasPriceAboveMaximum), err)
+	})
+}
+
+func TestEstimateGasPrice_UpkeepMaxGasPrice(t *testing.T) {
+	t.Parallel()
+
+	newExecuter := func(t *testing.T, override job.KeeperUpkeepOverride) *UpkeepExecuter {
+		spec := &job.KeeperSpec{UpkeepOverrides: job.KeeperUpkeepOverrides{"1": override}}
+		return newTestExecuter(t, testExecuterDeps{keeperSpec: spec}, func(c *configtest.TestGeneralConfig) {
+			c.Overrides.KeeperMaximumGasPrice = assets.GWei(100)
+		})
+	}
+	upkeep := UpkeepRegistration{UpkeepID: 1, ExecuteGas: 100_000}
+	head := eth.Head{Number: 20}
+	gwei := func(n uint64) *uint64 {
+		wei := n * 1_000_000_000
+		return &wei
+	}
+
+	t.Run("clamps the buffered gas price below the global cap", func(t *testing.T) {
+		gasPrice, _, err := newExecuter(t, job.KeeperUpkeepOverride{MaxGasPriceWei: gwei(61)}).estimateGasPrice(context.Background(), upkeep, head, testPerformData, nil)
+		require.NoError(t, err)
+		require.Equal(t, assets.GWei(61).String(), gasPrice.String())
+	})
+
+	t.Run("clamps the estimate if it isn't skipped", func(t *testing.T) {
+		gasPrice, _, err := newExecuter(t, job.KeeperUpkeepOverride{MaxGasPriceWei: gwei(50)}).estimateGasPrice(context.Background(), upkeep, head, testPerformData, nil)
+		require.NoError(t, err)
+		require.Equal(t, assets.GWei(50).String(), gasPrice.String())
+	})
+
+	t.Run("errors if the unbuffered estimate exceeds the cap and the upkeep is skipped", func(t *testing.T) {
+		_, _, err := newExecuter(t, job.KeeperUpkeepOverride{MaxGasPriceWei: gwei(50), SkipAboveMaxGasPrice: true}).estimateGasPrice(context.Background(), upkeep, head, testPerformData, nil)
+		require.True(t, errors.Is(err, errGasPriceAboveUpkeepMaximum), err)
+	})
+
+	t.Run("other upkeeps are capped globally", func(t *testing.T) {
+		ex := newExecuter(t, job.KeeperUpkeepOverride{MaxGasPriceWei: gwei(50), SkipAboveMaxGasPrice: true})
+		gasPrice, _, err := ex.estimateGasPrice(context.Background(), UpkeepRegistration{UpkeepID: 2, ExecuteGas: 100_000}, head, testPerformData, nil)
+		require.NoError(t, err)
+		buffered := bigmath.Div(bigmath.Mul(assets.GWei(60), 100+ex.config.KeeperGasPriceBufferPercent()), 100)
+		require.Equal(t, buffered.String(), gasPrice.String())
+	})
+}
+
+func TestApplyRegistryMaxGasPrice(t *testing.T) {
+	t.Parallel()
+
+	newExecuter := func(t *testing.T, clamp bool) *UpkeepExecuter {
+		return newTestExecuter(t, testExecuterDeps{}, func(c *configtest.TestGeneralConfig) {
+			c.Overrides.KeeperClampToRegistryMaxGasPrice = null.BoolFrom(clamp)
+		})
+	}
+	checked := &checkUpkeepResult{MaxValidGasPrice: assets.GWei(50)}
+
+	t.Run("clamps the gas price to the registry maximum", func(t *testing.T) {
+		gasPrice, err := newExecuter(t, true).applyRegistryMaxGasPrice(assets.GWei(60), checked, logger.Default)
+		require.NoError(t, err)
+		require.Equal(t, assets.GWei(50).String(), gasPrice.String())
+	})
+
+	t.Run("errors if the gas price exceeds the registry maximum and clamping is disabled", func(t *testing.T) {
+		_, err := newExecuter(t, false).applyRegistryMaxGasPrice(assets.GWei(60), checked, logger.Default)
+		require.True(t, errors.Is(err, errGasPriceAboveRegistryMaximum), err)
+	})
+
+	t.Run("leaves gas prices up to the registry maximum alone", func(t *testing.T) {
+		gasPrice, err := newExecuter(t, false).applyRegistryMaxGasPrice(assets.GWei(50), checked, logger.Default)
+		require.NoError(t, err)
+		require.Equal(t, assets.GWei(50).String(), gasPrice.String())
+	})
+
+	t.Run("no-op if the registry doesn't return a maximum", func(t *testing.T) {
+		for _, checked := range []*checkUpkeepResult{nil, {}} {
+			gasPrice, err := newExecuter(t, false).applyRegistryMaxGasPrice(assets.GWei(60), checked, logger.Default)
+			require.NoError(t, err)
+			require.Equal(t, assets.GWei(60).String(), gasPrice.String())
+		}
+	})
+}
+
+func TestEstimateGasPrice_UpkeepGasPriceBufferPercent(t *testing.T) {
+	t.Parallel()
+
+	aggressive := uint32(50)
+	ex := newTestExecuter(t, testExecuterDeps{keeperSpec: &job.KeeperSpec{
+		UpkeepOverrides: job.KeeperUpkeepOverrides{"1": {GasPriceBufferPercent: &aggressive}},
+	}})
+	head := eth.Head{Number: 20}
+
+	liquidation, _, err := ex.estimateGasPrice(context.Background(), UpkeepRegistration{UpkeepID: 1, ExecuteGas: 100_000}, head, testPerformData, nil)
+	require.NoError(t, err)
+	routine, _, err := ex.estimateGasPrice(context.Background(), UpkeepRegistration{UpkeepID: 2, ExecuteGas: 100_000}, head, testPerformData, nil)
+	require.NoError(t, err)
+
+	require.Equal(t, assets.GWei(90).String(), liquidation.String())
+	buffered := bigmath.Div(bigmath.Mul(assets.GWei(60), 100+ex.config.KeeperGasPriceBufferPercent()), 100)
+	require.Equal(t, buffered.String(), routine.String())
+}
+
+func TestEstimateGasPrice_ReturnsEstimatedGasLimit(t *testing.T) {
+	t.Parallel()
+
+	estimator := new(gasmocks.Estimator)
+	estimator.On("EstimateGas", mock.Anything, uint64(100_000)).Return(assets.GWei(60), uint64(150_000), nil)
+	ex := newTestExecuter(t, testExecuterDeps{estimator: estimator})
+
+	_, gasLimit, err := ex.estimateGasPrice(context.Background(), UpkeepRegistration{UpkeepID: 1, ExecuteGas: 100_000}, eth.Head{Number: 20}, testPerformData, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(150_000), gasLimit)
+}
+
+func TestPerformUpkeepGasLimit(t *testing.T) {
+	t.Parallel()
+
+	upkeep := UpkeepRegistration{UpkeepID: 1, ExecuteGas: 6_000_000}
+
+	t.Run("unclamped by default", func(t *testing.T) {
+		ex := newTestExecuter(t, testExecuterDeps{})
+		expected := upkeep.ExecuteGas + ex.config.KeeperRegistryPerformGasOverhead()
+		require.Equal(t, expected, ex.performUpkeepGasLimit(upkeep, logger.Default))
+	})
+
+	t.Run("clamped to KeeperMaxPerformGasLimit", func(t *testing.T) {
+		ex := newTestExecuter(t, testExecuterDeps{}, func(c *configtest.TestGeneralConfig) {
+			c.Overrides.KeeperMaxPerformGasLimit = null.IntFrom(5_000_000)
+		})
+		require.Equal(t, uint64(5_000_000), ex.performUpkeepGasLimit(upkeep, logger.Default))
+	})
+
+	t.Run("gas limits below KeeperMaxPerformGasLimit are unchanged", func(t *testing.T) {
+		ex := newTestExecuter(t, testExecuterDeps{}, func(c *configtest.TestGeneralConfig) {
+			c.Overrides.KeeperMaxPerformGasLimit = null.IntFrom(10_000_000)
+		})
+		expected := upkeep.ExecuteGas + ex.config.KeeperRegistryPerformGasOverhead()
+		require.Equal(t, expected, ex.performUpkeepGasLimit(upkeep, logger.Default))
+	})
+}
+
+func TestEstimatedGasLimit(t *testing.T) {
+	t.Parallel()
+
+	ex := newTestExecuter(t, testExecuterDeps{})
+
+	t.Run("uses a higher estimate", func(t *testing.T) {
+		require.Equal(t, uint64(150_000), ex.estimatedGasLimit(120_000, 150_000, eth.Head{}, logger.Default))
+	})
+
+	t.Run("keeps the configured gas limit over a lower estimate", func(t *testing.T) {
+		require.Equal(t, uint64(120_000), ex.estimatedGasLimit(120_000, 100_000, eth.Head{}, logger.Default))
+	})
+
+	t.Run("clamps the estimate to the block gas limit", func(t *testing.T) {
+		require.Equal(t, uint64(130_000), ex.estimatedGasLimit(120_000, 150_000, eth.Head{GasLimit: 130_000}, logger.Default))
+	})
+
+	t.Run("clamps the estimate to KeeperMaxPerformGasLimit", func(t *testing.T) {
+		ex := newTestExecuter(t, testExecuterDeps{}, func(c *configtest.TestGeneralConfig) {
+			c.Overrides.KeeperMaxPerformGasLimit = null.IntFrom(140_000)
+		})
+		require.Equal(t, uint64(140_000), ex.estimatedGasLimit(120_000, 150_000, eth.Head{}, logger.Default))
+	})
+}
+
+func TestReconcileGasPrice(t *testing.T) {
+	t.Parallel()
+
+	estimate := assets.GWei(60)
+	tests := []struct {
+		name     string
+		strategy string
+		weight   int64
+		baseFee  *big.Int
+		expected *big.Int
+	}{
+		{"max prefers a higher base fee", GasReconcileStrategyMax, 0, assets.GWei(80), assets.GWei(80)},
+		{"max keeps a higher estimate", GasReconcileStrategyMax, 0, assets.GWei(40), estimate},
+		{"estimator ignores the base fee", GasReconcileStrategyEstimator, 0, assets.GWei(80), estimate},
+		{"blend weights the estimate", GasReconcileStrategyBlend, 25, assets.GWei(100), assets.GWei(90)},
+		{"blend caps the weight at 100", GasReconcileStrategyBlend, 150, assets.GWei(100), estimate},
+		{"unknown strategies fall back to max", "median", 0, assets.GWei(80), assets.GWei(80)},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			ex := newTestExecuter(t, testExecuterDeps{}, func(c *configtest.TestGeneralConfig) {
+				c.Overrides.KeeperGasReconcileStrategy = null.StringFrom(test.strategy)
+				c.Overrides.KeeperGasReconcileEstimatorWeight = null.IntFrom(test.weight)
+			})
+			require.Equal(t, test.expected, ex.reconcileGasPrice(estimate, utils.NewBig(test.baseFee)))
+		})
+	}
+
+	t.Run("heads without a base fee leave the estimate untouched", func(t *testing.T) {
+		ex := newTestExecuter(t, testExecuterDeps{})
+		require.Equal(t, estimate, ex.reconcileGasPrice(estimate, nil))
+	})
+}
@@ -0,0 +1,91 @@
+package keeper
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/utils"
+	bigmath "github.com/smartcontractkit/chainlink/core/utils/big_math"
+)
+
+// errGasPriceAboveMaximum is returned by estimateGasPrice if the unbuffered estimate exceeds KeeperMaximumGasPrice
+var errGasPriceAboveMaximum = errors.New("gas price estimate exceeds KeeperMaximumGasPrice")
+
+// errGasPriceAboveUpkeepMaximum is returned by estimateGasPrice if the unbuffered estimate exceeds the
+// maxGasPriceWei override of an upkeep with skipAboveMaxGasPrice set
+var errGasPriceAboveUpkeepMaximum = errors.New("gas price estimate exceeds the maxGasPriceWei of the upkeep")
+
+// gasPriceSanityRange is the range of gas price estimates that are plausible on a chain.
+// Estimates outside of it point at a malfunctioning estimator rather than the market.
+type gasPriceSanityRange struct {
+	// min is nil unless a floor is configured with KeeperGasPriceSanityMinWei
+	min *big.Int
+	max *big.Int
+}
+
+// fallbackGasPriceSanityMax is used on chains without a specific maximum
+var fallbackGasPriceSanityMax = assets.GWei(10_000)
+
+var gasPriceSanityMaxes = map[int64]*big.Int{
+	// Optimism L2 gas prices are a small fraction of a gwei, but spikes are bounded like on mainnet
+	10: fallbackGasPriceSanityMax,
+	69: fallbackGasPriceSanityMax,
+	// Polygon gas prices regularly spike far beyond the fallback maximum
+	137:   assets.GWei(100_000),
+	80001: assets.GWei(100_000),
+}
+
+// gasPriceSanityRange returns the plausible gas price range for the chain. KeeperGasPriceSanityMaxWei
+// overrides the chain maximum, there is no floor unless KeeperGasPriceSanityMinWei is set above 0.
+func (ex *UpkeepExecuter) gasPriceSanityRange() gasPriceSanityRange {
+	var r gasPriceSanityRange
+	r.max = gasPriceSanityMaxes[ex.ethClient.ChainID().Int64()]
+	if r.max == nil {
+		r.max = fallbackGasPriceSanityMax
+	}
+	if min, ok := ex.config.KeeperGasPriceSanityMinWei(); ok && min.Sign() > 0 {
+		r.min = min
+	}
+	if max, ok := ex.config.KeeperGasPriceSanityMaxWei(); ok {
+		r.max = max
+	}
+	return r
+}
+
+// sanitizeGasPrice clamps an implausible estimate into the sanity range with a warning
+func (ex *UpkeepExecuter) sanitizeGasPrice(upkeep UpkeepRegistration, gasPrice *big.Int) *big.Int {
+	r := ex.gasPriceSanityRange()
+	var clamped *big.Int
+	switch {
+	case r.min != nil && gasPrice.Cmp(r.min) < 0:
+		clamped = r.min
+	case gasPrice.Cmp(r.max) > 0:
+		clamped = r.max
+	default:
+		return gasPrice
+	}
+	ex.logger.Warnw("gas estimator returned an implausible gas price, clamping it to the sanity range",
+		"upkeepID", upkeep.UpkeepID, "gasPrice", gasPrice, "clampedGasPrice", clamped,
+		"sanityMinWei", r.min, "sanityMaxWei", r.max)
+	ex.metrics.IncImplausibleGasPrices(upkeep.Registry.ContractAddress.Hex())
+	return new(big.Int).Set(clamped)
+}
+
+// checkBaseFeeOverpayment warns if the buffered gas price exceeds the head base fee by more than
+// KeeperBaseFeeOverpaymentFactor, which usually means an aggressive buffer on top of a low base fee
+func (ex *UpkeepExecuter) checkBaseFeeOverpayment(upkeep UpkeepRegistration, gasPrice *big.Int, baseFee *utils.Big) {
+	factor := ex.config.KeeperBaseFeeOverpaymentFactor()
+	if factor == 0 || baseFee == nil || baseFee.ToInt().Sign() <= 0 {
+		return
+	}
+	if gasPrice.Cmp(bigmath.Mul(baseFee.ToInt(), factor)) <= 0 {
+		return
+	}
+	ratio, _ := new(big.Float).Quo(new(big.Float).SetInt(gasPrice), new(big.Float).SetInt(baseFee.ToInt())).Float64()
+	ex.logger.Warnw("buffered gas price is far above the head base fee, the upkeep is likely overpaying",
+		"upkeepID", upkeep.UpkeepID, "gasPrice", gasPrice, "baseFee", baseFee, "ratio", ratio,
+		"maxRatio", factor, "gasPriceBufferPercent", ex.gasPriceBufferPercent(upkeep))
+	ex.metrics.IncBaseFeeOverpayments(upkeep.Registry.ContractAddress.Hex())
+}
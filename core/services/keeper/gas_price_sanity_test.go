@@ -0,0 +1,108 @@
+package keeper
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/configtest"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+func TestGasPriceSanityRange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		chainID int64
+		max     *big.Int
+	}{
+		{"mainnet", 1, assets.GWei(10_000)},
+		{"optimism", 10, assets.GWei(10_000)},
+		{"optimism kovan", 69, assets.GWei(10_000)},
+		{"polygon", 137, assets.GWei(100_000)},
+		{"polygon mumbai", 80001, assets.GWei(100_000)},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ex := newTestExecuter(t, testExecuterDeps{ethClient: &eth.NullClient{CID: big.NewInt(test.chainID)}})
+
+			r := ex.gasPriceSanityRange()
+			require.Nil(t, r.min, "the floor is opt-in")
+			require.Equal(t, test.max.String(), r.max.String())
+		})
+	}
+
+	t.Run("config overrides", func(t *testing.T) {
+		t.Parallel()
+		ex := newTestExecuter(t, testExecuterDeps{ethClient: &eth.NullClient{CID: big.NewInt(137)}}, func(c *configtest.TestGeneralConfig) {
+			c.Overrides.KeeperGasPriceSanityMinWei = assets.GWei(1)
+			c.Overrides.KeeperGasPriceSanityMaxWei = assets.GWei(500)
+		})
+
+		r := ex.gasPriceSanityRange()
+		require.Equal(t, assets.GWei(1).String(), r.min.String())
+		require.Equal(t, assets.GWei(500).String(), r.max.String())
+	})
+
+	t.Run("a floor of 0 is off", func(t *testing.T) {
+		t.Parallel()
+		ex := newTestExecuter(t, testExecuterDeps{}, func(c *configtest.TestGeneralConfig) {
+			c.Overrides.KeeperGasPriceSanityMinWei = big.NewInt(0)
+		})
+
+		require.Nil(t, ex.gasPriceSanityRange().min)
+	})
+}
+
+func TestSanitizeGasPrice(t *testing.T) {
+	t.Parallel()
+
+	newExecuter := func(t *testing.T, overrides ...func(*configtest.TestGeneralConfig)) (*UpkeepExecuter, *recordingMetrics) {
+		metrics := &recordingMetrics{}
+		return newTestExecuter(t, testExecuterDeps{metrics: metrics}, overrides...), metrics
+	}
+	upkeep := UpkeepRegistration{UpkeepID: 1}
+
+	t.Run("leaves plausible estimates alone", func(t *testing.T) {
+		ex, metrics := newExecuter(t)
+
+		require.Equal(t, assets.GWei(60).String(), ex.sanitizeGasPrice(upkeep, assets.GWei(60)).String())
+		require.Equal(t, 0, metrics.implausibleGasPrices)
+	})
+
+	t.Run("does not raise low estimates by default", func(t *testing.T) {
+		ex, metrics := newExecuter(t)
+
+		require.Equal(t, "1", ex.sanitizeGasPrice(upkeep, big.NewInt(1)).String())
+		require.Equal(t, 0, metrics.implausibleGasPrices)
+	})
+
+	t.Run("clamps to the maximum", func(t *testing.T) {
+		ex, metrics := newExecuter(t)
+
+		require.Equal(t, assets.GWei(10_000).String(), ex.sanitizeGasPrice(upkeep, assets.GWei(20_000)).String())
+		require.Equal(t, 1, metrics.implausibleGasPrices)
+	})
+
+	t.Run("clamps to the configured floor", func(t *testing.T) {
+		ex, metrics := newExecuter(t, func(c *configtest.TestGeneralConfig) {
+			c.Overrides.KeeperGasPriceSanityMinWei = assets.GWei(1)
+		})
+
+		require.Equal(t, assets.GWei(1).String(), ex.sanitizeGasPrice(upkeep, big.NewInt(1)).String())
+		require.Equal(t, 1, metrics.implausibleGasPrices)
+	})
+
+	t.Run("does not alias the range", func(t *testing.T) {
+		ex, _ := newExecuter(t)
+
+		clamped := ex.sanitizeGasPrice(upkeep, assets.GWei(20_000))
+		clamped.SetInt64(0)
+		require.Equal(t, assets.GWei(10_000).String(), ex.gasPriceSanityRange().max.String())
+	})
+}
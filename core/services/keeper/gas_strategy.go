@@ -0,0 +1,39 @@
+package keeper
+
+import (
+	"context"
+	"math/big"
+
+	bigmath "github.com/smartcontractkit/chainlink/core/utils/big_math"
+)
+
+// UpkeepGasStrategy prices the perform transaction of an upkeep. baseEstimate is the gas price of the
+// gas estimator, after sanity checks and reconciliation with the head base fee. The executer caps the
+// returned price at KeeperMaximumGasPrice and the maxGasPriceWei of the upkeep.
+// It's called from the execution goroutines and must be safe for concurrent use.
+type UpkeepGasStrategy interface {
+	PriceFor(ctx context.Context, upkeep UpkeepRegistration, baseEstimate *big.Int) (*big.Int, error)
+}
+
+// bufferPercentGasStrategy is the default UpkeepGasStrategy, it adds the gas price buffer of the
+// upkeep to the estimate
+type bufferPercentGasStrategy struct {
+	ex *UpkeepExecuter
+}
+
+var _ UpkeepGasStrategy = bufferPercentGasStrategy{}
+
+func (s bufferPercentGasStrategy) PriceFor(_ context.Context, upkeep UpkeepRegistration, baseEstimate *big.Int) (*big.Int, error) {
+	return bigmath.Div(
+		bigmath.Mul(baseEstimate, 100+s.ex.gasPriceBufferPercent(upkeep)),
+		100,
+	), nil
+}
+
+// upkeepGasStrategy returns the UpkeepGasStrategy of the executer, falling back to the gas price buffer
+func (ex *UpkeepExecuter) upkeepGasStrategy() UpkeepGasStrategy {
+	if ex.gasStrategy != nil {
+		return ex.gasStrategy
+	}
+	return bufferPercentGasStrategy{ex}
+}
@@ -0,0 +1,77 @@
+package keeper
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/configtest"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+// fixedGasStrategy prices every perform at gasPrice, recording the estimates it was called with
+type fixedGasStrategy struct {
+	gasPrice *big.Int
+	err      error
+
+	mu        sync.Mutex
+	estimates map[int64]*big.Int
+}
+
+func (s *fixedGasStrategy) PriceFor(_ context.Context, upkeep UpkeepRegistration, baseEstimate *big.Int) (*big.Int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.estimates == nil {
+		s.estimates = make(map[int64]*big.Int)
+	}
+	s.estimates[upkeep.UpkeepID] = baseEstimate
+	return s.gasPrice, s.err
+}
+
+func TestEstimateGasPrice_UpkeepGasStrategy(t *testing.T) {
+	t.Parallel()
+
+	newExecuter := func(t *testing.T, strategy UpkeepGasStrategy) *UpkeepExecuter {
+		return newTestExecuter(t, testExecuterDeps{strategy: strategy}, func(c *configtest.TestGeneralConfig) {
+			c.Overrides.KeeperMaximumGasPrice = assets.GWei(100)
+		})
+	}
+	upkeep := UpkeepRegistration{UpkeepID: 1, ExecuteGas: 100_000}
+	head := eth.Head{Number: 20}
+
+	t.Run("prices the perform with the strategy", func(t *testing.T) {
+		strategy := &fixedGasStrategy{gasPrice: assets.GWei(75)}
+		gasPrice, _, err := newExecuter(t, strategy).estimateGasPrice(context.Background(), upkeep, head, testPerformData, nil)
+		require.NoError(t, err)
+		require.Equal(t, assets.GWei(75).String(), gasPrice.String())
+		require.Equal(t, assets.GWei(60).String(), strategy.estimates[upkeep.UpkeepID].String())
+	})
+
+	t.Run("caps the strategy price at KeeperMaximumGasPrice", func(t *testing.T) {
+		strategy := &fixedGasStrategy{gasPrice: assets.GWei(150)}
+		gasPrice, _, err := newExecuter(t, strategy).estimateGasPrice(context.Background(), upkeep, head, testPerformData, nil)
+		require.NoError(t, err)
+		require.Equal(t, assets.GWei(100).String(), gasPrice.String())
+	})
+
+	t.Run("errors if the strategy fails", func(t *testing.T) {
+		strategy := &fixedGasStrategy{err: errors.New("oracle unavailable")}
+		_, _, err := newExecuter(t, strategy).estimateGasPrice(context.Background(), upkeep, head, testPerformData, nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "oracle unavailable")
+	})
+
+	t.Run("defaults to the gas price buffer", func(t *testing.T) {
+		ex := newExecuter(t, nil)
+		gasPrice, _, err := ex.estimateGasPrice(context.Background(), upkeep, head, testPerformData, nil)
+		require.NoError(t, err)
+		expected, err := bufferPercentGasStrategy{ex}.PriceFor(context.Background(), upkeep, assets.GWei(60))
+		require.NoError(t, err)
+		require.Equal(t, expected.String(), gasPrice.String())
+	})
+}
@@ -0,0 +1,102 @@
+package keeper
+
+import (
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+// blockTimeSmoothing is the weight of the average block time against the block time of a new head
+const blockTimeSmoothing = 4
+
+// observeHeadLag updates whether the executer is behind, i.e. more than KeeperGracePeriodLagThreshold
+// heads arrived since the last processed head while it was busy processing it
+func (ex *UpkeepExecuter) observeHeadLag(head eth.Head) {
+	threshold := ex.config.KeeperGracePeriodLagThreshold()
+	if threshold == 0 || ex.lastHead == nil {
+		return
+	}
+	skipped := head.Number - ex.lastHead.Number - 1
+	behind := skipped > int64(threshold)
+	if behind == ex.behind {
+		return
+	}
+	ex.behind = behind
+	if behind {
+		ex.logger.Warnw("upkeep executer is falling behind, widening the grace period",
+			"blockheight", head.Number, "skippedHeads", skipped, "gracePeriod", ex.gracePeriod())
+	} else {
+		ex.logger.Infow("upkeep executer caught up, restoring the grace period",
+			"blockheight", head.Number, "gracePeriod", ex.gracePeriod())
+	}
+}
+
+// observeBlockTime averages the block times between the last processed head and head, as derived from their timestamps
+func (ex *UpkeepExecuter) observeBlockTime(head eth.Head) {
+	if ex.lastHead == nil || head.Number <= ex.lastHead.Number || head.Timestamp.IsZero() || !head.Timestamp.After(ex.lastHead.Timestamp) {
+		return
+	}
+	blockTime := head.Timestamp.Sub(ex.lastHead.Timestamp) / time.Duration(head.Number-ex.lastHead.Number)
+	if ex.avgBlockTime == 0 {
+		ex.avgBlockTime = blockTime
+		return
+	}
+	ex.avgBlockTime += (blockTime - ex.avgBlockTime) / blockTimeSmoothing
+}
+
+// blockTime returns KeeperAverageBlockTime, or the average block time of the processed heads if unset.
+// It returns 0 if the block time isn't known yet.
+func (ex *UpkeepExecuter) blockTime() time.Duration {
+	if blockTime := ex.config.KeeperAverageBlockTime(); blockTime > 0 {
+		return blockTime
+	}
+	return ex.avgBlockTime
+}
+
+// gracePeriodBlocks returns the number of blocks of blockTime that span at least duration
+func gracePeriodBlocks(duration, blockTime time.Duration) int64 {
+	return int64((duration + blockTime - 1) / blockTime)
+}
+
+// maximumGracePeriod returns KeeperMaximumGracePeriodDuration in blocks, or KeeperMaximumGracePeriod
+// if it's unset or the block time isn't known
+func (ex *UpkeepExecuter) maximumGracePeriod() int64 {
+	duration := ex.config.KeeperMaximumGracePeriodDuration()
+	if duration <= 0 {
+		return ex.config.KeeperMaximumGracePeriod()
+	}
+	blockTime := ex.blockTime()
+	if blockTime <= 0 {
+		return ex.config.KeeperMaximumGracePeriod()
+	}
+	return gracePeriodBlocks(duration, blockTime)
+}
+
+// gracePeriod is the grace period passed to the eligibility query, the maximum grace period
+// widened by KeeperGracePeriodLagMultiplier while the executer is behind
+func (ex *UpkeepExecuter) gracePeriod() int64 {
+	gracePeriod := ex.maximumGracePeriod()
+	if !ex.behind {
+		return gracePeriod
+	}
+	multiplier := ex.config.KeeperGracePeriodLagMultiplier()
+	if multiplier < 1 {
+		return gracePeriod
+	}
+	return int64(float64(gracePeriod) * float64(multiplier))
+}
+
+// ranRecently returns true if upkeep isn't eligible on blockNumber because it ran during the last
+// gracePeriod blocks, less than minConfirmations blocks ago or already during the current turn.
+// It mirrors the run conditions of EligibleUpkeepsForRegistry for upkeeps that aren't loaded by it.
+func ranRecently(upkeep UpkeepRegistration, blockNumber, gracePeriod, minConfirmations int64) bool {
+	lastRun := upkeep.LastRunBlockHeight
+	if lastRun == 0 {
+		return false
+	}
+	turnStart := blockNumber
+	if blockCountPerTurn := int64(upkeep.Registry.BlockCountPerTurn); blockCountPerTurn > 0 {
+		turnStart -= blockNumber % blockCountPerTurn
+	}
+	return lastRun+gracePeriod >= blockNumber || blockNumber-lastRun < minConfirmations || lastRun >= turnStart
+}
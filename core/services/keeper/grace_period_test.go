@@ -0,0 +1,140 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/configtest"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+func TestGracePeriodBlocks(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, int64(30), gracePeriodBlocks(time.Minute, 2*time.Second))
+	require.Equal(t, int64(5), gracePeriodBlocks(time.Minute, 13*time.Second))
+	require.Equal(t, int64(240), gracePeriodBlocks(time.Minute, 250*time.Millisecond))
+	require.Equal(t, int64(1), gracePeriodBlocks(time.Second, 13*time.Second))
+}
+
+func TestMaximumGracePeriod(t *testing.T) {
+	t.Parallel()
+
+	newExecuter := func(t *testing.T, duration, blockTime time.Duration) *UpkeepExecuter {
+		return newTestExecuter(t, testExecuterDeps{}, func(c *configtest.TestGeneralConfig) {
+			c.Overrides.KeeperMaximumGracePeriod = null.IntFrom(100)
+			c.Overrides.KeeperMaximumGracePeriodDuration = &duration
+			c.Overrides.KeeperAverageBlockTime = &blockTime
+		})
+	}
+
+	t.Run("translates the duration with the configured block time", func(t *testing.T) {
+		require.Equal(t, int64(30), newExecuter(t, time.Minute, 2*time.Second).gracePeriod())
+	})
+
+	t.Run("translates the duration with the block time of the processed heads", func(t *testing.T) {
+		ex := newExecuter(t, time.Minute, 0)
+		start := time.Unix(1_600_000_000, 0)
+		for number := int64(0); number < 10; number++ {
+			head := eth.Head{Number: number, Timestamp: start.Add(time.Duration(number) * 3 * time.Second)}
+			ex.observeBlockTime(head)
+			ex.lastHead = &head
+		}
+		require.Equal(t, 3*time.Second, ex.avgBlockTime)
+		require.Equal(t, int64(20), ex.gracePeriod())
+
+		// skipped heads don't skew the block time
+		head := eth.Head{Number: 20, Timestamp: ex.lastHead.Timestamp.Add(33 * time.Second)}
+		ex.observeBlockTime(head)
+		require.Equal(t, 3*time.Second, ex.avgBlockTime)
+	})
+
+	t.Run("falls back to the block count while the block time is unknown", func(t *testing.T) {
+		require.Equal(t, int64(100), newExecuter(t, time.Minute, 0).gracePeriod())
+	})
+
+	t.Run("falls back to the block count if the duration is unset", func(t *testing.T) {
+		require.Equal(t, int64(100), newExecuter(t, 0, 2*time.Second).gracePeriod())
+	})
+}
+
+func TestUpkeepExecuter_ObserveHeadLag(t *testing.T) {
+	t.Parallel()
+
+	newExecuter := func(t *testing.T, threshold uint32, multiplier float64) *UpkeepExecuter {
+		return newTestExecuter(t, testExecuterDeps{}, func(c *configtest.TestGeneralConfig) {
+			c.Overrides.KeeperMaximumGracePeriod = null.IntFrom(100)
+			c.Overrides.KeeperGracePeriodLagThreshold = null.IntFrom(int64(threshold))
+			c.Overrides.KeeperGracePeriodLagMultiplier = null.FloatFrom(multiplier)
+		})
+	}
+	observe := func(ex *UpkeepExecuter, numbers ...int64) {
+		for _, number := range numbers {
+			head := eth.Head{Number: number}
+			ex.observeHeadLag(head)
+			ex.lastHead = &head
+		}
+	}
+
+	t.Run("widens the grace period while behind", func(t *testing.T) {
+		ex := newExecuter(t, 3, 1.5)
+		observe(ex, 10, 14)
+		require.False(t, ex.behind, "3 skipped heads are within the threshold")
+		require.Equal(t, int64(100), ex.gracePeriod())
+
+		observe(ex, 19)
+		require.True(t, ex.behind)
+		require.Equal(t, int64(150), ex.gracePeriod())
+
+		observe(ex, 20)
+		require.False(t, ex.behind)
+		require.Equal(t, int64(100), ex.gracePeriod())
+	})
+
+	t.Run("ignores the lag if the threshold is unset", func(t *testing.T) {
+		ex := newExecuter(t, 0, 1.5)
+		observe(ex, 10, 50)
+		require.False(t, ex.behind)
+		require.Equal(t, int64(100), ex.gracePeriod())
+	})
+
+	t.Run("keeps the grace period if the multiplier is below 1", func(t *testing.T) {
+		ex := newExecuter(t, 3, 0.5)
+		observe(ex, 10, 50)
+		require.True(t, ex.behind)
+		require.Equal(t, int64(100), ex.gracePeriod())
+	})
+}
+
+func TestRanRecently(t *testing.T) {
+	t.Parallel()
+
+	registry := Registry{BlockCountPerTurn: 20}
+	tests := []struct {
+		name             string
+		lastRun          int64
+		blockNumber      int64
+		gracePeriod      int64
+		minConfirmations int64
+		ranRecently      bool
+	}{
+		{"never ran", 0, 45, 10, 0, false},
+		{"outside of the grace period", 30, 45, 10, 0, false},
+		{"inside of the grace period", 38, 45, 10, 0, true},
+		{"at the end of the grace period", 35, 45, 10, 0, true},
+		{"confirmed", 30, 45, 0, 15, false},
+		{"unconfirmed", 35, 45, 0, 15, true},
+		{"ran during the current turn", 41, 45, 0, 0, true},
+		{"ran at the start of the current turn", 40, 45, 0, 0, true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			upkeep := UpkeepRegistration{LastRunBlockHeight: test.lastRun, Registry: registry}
+			require.Equal(t, test.ranRecently, ranRecently(upkeep, test.blockNumber, test.gracePeriod, test.minConfirmations))
+		})
+	}
+}
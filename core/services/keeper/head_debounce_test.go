@@ -0,0 +1,52 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/configtest"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+func TestUpkeepExecuter_Debounced(t *testing.T) {
+	t.Parallel()
+
+	lastHead := eth.Head{Number: 20}
+	tests := []struct {
+		name      string
+		interval  time.Duration
+		sinceLast time.Duration
+		head      eth.Head
+		debounced bool
+	}{
+		{"next head within the interval", time.Minute, time.Second, eth.Head{Number: 21}, true},
+		{"head at the same height within the interval", time.Minute, time.Second, eth.Head{Number: 20}, true},
+		{"next head after the interval", time.Minute, 2 * time.Minute, eth.Head{Number: 21}, false},
+		// heads advancing the chain by several blocks aren't held back
+		{"head skipping blocks within the interval", time.Minute, time.Second, eth.Head{Number: 23}, false},
+		{"no interval", 0, time.Second, eth.Head{Number: 21}, false},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ex := newTestExecuter(t, testExecuterDeps{}, func(c *configtest.TestGeneralConfig) {
+				c.Overrides.KeeperMinHeadInterval = &test.interval
+			})
+			ex.lastHead = &lastHead
+			ex.lastHeadAt = time.Now().Add(-test.sinceLast)
+			require.Equal(t, test.debounced, ex.debounced(test.head))
+		})
+	}
+
+	t.Run("the first head isn't debounced", func(t *testing.T) {
+		interval := time.Minute
+		ex := newTestExecuter(t, testExecuterDeps{}, func(c *configtest.TestGeneralConfig) {
+			c.Overrides.KeeperMinHeadInterval = &interval
+		})
+		require.False(t, ex.debounced(eth.Head{Number: 21}))
+	})
+}
@@ -0,0 +1,79 @@
+package keeper
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/smartcontractkit/chainlink/core/services/gas"
+)
+
+// headGasPrices holds the gas price estimates shared by the upkeeps executed on a head, by gas price speed,
+// for KeeperShareHeadGasPrice. The gas price of a head doesn't depend on the upkeep, unlike the gas limit.
+type headGasPrices struct {
+	mu     sync.Mutex
+	prices map[gas.Speed]*headGasPrice
+}
+
+// headGasPrice is the shared gas price of a speed, its mutex is held while it is estimated
+type headGasPrice struct {
+	mu    sync.Mutex
+	price *big.Int
+}
+
+// newHeadGasPrices returns the gas price cache of a head, or nil if KeeperShareHeadGasPrice is disabled
+func (ex *UpkeepExecuter) newHeadGasPrices() *headGasPrices {
+	if !ex.config.KeeperShareHeadGasPrice() {
+		return nil
+	}
+	return &headGasPrices{prices: make(map[gas.Speed]*headGasPrice)}
+}
+
+// get returns the gas price estimated for speed, calling estimate if it isn't known yet.
+// Concurrent callers of a speed wait for its first estimate, without blocking the other speeds.
+// Failed estimates aren't cached.
+func (p *headGasPrices) get(speed gas.Speed, estimate func() (*big.Int, error)) (*big.Int, error) {
+	p.mu.Lock()
+	shared, ok := p.prices[speed]
+	if !ok {
+		shared = &headGasPrice{}
+		p.prices[speed] = shared
+	}
+	p.mu.Unlock()
+
+	shared.mu.Lock()
+	defer shared.mu.Unlock()
+	if shared.price != nil {
+		return new(big.Int).Set(shared.price), nil
+	}
+	price, err := estimate()
+	if err != nil {
+		return nil, err
+	}
+	shared.price = price
+	return new(big.Int).Set(price), nil
+}
+
+// estimatorGasPrice returns the gas price and gas limit of the gas estimator for the perform transaction,
+// before it is sanitized and buffered. The gas price is shared between the upkeeps of a head if prices
+// isn't nil, the gas limit of the estimator is then unknown and 0 is returned for it.
+func (ex *UpkeepExecuter) estimatorGasPrice(upkeep UpkeepRegistration, performTxData []byte, prices *headGasPrices) (*big.Int, uint64, error) {
+	tiered, isTiered := ex.gasEstimator.(gas.TieredEstimator)
+	estimate := func() (*big.Int, uint64, error) {
+		if isTiered {
+			return tiered.EstimateGasForSpeed(performTxData, upkeep.ExecuteGas, ex.gasPriceSpeed(upkeep))
+		}
+		return ex.gasEstimator.EstimateGas(performTxData, upkeep.ExecuteGas)
+	}
+	if prices == nil || ex.config.KeeperUseEstimatedGasLimit() {
+		return estimate()
+	}
+	var speed gas.Speed
+	if isTiered {
+		speed = ex.gasPriceSpeed(upkeep)
+	}
+	gasPrice, err := prices.get(speed, func() (*big.Int, error) {
+		gasPrice, _, err := estimate()
+		return gasPrice, err
+	})
+	return gasPrice, 0, err
+}
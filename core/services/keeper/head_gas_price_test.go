@@ -0,0 +1,137 @@
+package keeper
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/configtest"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/services/gas"
+	gasmocks "github.com/smartcontractkit/chainlink/core/services/gas/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+)
+
+func TestEstimateGasPrice_SharesHeadGasPrice(t *testing.T) {
+	t.Parallel()
+
+	newExecuter := func(t *testing.T, share, useEstimatedGasLimit bool) (*UpkeepExecuter, *gasmocks.Estimator) {
+		estimator := new(gasmocks.Estimator)
+		estimator.On("EstimateGas", mock.Anything, mock.Anything).Return(assets.GWei(60), uint64(0), nil)
+		aggressive := uint32(50)
+		spec := &job.KeeperSpec{UpkeepOverrides: job.KeeperUpkeepOverrides{"2": {GasPriceBufferPercent: &aggressive}}}
+		return newTestExecuter(t, testExecuterDeps{keeperSpec: spec, estimator: estimator}, func(c *configtest.TestGeneralConfig) {
+			c.Overrides.KeeperShareHeadGasPrice = null.BoolFrom(share)
+			c.Overrides.KeeperUseEstimatedGasLimit = null.BoolFrom(useEstimatedGasLimit)
+		}), estimator
+	}
+	upkeeps := []UpkeepRegistration{
+		{UpkeepID: 1, ExecuteGas: 100_000},
+		{UpkeepID: 2, ExecuteGas: 500_000},
+		{UpkeepID: 3, ExecuteGas: 2_000_000},
+	}
+	head := eth.Head{Number: 20}
+	// estimateAll estimates the gas price of every upkeep, sharing prices between them
+	estimateAll := func(t *testing.T, ex *UpkeepExecuter, prices *headGasPrices) []*big.Int {
+		var gasPrices []*big.Int
+		for i, upkeep := range upkeeps {
+			gasPrice, _, err := ex.estimateGasPrice(context.Background(), upkeep, head, make([]byte, 32*(i+1)), prices)
+			require.NoError(t, err)
+			gasPrices = append(gasPrices, gasPrice)
+		}
+		return gasPrices
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		ex, _ := newExecuter(t, false, false)
+		require.Nil(t, ex.newHeadGasPrices())
+	})
+
+	t.Run("estimates once per head", func(t *testing.T) {
+		ex, estimator := newExecuter(t, true, false)
+
+		shared := estimateAll(t, ex, ex.newHeadGasPrices())
+		estimator.AssertNumberOfCalls(t, "EstimateGas", 1)
+
+		// the buffer of every upkeep still applies to the shared price
+		unshared := estimateAll(t, ex, nil)
+		require.Equal(t, unshared, shared)
+		require.Equal(t, assets.GWei(90).String(), shared[1].String())
+
+		// the next head estimates again
+		estimator.Calls = nil
+		estimateAll(t, ex, ex.newHeadGasPrices())
+		estimator.AssertNumberOfCalls(t, "EstimateGas", 1)
+	})
+
+	t.Run("estimates every upkeep without a shared price", func(t *testing.T) {
+		ex, estimator := newExecuter(t, true, false)
+
+		estimateAll(t, ex, nil)
+		estimator.AssertNumberOfCalls(t, "EstimateGas", len(upkeeps))
+	})
+
+	t.Run("estimates every upkeep when using the estimated gas limit", func(t *testing.T) {
+		ex, estimator := newExecuter(t, true, true)
+
+		estimateAll(t, ex, ex.newHeadGasPrices())
+		estimator.AssertNumberOfCalls(t, "EstimateGas", len(upkeeps))
+	})
+}
+
+func TestHeadGasPrices_Get(t *testing.T) {
+	t.Parallel()
+
+	t.Run("a slow estimate doesn't block the other speeds", func(t *testing.T) {
+		prices := &headGasPrices{prices: make(map[gas.Speed]*headGasPrice)}
+		release := make(chan struct{})
+		slow := make(chan *big.Int, 1)
+		go func() {
+			price, err := prices.get(gas.SpeedEconomy, func() (*big.Int, error) {
+				<-release
+				return assets.GWei(30), nil
+			})
+			assert.NoError(t, err)
+			slow <- price
+		}()
+
+		fast := make(chan *big.Int, 1)
+		go func() {
+			price, err := prices.get(gas.SpeedFast, func() (*big.Int, error) { return assets.GWei(90), nil })
+			assert.NoError(t, err)
+			fast <- price
+		}()
+		select {
+		case price := <-fast:
+			require.Equal(t, assets.GWei(90).String(), price.String())
+		case <-time.After(5 * time.Second):
+			t.Fatal("the fast speed waited for the slow estimate")
+		}
+
+		close(release)
+		require.Equal(t, assets.GWei(30).String(), (<-slow).String())
+	})
+
+	t.Run("failed estimates are retried", func(t *testing.T) {
+		prices := &headGasPrices{prices: make(map[gas.Speed]*headGasPrice)}
+
+		_, err := prices.get(gas.SpeedStandard, func() (*big.Int, error) { return nil, errors.New("estimator not started") })
+		require.Error(t, err)
+		price, err := prices.get(gas.SpeedStandard, func() (*big.Int, error) { return assets.GWei(60), nil })
+		require.NoError(t, err)
+		require.Equal(t, assets.GWei(60).String(), price.String())
+
+		// the estimate is cached once it succeeded
+		price, err = prices.get(gas.SpeedStandard, func() (*big.Int, error) { return nil, errors.New("estimated again") })
+		require.NoError(t, err)
+		require.Equal(t, assets.GWei(60).String(), price.String())
+	})
+}
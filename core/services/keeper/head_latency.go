@@ -0,0 +1,31 @@
+package keeper
+
+import (
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+)
+
+// arrivedHead is a head delivered to the mailbox along with the time it arrived
+type arrivedHead struct {
+	eth.Head
+	arrivedAt time.Time
+}
+
+// deliverHead hands the head to the run goroutine, timestamping its arrival
+func (ex *UpkeepExecuter) deliverHead(head eth.Head) {
+	ex.cancelReorgedBatch(head)
+	ex.mailbox.Deliver(arrivedHead{Head: head, arrivedAt: time.Now()})
+}
+
+// observeHeadToBroadcast records the time from the arrival of the head to the completion of
+// the run's ethtx task, which hands the perform transaction to the tx manager for broadcast
+func (ex *UpkeepExecuter) observeHeadToBroadcast(run pipeline.Run, arrivedAt time.Time) {
+	for _, taskRun := range run.PipelineTaskRuns {
+		if taskRun.Type == pipeline.TaskTypeETHTx && taskRun.FinishedAt.Valid && !taskRun.Error.Valid {
+			ex.metrics.ObserveHeadToBroadcast(ex.job.KeeperSpec.ContractAddress.Hex(), taskRun.FinishedAt.Time.Sub(arrivedAt))
+			return
+		}
+	}
+}
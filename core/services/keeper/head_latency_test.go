@@ -0,0 +1,48 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+)
+
+func TestUpkeepExecuter_ObserveHeadToBroadcast(t *testing.T) {
+	t.Parallel()
+
+	arrivedAt := time.Now()
+	finishedAt := null.TimeFrom(arrivedAt.Add(1500 * time.Millisecond))
+	tests := []struct {
+		name     string
+		taskRuns []pipeline.TaskRun
+		observed []time.Duration
+	}{
+		{"broadcast perform", []pipeline.TaskRun{
+			{Type: pipeline.TaskTypeETHCall, FinishedAt: null.TimeFrom(arrivedAt.Add(time.Second))},
+			{Type: pipeline.TaskTypeETHTx, FinishedAt: finishedAt},
+		}, []time.Duration{1500 * time.Millisecond}},
+		{"errored ethtx task", []pipeline.TaskRun{
+			{Type: pipeline.TaskTypeETHTx, FinishedAt: finishedAt, Error: null.StringFrom("insufficient funds")},
+		}, nil},
+		{"unfinished ethtx task", []pipeline.TaskRun{
+			{Type: pipeline.TaskTypeETHTx},
+		}, nil},
+		{"run without an ethtx task", []pipeline.TaskRun{
+			{Type: pipeline.TaskTypeETHCall, FinishedAt: finishedAt},
+		}, nil},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := &recordingMetrics{}
+			ex := newTestExecuter(t, testExecuterDeps{metrics: recorder})
+			ex.observeHeadToBroadcast(pipeline.Run{PipelineTaskRuns: test.taskRuns}, arrivedAt)
+			require.Equal(t, test.observed, recorder.headToBroadcast)
+		})
+	}
+}
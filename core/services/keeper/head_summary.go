@@ -0,0 +1,57 @@
+package keeper
+
+import (
+	"time"
+
+	"go.uber.org/atomic"
+)
+
+// executionOutcome is the result of executing an upkeep for a head
+type executionOutcome int
+
+const (
+	executionSkipped executionOutcome = iota
+	executionPerformed
+	executionFailed
+)
+
+func (o executionOutcome) String() string {
+	switch o {
+	case executionPerformed:
+		return "performed"
+	case executionFailed:
+		return "failed"
+	default:
+		return "skipped"
+	}
+}
+
+// headSummary tallies the outcomes of the upkeeps executed for a head, the registries of
+// a head processed concurrently share it
+type headSummary struct {
+	eligible  atomic.Int64
+	performed atomic.Int64
+	failed    atomic.Int64
+	skipped   atomic.Int64
+}
+
+func (s *headSummary) record(outcome executionOutcome) {
+	switch outcome {
+	case executionPerformed:
+		s.performed.Inc()
+	case executionFailed:
+		s.failed.Inc()
+	default:
+		s.skipped.Inc()
+	}
+}
+
+// logHeadSummary logs the summary of a head on a single line if KeeperCompactHeadLog is enabled
+func (ex *UpkeepExecuter) logHeadSummary(headNumber int64, summary *headSummary, started time.Time) {
+	if !ex.config.KeeperCompactHeadLog() {
+		return
+	}
+	ex.logger.Infof("head=%d eligible=%d performed=%d failed=%d skipped=%d dur=%dms",
+		headNumber, summary.eligible.Load(), summary.performed.Load(), summary.failed.Load(), summary.skipped.Load(),
+		time.Since(started).Milliseconds())
+}
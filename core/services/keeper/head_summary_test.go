@@ -0,0 +1,44 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/configtest"
+	"github.com/smartcontractkit/chainlink/core/logger"
+)
+
+func TestUpkeepExecuter_LogHeadSummary(t *testing.T) {
+	t.Parallel()
+
+	summary := &headSummary{}
+	summary.eligible.Add(4)
+	summary.record(executionPerformed)
+	summary.record(executionPerformed)
+	summary.record(executionFailed)
+	summary.record(executionSkipped)
+
+	t.Run("logs a single line when enabled", func(t *testing.T) {
+		ex := newTestExecuter(t, testExecuterDeps{}, func(c *configtest.TestGeneralConfig) {
+			c.Overrides.KeeperCompactHeadLog = null.BoolFrom(true)
+		})
+		ex.logger = logger.CreateMemoryTestLogger(zapcore.InfoLevel)
+
+		ex.logHeadSummary(424242, summary, time.Now())
+		require.Contains(t, logger.MemoryLogTestingOnly().String(), "head=424242 eligible=4 performed=2 failed=1 skipped=1 dur=")
+	})
+
+	t.Run("logs nothing when disabled", func(t *testing.T) {
+		ex := newTestExecuter(t, testExecuterDeps{}, func(c *configtest.TestGeneralConfig) {
+			c.Overrides.KeeperCompactHeadLog = null.BoolFrom(false)
+		})
+		ex.logger = logger.CreateMemoryTestLogger(zapcore.InfoLevel)
+
+		ex.logHeadSummary(434343, summary, time.Now())
+		require.NotContains(t, logger.MemoryLogTestingOnly().String(), "head=434343")
+	})
+}
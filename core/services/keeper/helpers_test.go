@@ -1,5 +1,27 @@
 package keeper
 
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/configtest"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/bulletprooftxmanager"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/services/gas"
+	gasmocks "github.com/smartcontractkit/chainlink/core/services/gas/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+)
+
 func (rs *RegistrySynchronizer) ExportedFullSync() {
 	rs.fullSync()
 }
@@ -7,3 +29,116 @@ func (rs *RegistrySynchronizer) ExportedFullSync() {
 func (rs *RegistrySynchronizer) ExportedProcessLogs() {
 	rs.processLogs()
 }
+
+// ExportedRegistryABI returns the ABI the calls to registries of version are packed with
+func ExportedRegistryABI(version string) abi.ABI {
+	return registryVersions[version].abi
+}
+
+// eligibleClient is a null client whose checkUpkeep calls return the result of an eligible upkeep
+type eligibleClient struct {
+	*eth.NullClient
+}
+
+func newEligibleClient() *eligibleClient {
+	return &eligibleClient{NullClient: &eth.NullClient{CID: big.NewInt(1)}}
+}
+
+func (c *eligibleClient) CallContract(context.Context, ethereum.CallMsg, *big.Int) ([]byte, error) {
+	// a zero adjustedGasWei doesn't cap the gas price of the perform
+	return RegistryABI.Methods["checkUpkeep"].Outputs.Pack(
+		[]byte{0x12},
+		big.NewInt(1),
+		big.NewInt(2_000_000),
+		big.NewInt(0),
+		big.NewInt(1),
+	)
+}
+
+// recordingEventSink collects the events emitted by an executer
+type recordingEventSink struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (s *recordingEventSink) Emit(e Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, e)
+}
+
+// ofType returns the events of type eventType emitted so far, in order
+func (s *recordingEventSink) ofType(eventType EventType) []Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var events []Event
+	for _, e := range s.events {
+		if e.Type == eventType {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// testExecuterDeps are the dependencies of an executer built by newTestExecuter, unset fields get a default
+type testExecuterDeps struct {
+	// db backs the ORM of the executer, which has no database if it's nil
+	db *gorm.DB
+	// keeperSpec defaults to an empty keeper spec
+	keeperSpec *job.KeeperSpec
+	// ethClient defaults to a null client
+	ethClient eth.Client
+	// estimator defaults to an estimator quoting 60 gwei with no gas limit
+	estimator gas.Estimator
+	runner    pipeline.Runner
+	strategy  UpkeepGasStrategy
+	// metrics replace the default metrics of the executer
+	metrics Metrics
+	// events replace the default log event sink of the executer
+	events EventSink
+}
+
+// newTestExecuter builds an executer with NewUpkeepExecuter for the internal tests, without starting it.
+// overrides are applied to its config first.
+func newTestExecuter(t *testing.T, deps testExecuterDeps, overrides ...func(*configtest.TestGeneralConfig)) *UpkeepExecuter {
+	config := configtest.NewTestGeneralConfig(t)
+	for _, override := range overrides {
+		override(config)
+	}
+	if deps.keeperSpec == nil {
+		deps.keeperSpec = &job.KeeperSpec{}
+	}
+	if deps.ethClient == nil {
+		deps.ethClient = &eth.NullClient{CID: big.NewInt(1)}
+	}
+	if deps.estimator == nil {
+		estimator := new(gasmocks.Estimator)
+		estimator.On("EstimateGas", mock.Anything, mock.Anything).Maybe().Return(assets.GWei(60), uint64(0), nil)
+		deps.estimator = estimator
+	}
+	var orm ORM
+	if deps.db != nil {
+		orm = NewORM(deps.db, nil, config, bulletprooftxmanager.SendEveryStrategy{})
+	}
+	ex := NewUpkeepExecuter(
+		job.Job{KeeperSpec: deps.keeperSpec, PipelineSpec: &pipeline.Spec{}},
+		orm,
+		deps.runner,
+		deps.ethClient,
+		nil,
+		deps.estimator,
+		deps.strategy,
+		logger.Default,
+		config,
+		NewQueryLimiter(),
+		nil,
+		nil,
+	)
+	if deps.metrics != nil {
+		ex.metrics = deps.metrics
+	}
+	if deps.events != nil {
+		ex.events = deps.events
+	}
+	return ex
+}
@@ -0,0 +1,16 @@
+package keeper
+
+// observeIdle reports the executer as idle once KeeperIdleHeadThreshold consecutive heads had no
+// eligible upkeeps, and as busy as soon as a head has some. It must only be called from the run goroutine.
+func (ex *UpkeepExecuter) observeIdle(eligibleUpkeeps int) {
+	threshold := ex.config.KeeperIdleHeadThreshold()
+	if threshold == 0 {
+		return
+	}
+	if eligibleUpkeeps > 0 {
+		ex.idleHeads = 0
+	} else if ex.idleHeads < threshold {
+		ex.idleHeads++
+	}
+	ex.metrics.SetIdle(ex.job.KeeperSpec.ContractAddress.Hex(), ex.idleHeads >= threshold)
+}
@@ -0,0 +1,34 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/configtest"
+)
+
+func TestObserveIdle(t *testing.T) {
+	t.Parallel()
+
+	recorder := &recordingMetrics{}
+	ex := newTestExecuter(t, testExecuterDeps{metrics: recorder}, func(c *configtest.TestGeneralConfig) {
+		c.Overrides.KeeperIdleHeadThreshold = null.IntFrom(3)
+	})
+
+	for _, eligible := range []int{2, 0, 0, 0, 0, 1, 0} {
+		ex.observeIdle(eligible)
+	}
+	require.Equal(t, []bool{false, false, false, true, true, false, false}, recorder.idle)
+
+	t.Run("disabled", func(t *testing.T) {
+		recorder := &recordingMetrics{}
+		ex := newTestExecuter(t, testExecuterDeps{metrics: recorder}, func(c *configtest.TestGeneralConfig) {
+			c.Overrides.KeeperIdleHeadThreshold = null.IntFrom(0)
+		})
+
+		ex.observeIdle(0)
+		require.Empty(t, recorder.idle)
+	})
+}
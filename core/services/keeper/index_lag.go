@@ -0,0 +1,53 @@
+package keeper
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/services/postgres"
+)
+
+// Healthy returns an error while eligibility is skipped because the indexed registry logs lag
+// behind the head by more than KeeperMaxIndexLag or the fromAddress balance is below KeeperMinimumBalance,
+// or if no head was processed within KeeperMaxProcessedHeadAge
+func (ex *UpkeepExecuter) Healthy() error {
+	if err := ex.StartStopOnce.Healthy(); err != nil {
+		return err
+	}
+	if ex.indexDegraded.Load() {
+		return errors.New("indexed registry logs lag behind the head by more than KeeperMaxIndexLag")
+	}
+	if ex.insufficientBalance.Load() {
+		return errors.New("balance of the keeper fromAddress is below KeeperMinimumBalance")
+	}
+	return ex.processedHeadAgeHealthy()
+}
+
+// indexCaughtUp returns false if the highest registry log indexed for the job lags behind head
+// by more than KeeperMaxIndexLag, in which case the eligible upkeeps may be stale. Jobs without
+// indexed logs and failed lookups are not considered to lag.
+func (ex *UpkeepExecuter) indexCaughtUp(head eth.Head) bool {
+	maxLag := ex.config.KeeperMaxIndexLag()
+	if maxLag == 0 {
+		return true
+	}
+
+	ctx, cancel := postgres.DefaultQueryCtx()
+	defer cancel()
+	indexed, exists, err := ex.orm.IndexedBlockNumberForJob(ctx, ex.job.ID)
+	if err != nil {
+		ex.logger.Warnw("unable to load the indexed block number", "error", err)
+		return true
+	}
+	lag := head.Number - indexed
+	if !exists || lag <= int64(maxLag) {
+		if ex.indexDegraded.CAS(true, false) {
+			ex.logger.Infow("indexed registry logs caught up with the head, resuming upkeeps", "blockheight", head.Number)
+		}
+		return true
+	}
+	ex.indexDegraded.Store(true)
+	ex.logger.Warnw("skipping head, indexed registry logs lag behind it by more than KeeperMaxIndexLag",
+		"blockheight", head.Number, "indexedBlockNumber", indexed, "lag", lag, "maxLag", maxLag)
+	return false
+}
@@ -0,0 +1,239 @@
+package keeper
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/services/keystore/keys/ethkey"
+	"github.com/smartcontractkit/chainlink/core/services/postgres"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+// maxLogTriggerTopics is the number of indexed topics a log can have
+const maxLogTriggerTopics = 4
+
+// logTriggerBatch is a batch of trigger logs of a registry flushed by the logTriggerBatcher
+type logTriggerBatch struct {
+	registry common.Address
+	triggers []logTrigger
+}
+
+// logTriggerFilter returns the filter query of the logs triggering a log-triggered upkeep
+func logTriggerFilter(trigger job.KeeperLogTrigger) (ethereum.FilterQuery, error) {
+	if trigger.Address.IsZero() {
+		return ethereum.FilterQuery{}, errors.New("address must be set")
+	}
+	if len(trigger.Topics) == 0 || len(trigger.Topics) > maxLogTriggerTopics {
+		return ethereum.FilterQuery{}, errors.Errorf("between 1 and %d topics must be set, got %d", maxLogTriggerTopics, len(trigger.Topics))
+	}
+	topics := make([][]common.Hash, len(trigger.Topics))
+	for i, topic := range trigger.Topics {
+		b, err := hexutil.Decode(topic)
+		if err != nil || len(b) != common.HashLength {
+			return ethereum.FilterQuery{}, errors.Errorf("topic %q is not a hex encoded 32 byte hash", topic)
+		}
+		topics[i] = []common.Hash{common.BytesToHash(b)}
+	}
+	return ethereum.FilterQuery{
+		Addresses: []common.Address{trigger.Address.Address()},
+		Topics:    topics,
+	}, nil
+}
+
+// subscribeLogTriggers subscribes to the logs of the log-triggered upkeeps of the job. A failed
+// subscription is logged, the upkeep is then only checked on new heads.
+func (ex *UpkeepExecuter) subscribeLogTriggers() {
+	var upkeepIDs []int64
+	for id, override := range ex.job.KeeperSpec.UpkeepOverrides {
+		if override.LogTrigger == nil {
+			continue
+		}
+		upkeepID, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			continue
+		}
+		upkeepIDs = append(upkeepIDs, upkeepID)
+	}
+	if len(upkeepIDs) == 0 {
+		return
+	}
+	sort.Slice(upkeepIDs, func(i, j int) bool { return upkeepIDs[i] < upkeepIDs[j] })
+
+	ex.logTriggers = newLogTriggerBatcher(ex.config.KeeperLogTriggerBatchWindow(), int(ex.config.KeeperLogTriggerBatchSize()), func(registry common.Address, triggers []logTrigger) {
+		select {
+		case ex.chLogTriggers <- logTriggerBatch{registry: registry, triggers: triggers}:
+		case <-ex.chStop:
+		}
+	})
+	ex.wgDone.Add(1)
+	go ex.runLogTriggers()
+	for _, upkeepID := range upkeepIDs {
+		override, _ := ex.job.KeeperSpec.UpkeepOverrides.ForUpkeep(upkeepID)
+		filter, err := logTriggerFilter(*override.LogTrigger)
+		if err != nil {
+			ex.logger.Errorw("invalid log trigger, the upkeep is only checked on new heads", "upkeepID", upkeepID, "error", err)
+			continue
+		}
+		chLogs := make(chan types.Log)
+		ctx, cancel := utils.ContextFromChan(ex.chStop)
+		sub, err := ex.ethClient.SubscribeFilterLogs(ctx, filter, chLogs)
+		cancel()
+		if err != nil {
+			ex.logger.Errorw("unable to subscribe to the logs of a log-triggered upkeep, it is only checked on new heads",
+				"upkeepID", upkeepID, "error", err)
+			continue
+		}
+		ex.wgDone.Add(1)
+		go ex.receiveLogTriggers(upkeepID, sub, chLogs)
+	}
+}
+
+// receiveLogTriggers hands the logs of a log trigger subscription to the batcher until the executer
+// is closed or the subscription fails
+func (ex *UpkeepExecuter) receiveLogTriggers(upkeepID int64, sub ethereum.Subscription, chLogs <-chan types.Log) {
+	defer ex.wgDone.Done()
+	defer sub.Unsubscribe()
+	for {
+		select {
+		case log := <-chLogs:
+			if log.Removed {
+				// the log was reorged out, the upkeep is re-triggered if it's emitted again
+				continue
+			}
+			for _, registryAddress := range ex.job.KeeperSpec.RegistryAddresses() {
+				ex.logTriggers.Add(registryAddress.Address(), logTrigger{UpkeepID: upkeepID, Log: log})
+			}
+		case err := <-sub.Err():
+			ex.logger.Errorw("log trigger subscription failed, the upkeep is only checked on new heads",
+				"upkeepID", upkeepID, "error", err)
+			return
+		case <-ex.chStop:
+			return
+		}
+	}
+}
+
+// runLogTriggers executes the batches of trigger logs until the executer is closed
+func (ex *UpkeepExecuter) runLogTriggers() {
+	defer ex.wgDone.Done()
+	for {
+		select {
+		case batch := <-ex.chLogTriggers:
+			ex.executeLogTriggers(batch.registry, batch.triggers)
+		case <-ex.chStop:
+			return
+		}
+	}
+}
+
+// executeLogTriggers executes the upkeeps of registry triggered by a log, on the block of the log.
+// Like on heads, an upkeep is only executed if this node holds its turn, it's outside of the grace period
+// and not backing off, without a pending perform if KeeperExcludePendingPerforms is set and within
+// KeeperMaxUpkeepsPerBlock. The executions are cancelled if a reorg replaces the block of their log.
+func (ex *UpkeepExecuter) executeLogTriggers(registry common.Address, triggers []logTrigger) {
+	logs := make(map[upkeepKey]types.Log, len(triggers))
+	upkeeps := make([]UpkeepRegistration, 0, len(triggers))
+	var head eth.Head
+	for _, trigger := range triggers {
+		upkeep, err := ex.loadTriggeredUpkeep(registry, trigger.UpkeepID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// the upkeep belongs to another registry of the job
+			continue
+		} else if err != nil {
+			ex.logger.Errorw("unable to load log-triggered upkeep", "upkeepID", trigger.UpkeepID, "registryAddress", registry.Hex(), "error", err)
+			continue
+		}
+		blockNumber := int64(trigger.Log.BlockNumber)
+		ex.logger.Debugw("upkeep triggered by log", "upkeepID", upkeep.UpkeepID, "registryAddress", registry.Hex(),
+			"blockheight", blockNumber, "txHash", trigger.Log.TxHash.Hex(), "logIndex", trigger.Log.Index)
+		if !ex.triggerEligible(upkeep, blockNumber) {
+			continue
+		}
+		logs[upkeep.key()] = trigger.Log
+		upkeeps = append(upkeeps, upkeep)
+		if blockNumber > head.Number {
+			head = eth.Head{Number: blockNumber, Hash: trigger.Log.BlockHash}
+		}
+	}
+	if len(upkeeps) == 0 {
+		return
+	}
+
+	if ex.config.KeeperExcludePendingPerforms() {
+		upkeeps = ex.excludePendingPerforms(head, upkeeps)
+	}
+	upkeeps = ex.limitPerRegistry(head, upkeeps)
+
+	for i, upkeep := range upkeeps {
+		if !ex.acquireExecutionSlot() {
+			ex.logger.Infow("shutting down, dropping the remaining trigger logs",
+				"registryAddress", registry.Hex(), "remaining", len(upkeeps)-i)
+			return
+		}
+		triggerLog := logs[upkeep.key()]
+		triggerHead := eth.Head{Number: int64(triggerLog.BlockNumber), Hash: triggerLog.BlockHash}
+		ctx, release := ex.startTriggerBatch(triggerHead)
+		ex.inFlight.Inc()
+		ex.metrics.AddActiveExecutions(ex.job.KeeperSpec.ContractAddress.Hex(), 1)
+		done := func(executionOutcome) {
+			release()
+			ex.inFlight.Dec()
+			ex.metrics.AddActiveExecutions(ex.job.KeeperSpec.ContractAddress.Hex(), -1)
+			<-ex.executionQueue
+			ex.wgDone.Done()
+		}
+		// Close waits on wgDone for the executions to drain
+		ex.wgDone.Add(1)
+		go ex.execute(ctx, upkeep, triggerHead, time.Now(), false, nil, nil, &triggerLog, done)
+	}
+}
+
+// triggerEligible returns true if upkeep may be executed on blockNumber, the block of its trigger log,
+// applying the per-upkeep checks the eligibility query and processActiveUpkeeps apply on heads
+func (ex *UpkeepExecuter) triggerEligible(upkeep UpkeepRegistration, blockNumber int64) bool {
+	lggr := ex.logger.With("upkeepID", upkeep.UpkeepID, "blockheight", blockNumber)
+	if upkeep.Registry.Paused {
+		return false
+	}
+	if window, ok := nextTurnWindow(upkeep, blockNumber); !ok || window.startBlock > blockNumber {
+		return false
+	}
+	if ranRecently(upkeep, blockNumber, ex.lastGracePeriod.Load(), int64(ex.config.KeeperMinConfirmations())) {
+		lggr.Debugw("upkeep ran recently, ignoring its trigger log", "lastRunBlockHeight", upkeep.LastRunBlockHeight)
+		return false
+	}
+	if backingOff(upkeep, blockNumber, ex.config.KeeperMaximumBackoffBlocks()) {
+		lggr.Debugw("upkeep is backing off from a failed execution, ignoring its trigger log")
+		return false
+	}
+	if ex.isInFlight(upkeep) {
+		lggr.Debugw("upkeep is already executing, ignoring its trigger log")
+		return false
+	}
+	return true
+}
+
+// loadTriggeredUpkeep loads the upkeep of registry along with its registry
+func (ex *UpkeepExecuter) loadTriggeredUpkeep(registry common.Address, upkeepID int64) (UpkeepRegistration, error) {
+	ctx, cancel := postgres.DefaultQueryCtx()
+	defer cancel()
+	return ex.orm.UpkeepForJob(ctx, ex.job.ID, ethkey.EIP55AddressFromAddress(registry), upkeepID)
+}
+
+// isInFlight returns true if upkeep is executing
+func (ex *UpkeepExecuter) isInFlight(upkeep UpkeepRegistration) bool {
+	ex.inFlightUpkeepsMu.Lock()
+	defer ex.inFlightUpkeepsMu.Unlock()
+	_, exists := ex.inFlightUpkeeps[upkeep.key()]
+	return exists
+}
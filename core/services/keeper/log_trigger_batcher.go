@@ -0,0 +1,114 @@
+package keeper
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// logTrigger is an on-chain log that triggers a log-triggered upkeep
+type logTrigger struct {
+	UpkeepID int64
+	Log      types.Log
+}
+
+// logTriggerBatcher collects trigger logs per registry for a fixed window and hands them
+// on as a single batch, keeping only the most recent log per upkeep
+type logTriggerBatcher struct {
+	window time.Duration
+	// size flushes a registry early once that many upkeeps are pending, 0 disables it
+	size    int
+	flush   func(registry common.Address, triggers []logTrigger)
+	mu      sync.Mutex
+	stopped bool
+	pending map[common.Address]*pendingLogTriggers
+}
+
+// pendingLogTriggers are the trigger logs of a registry waiting for the window to expire
+type pendingLogTriggers struct {
+	triggers map[int64]logTrigger
+	timer    *time.Timer
+}
+
+func newLogTriggerBatcher(window time.Duration, size int, flush func(registry common.Address, triggers []logTrigger)) *logTriggerBatcher {
+	return &logTriggerBatcher{
+		window:  window,
+		size:    size,
+		flush:   flush,
+		pending: make(map[common.Address]*pendingLogTriggers),
+	}
+}
+
+// Add queues a trigger log for its registry. With a zero window the log is flushed immediately,
+// otherwise once the window expired or size upkeeps are pending. Logs added after Stop are dropped.
+func (b *logTriggerBatcher) Add(registry common.Address, trigger logTrigger) {
+	b.mu.Lock()
+	if b.stopped {
+		b.mu.Unlock()
+		return
+	}
+	if b.window <= 0 {
+		b.mu.Unlock()
+		b.flush(registry, []logTrigger{trigger})
+		return
+	}
+	p, exists := b.pending[registry]
+	if !exists {
+		p = &pendingLogTriggers{triggers: make(map[int64]logTrigger)}
+		p.timer = time.AfterFunc(b.window, func() { b.flushRegistry(registry, p) })
+		b.pending[registry] = p
+	}
+	p.triggers[trigger.UpkeepID] = trigger
+	if b.size > 0 && len(p.triggers) >= b.size {
+		b.takePending(registry)
+		b.mu.Unlock()
+		b.flushTriggers(registry, p.triggers)
+		return
+	}
+	b.mu.Unlock()
+}
+
+// Stop discards any pending trigger logs without flushing them, a timer that already fired
+// doesn't flush either
+func (b *logTriggerBatcher) Stop() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.stopped = true
+	for registry := range b.pending {
+		b.takePending(registry)
+	}
+}
+
+// flushRegistry flushes the pending logs p of registry once their window expired. It's a no-op if
+// the batcher was stopped or p was flushed on size in the meantime.
+func (b *logTriggerBatcher) flushRegistry(registry common.Address, p *pendingLogTriggers) {
+	b.mu.Lock()
+	if b.stopped || b.pending[registry] != p {
+		b.mu.Unlock()
+		return
+	}
+	b.takePending(registry)
+	b.mu.Unlock()
+
+	b.flushTriggers(registry, p.triggers)
+}
+
+// takePending removes the pending logs of registry and stops their timer, the caller must hold mu
+func (b *logTriggerBatcher) takePending(registry common.Address) {
+	if p, exists := b.pending[registry]; exists {
+		p.timer.Stop()
+		delete(b.pending, registry)
+	}
+}
+
+func (b *logTriggerBatcher) flushTriggers(registry common.Address, pending map[int64]logTrigger) {
+	triggers := make([]logTrigger, 0, len(pending))
+	for _, trigger := range pending {
+		triggers = append(triggers, trigger)
+	}
+	sort.Slice(triggers, func(i, j int) bool { return triggers[i].UpkeepID < triggers[j].UpkeepID })
+	b.flush(registry, triggers)
+}
@@ -0,0 +1,129 @@
+package keeper
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+// flushRecorder records the batches flushed by a logTriggerBatcher
+type flushRecorder struct {
+	mu      sync.Mutex
+	batches [][]logTrigger
+}
+
+func (r *flushRecorder) flush(_ common.Address, triggers []logTrigger) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.batches = append(r.batches, triggers)
+}
+
+func (r *flushRecorder) flushed() [][]logTrigger {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([][]logTrigger(nil), r.batches...)
+}
+
+func upkeepIDs(triggers []logTrigger) []int64 {
+	ids := make([]int64, len(triggers))
+	for i, trigger := range triggers {
+		ids[i] = trigger.UpkeepID
+	}
+	return ids
+}
+
+func TestLogTriggerBatcher(t *testing.T) {
+	t.Parallel()
+
+	registry := common.HexToAddress("0x9E40733cC9df84636505f4e6Db28DCa0dC5D1bba")
+	trigger := func(upkeepID int64, blockNumber uint64) logTrigger {
+		return logTrigger{UpkeepID: upkeepID, Log: types.Log{BlockNumber: blockNumber}}
+	}
+
+	t.Run("flushes immediately without a window", func(t *testing.T) {
+		r := &flushRecorder{}
+		b := newLogTriggerBatcher(0, 0, r.flush)
+
+		b.Add(registry, trigger(1, 10))
+		require.Len(t, r.flushed(), 1)
+	})
+
+	t.Run("flushes on timeout, keeping the latest log per upkeep", func(t *testing.T) {
+		r := &flushRecorder{}
+		b := newLogTriggerBatcher(50*time.Millisecond, 0, r.flush)
+
+		b.Add(registry, trigger(2, 10))
+		b.Add(registry, trigger(1, 10))
+		b.Add(registry, trigger(2, 11))
+		require.Empty(t, r.flushed())
+
+		require.Eventually(t, func() bool { return len(r.flushed()) == 1 }, 5*time.Second, 10*time.Millisecond)
+		batch := r.flushed()[0]
+		require.Equal(t, []int64{1, 2}, upkeepIDs(batch))
+		require.Equal(t, uint64(11), batch[1].Log.BlockNumber)
+	})
+
+	t.Run("flushes on size", func(t *testing.T) {
+		r := &flushRecorder{}
+		b := newLogTriggerBatcher(time.Hour, 2, r.flush)
+		defer b.Stop()
+
+		b.Add(registry, trigger(1, 10))
+		b.Add(registry, trigger(1, 11))
+		require.Empty(t, r.flushed(), "logs of the same upkeep count once")
+		b.Add(registry, trigger(2, 11))
+		require.Len(t, r.flushed(), 1)
+		require.Equal(t, []int64{1, 2}, upkeepIDs(r.flushed()[0]))
+
+		// the next logs start a new batch
+		b.Add(registry, trigger(3, 12))
+		require.Len(t, r.flushed(), 1)
+	})
+
+	t.Run("a size flush cancels the window of the flushed logs", func(t *testing.T) {
+		r := &flushRecorder{}
+		b := newLogTriggerBatcher(100*time.Millisecond, 2, r.flush)
+
+		b.Add(registry, trigger(1, 10))
+		b.Add(registry, trigger(2, 10))
+		require.Len(t, r.flushed(), 1)
+		require.Never(t, func() bool { return len(r.flushed()) > 1 }, 300*time.Millisecond, 10*time.Millisecond)
+	})
+
+	t.Run("does not flush after Stop", func(t *testing.T) {
+		r := &flushRecorder{}
+		b := newLogTriggerBatcher(50*time.Millisecond, 0, r.flush)
+
+		b.Add(registry, trigger(1, 10))
+		b.Stop()
+		b.Add(registry, trigger(2, 10))
+		require.Never(t, func() bool { return len(r.flushed()) > 0 }, 200*time.Millisecond, 10*time.Millisecond)
+	})
+
+	t.Run("a timer that fired before Stop does not flush", func(t *testing.T) {
+		r := &flushRecorder{}
+		b := newLogTriggerBatcher(time.Hour, 0, r.flush)
+
+		b.Add(registry, trigger(1, 10))
+		b.mu.Lock()
+		p := b.pending[registry]
+		b.mu.Unlock()
+		b.Stop()
+		// the timer fires after Stop took the lock
+		b.flushRegistry(registry, p)
+		require.Empty(t, r.flushed())
+	})
+
+	t.Run("drops logs added after Stop without a window", func(t *testing.T) {
+		r := &flushRecorder{}
+		b := newLogTriggerBatcher(0, 0, r.flush)
+
+		b.Stop()
+		b.Add(registry, trigger(1, 10))
+		require.Empty(t, r.flushed())
+	})
+}
@@ -0,0 +1,38 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/services/job"
+	"github.com/smartcontractkit/chainlink/core/services/keystore/keys/ethkey"
+)
+
+func TestLogTriggerFilter(t *testing.T) {
+	t.Parallel()
+
+	address := ethkey.EIP55Address("0x9E40733cC9df84636505f4e6Db28DCa0dC5D1bba")
+	topic := "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+
+	t.Run("filters by address and topics", func(t *testing.T) {
+		filter, err := logTriggerFilter(job.KeeperLogTrigger{Address: address, Topics: []string{topic}})
+		require.NoError(t, err)
+		require.Equal(t, []common.Address{address.Address()}, filter.Addresses)
+		require.Equal(t, [][]common.Hash{{common.HexToHash(topic)}}, filter.Topics)
+	})
+
+	t.Run("invalid triggers", func(t *testing.T) {
+		for name, trigger := range map[string]job.KeeperLogTrigger{
+			"no address":    {Topics: []string{topic}},
+			"no topics":     {Address: address},
+			"too many":      {Address: address, Topics: []string{topic, topic, topic, topic, topic}},
+			"short topic":   {Address: address, Topics: []string{"0xddf252ad"}},
+			"not hex topic": {Address: address, Topics: []string{"Transfer(address,address,uint256)"}},
+		} {
+			_, err := logTriggerFilter(trigger)
+			require.Error(t, err, name)
+		}
+	})
+}
@@ -0,0 +1,266 @@
+package keeper
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+)
+
+// Metrics records the metrics emitted by the upkeep executer. It decouples the call sites
+// from the metrics backend so that backends other than Prometheus can be plugged in.
+// Prometheus is the only implementation, an OpenTelemetry implementation and a config
+// option selecting the backend are follow-up work needing the OTel metrics SDK.
+type Metrics interface {
+	// AddReorgReevaluations counts upkeeps re-evaluated because a reorg replaced the previously processed head
+	AddReorgReevaluations(registryAddress string, upkeeps int)
+	// SetGasPriceConfidence records the confidence the gas estimator reported for the latest upkeep gas price
+	SetGasPriceConfidence(registryAddress string, confidence float64)
+	// IncEmptyMailboxRetrieves counts head notifications that found no head to retrieve
+	IncEmptyMailboxRetrieves(registryAddress string)
+	// ObserveQueueWait records how long an upkeep waited for a free execution slot
+	ObserveQueueWait(registryAddress string, wait time.Duration)
+	// IncExecutionTimeouts counts upkeep executions that ran out of time
+	IncExecutionTimeouts(registryAddress string)
+	// IncImplausibleGasPrices counts gas price estimates clamped into the gas price sanity range
+	IncImplausibleGasPrices(registryAddress string)
+	// IncBaseFeeOverpayments counts performs whose buffered gas price exceeds the head base fee by more than KeeperBaseFeeOverpaymentFactor
+	IncBaseFeeOverpayments(registryAddress string)
+	// ObserveHeadToBroadcast records the time from the arrival of a head to the perform transaction being handed to the tx manager
+	ObserveHeadToBroadcast(registryAddress string, latency time.Duration)
+	// AddActiveExecutions tracks the number of running upkeep executions
+	AddActiveExecutions(registryAddress string, delta int)
+	// AddEligibleUpkeeps counts the eligible upkeeps retrieved for a head
+	AddEligibleUpkeeps(registryAddress string, upkeeps int)
+	// IncPipelineRuns counts finished upkeep pipeline runs by their status
+	IncPipelineRuns(registryAddress string, status pipeline.RunStatus)
+	// ObserveExecuteDuration records how long an upkeep execution took
+	ObserveExecuteDuration(registryAddress string, duration time.Duration)
+	// IncBlockGasLimitExceeded counts upkeeps skipped because their perform gas limit exceeds the block gas limit
+	IncBlockGasLimitExceeded(registryAddress string)
+	// SetIdle reports whether the executer found no eligible upkeeps on the recent heads it processed
+	SetIdle(registryAddress string, idle bool)
+	// AddSkippedHeads counts heads replaced in the mailbox by a newer head before the executer processed them
+	AddSkippedHeads(registryAddress string, heads int)
+	// IncDuplicateHeads counts heads ignored because they are identical to the previously processed head
+	IncDuplicateHeads(registryAddress string)
+	// IncOverdueUpkeeps counts upkeeps that reached KeeperOverdueWarnHeads consecutive eligible heads without a perform
+	IncOverdueUpkeeps(registryAddress string)
+	// IncUpkeepStateEvictions counts upkeeps evicted from a kind of per-upkeep state because of KeeperMaxUpkeepStateEntries
+	IncUpkeepStateEvictions(registryAddress, state string)
+	// IncPipelinePanics counts pipeline runs of upkeeps that panicked
+	IncPipelinePanics(registryAddress string)
+	// SetInsufficientBalance reports whether heads are skipped because the balance of the keeper fromAddress is below KeeperMinimumBalance
+	SetInsufficientBalance(registryAddress string, insufficient bool)
+}
+
+var _ Metrics = (*promMetrics)(nil)
+
+// defaultMetrics is shared by all executers since Prometheus collectors can only be registered once
+var defaultMetrics = newPromMetrics(prometheus.DefaultRegisterer)
+
+type promMetrics struct {
+	reorgReevaluations    *prometheus.CounterVec
+	gasPriceConfidence    *prometheus.GaugeVec
+	emptyMailboxRetrieves *prometheus.CounterVec
+	queueWaitSeconds      *prometheus.HistogramVec
+	executionTimeouts     *prometheus.CounterVec
+	implausibleGasPrices  *prometheus.CounterVec
+	baseFeeOverpayments   *prometheus.CounterVec
+	headToBroadcast       *prometheus.HistogramVec
+	activeExecutions      *prometheus.GaugeVec
+	eligibleUpkeeps       *prometheus.CounterVec
+	pipelineRuns          *prometheus.CounterVec
+	executeDuration       *prometheus.HistogramVec
+	blockGasLimitExceeded *prometheus.CounterVec
+	idle                  *prometheus.GaugeVec
+	skippedHeads          *prometheus.CounterVec
+	duplicateHeads        *prometheus.CounterVec
+	overdueUpkeeps        *prometheus.CounterVec
+	upkeepStateEvictions  *prometheus.CounterVec
+	pipelinePanics        *prometheus.CounterVec
+	insufficientBalance   *prometheus.GaugeVec
+
+	descriptors []MetricDescriptor
+}
+
+func newPromMetrics(registerer prometheus.Registerer) *promMetrics {
+	factory := &describingFactory{factory: promauto.With(registerer)}
+	m := &promMetrics{
+		reorgReevaluations: factory.counterVec(prometheus.CounterOpts{
+			Name: "keeper_reorg_reevaluations_total",
+			Help: "Number of upkeeps re-evaluated because a reorg replaced the previously processed head",
+		}, []string{"registryAddress"}),
+		gasPriceConfidence: factory.gaugeVec(prometheus.GaugeOpts{
+			Name: "keeper_gas_price_confidence",
+			Help: "Confidence between 0 and 1 reported by the gas estimator for the most recent upkeep gas price",
+		}, []string{"registryAddress"}),
+		emptyMailboxRetrieves: factory.counterVec(prometheus.CounterOpts{
+			Name: "keeper_empty_mailbox_retrieves_total",
+			Help: "Number of times the upkeep executer was notified of a new head but found none to retrieve",
+		}, []string{"registryAddress"}),
+		queueWaitSeconds: factory.histogramVec(prometheus.HistogramOpts{
+			Name:    "keeper_queue_wait_seconds",
+			Help:    "Time an eligible upkeep waited for a free slot in the execution queue before executing",
+			Buckets: []float64{0.01, 0.05, 0.1, 0.5, 1, 2, 5, 10, 30, 60},
+		}, []string{"registryAddress"}),
+		executionTimeouts: factory.counterVec(prometheus.CounterOpts{
+			Name: "keeper_execution_timeouts_total",
+			Help: "Number of upkeep executions cancelled because they exceeded their deadline",
+		}, []string{"registryAddress"}),
+		implausibleGasPrices: factory.counterVec(prometheus.CounterOpts{
+			Name: "keeper_implausible_gas_prices_total",
+			Help: "Number of gas price estimates outside of the gas price sanity range that were clamped into it",
+		}, []string{"registryAddress"}),
+		baseFeeOverpayments: factory.counterVec(prometheus.CounterOpts{
+			Name: "keeper_base_fee_overpayments_total",
+			Help: "Number of upkeep performs whose buffered gas price exceeded the head base fee by more than KEEPER_BASE_FEE_OVERPAYMENT_FACTOR",
+		}, []string{"registryAddress"}),
+		headToBroadcast: factory.histogramVec(prometheus.HistogramOpts{
+			Name:    "keeper_head_to_broadcast_seconds",
+			Help:    "Time from the arrival of a head to the perform transaction for an upkeep eligible on it being handed to the tx manager for broadcast",
+			Buckets: []float64{0.1, 0.25, 0.5, 1, 2, 5, 10, 30, 60, 120},
+		}, []string{"registryAddress"}),
+		activeExecutions: factory.gaugeVec(prometheus.GaugeOpts{
+			Name: "keeper_active_executions",
+			Help: "Number of upkeep executions currently running. A value that keeps climbing indicates leaked executions",
+		}, []string{"registryAddress"}),
+		eligibleUpkeeps: factory.counterVec(prometheus.CounterOpts{
+			Name: "keeper_eligible_upkeeps_total",
+			Help: "Number of eligible upkeeps retrieved for the heads processed by the upkeep executer",
+		}, []string{"registryAddress"}),
+		pipelineRuns: factory.counterVec(prometheus.CounterOpts{
+			Name: "keeper_pipeline_runs_total",
+			Help: "Number of upkeep pipeline runs, by whether they completed or errored",
+		}, []string{"registryAddress", "status"}),
+		executeDuration: factory.histogramVec(prometheus.HistogramOpts{
+			Name:    "keeper_execute_duration_seconds",
+			Help:    "Wall-clock time of an upkeep execution, from leaving the execution queue to finishing or skipping the upkeep",
+			Buckets: []float64{0.05, 0.1, 0.25, 0.5, 1, 2, 5, 10, 30, 60},
+		}, []string{"registryAddress"}),
+		blockGasLimitExceeded: factory.counterVec(prometheus.CounterOpts{
+			Name: "keeper_block_gas_limit_exceeded_total",
+			Help: "Number of upkeeps skipped because their perform gas limit exceeds the block gas limit, so the perform tx could never be included",
+		}, []string{"registryAddress"}),
+		idle: factory.gaugeVec(prometheus.GaugeOpts{
+			Name: "keeper_idle",
+			Help: "1 if the upkeep executer processed its recent heads but found no eligible upkeeps on them, 0 while upkeeps are eligible",
+		}, []string{"registryAddress"}),
+		skippedHeads: factory.counterVec(prometheus.CounterOpts{
+			Name: "keeper_skipped_heads_total",
+			Help: "Number of heads the upkeep executer never processed because a newer head replaced them in its mailbox",
+		}, []string{"registryAddress"}),
+		duplicateHeads: factory.counterVec(prometheus.CounterOpts{
+			Name: "keeper_duplicate_heads_total",
+			Help: "Number of heads ignored by the upkeep executer because they had the same number and hash as the previously processed head",
+		}, []string{"registryAddress"}),
+		overdueUpkeeps: factory.counterVec(prometheus.CounterOpts{
+			Name: "keeper_overdue_upkeeps_total",
+			Help: "Number of times an upkeep was eligible on KeeperOverdueWarnHeads consecutive heads without being performed by this node",
+		}, []string{"registryAddress"}),
+		upkeepStateEvictions: factory.counterVec(prometheus.CounterOpts{
+			Name: "keeper_upkeep_state_evictions_total",
+			Help: "Number of upkeeps evicted from the in-memory state of the upkeep executer because it held KeeperMaxUpkeepStateEntries upkeeps",
+		}, []string{"registryAddress", "state"}),
+		pipelinePanics: factory.counterVec(prometheus.CounterOpts{
+			Name: "keeper_pipeline_panics_total",
+			Help: "Number of upkeep pipeline runs that panicked and were recovered as failed runs",
+		}, []string{"registryAddress"}),
+		insufficientBalance: factory.gaugeVec(prometheus.GaugeOpts{
+			Name: "keeper_insufficient_balance",
+			Help: "1 while the upkeep executer skips heads because the balance of the keeper fromAddress is below KeeperMinimumBalance, 0 otherwise",
+		}, []string{"registryAddress"}),
+	}
+	m.descriptors = factory.descriptors
+	return m
+}
+
+func (m *promMetrics) AddReorgReevaluations(registryAddress string, upkeeps int) {
+	m.reorgReevaluations.WithLabelValues(registryAddress).Add(float64(upkeeps))
+}
+
+func (m *promMetrics) SetGasPriceConfidence(registryAddress string, confidence float64) {
+	m.gasPriceConfidence.WithLabelValues(registryAddress).Set(confidence)
+}
+
+func (m *promMetrics) IncEmptyMailboxRetrieves(registryAddress string) {
+	m.emptyMailboxRetrieves.WithLabelValues(registryAddress).Inc()
+}
+
+func (m *promMetrics) ObserveQueueWait(registryAddress string, wait time.Duration) {
+	m.queueWaitSeconds.WithLabelValues(registryAddress).Observe(wait.Seconds())
+}
+
+func (m *promMetrics) IncExecutionTimeouts(registryAddress string) {
+	m.executionTimeouts.WithLabelValues(registryAddress).Inc()
+}
+
+func (m *promMetrics) IncImplausibleGasPrices(registryAddress string) {
+	m.implausibleGasPrices.WithLabelValues(registryAddress).Inc()
+}
+
+func (m *promMetrics) IncBaseFeeOverpayments(registryAddress string) {
+	m.baseFeeOverpayments.WithLabelValues(registryAddress).Inc()
+}
+
+func (m *promMetrics) ObserveHeadToBroadcast(registryAddress string, latency time.Duration) {
+	m.headToBroadcast.WithLabelValues(registryAddress).Observe(latency.Seconds())
+}
+
+func (m *promMetrics) AddActiveExecutions(registryAddress string, delta int) {
+	m.activeExecutions.WithLabelValues(registryAddress).Add(float64(delta))
+}
+
+func (m *promMetrics) AddEligibleUpkeeps(registryAddress string, upkeeps int) {
+	m.eligibleUpkeeps.WithLabelValues(registryAddress).Add(float64(upkeeps))
+}
+
+func (m *promMetrics) IncPipelineRuns(registryAddress string, status pipeline.RunStatus) {
+	m.pipelineRuns.WithLabelValues(registryAddress, string(status)).Inc()
+}
+
+func (m *promMetrics) ObserveExecuteDuration(registryAddress string, duration time.Duration) {
+	m.executeDuration.WithLabelValues(registryAddress).Observe(duration.Seconds())
+}
+
+func (m *promMetrics) IncBlockGasLimitExceeded(registryAddress string) {
+	m.blockGasLimitExceeded.WithLabelValues(registryAddress).Inc()
+}
+
+func (m *promMetrics) SetIdle(registryAddress string, idle bool) {
+	value := 0.0
+	if idle {
+		value = 1
+	}
+	m.idle.WithLabelValues(registryAddress).Set(value)
+}
+
+func (m *promMetrics) AddSkippedHeads(registryAddress string, heads int) {
+	m.skippedHeads.WithLabelValues(registryAddress).Add(float64(heads))
+}
+
+func (m *promMetrics) IncDuplicateHeads(registryAddress string) {
+	m.duplicateHeads.WithLabelValues(registryAddress).Inc()
+}
+
+func (m *promMetrics) IncOverdueUpkeeps(registryAddress string) {
+	m.overdueUpkeeps.WithLabelValues(registryAddress).Inc()
+}
+
+func (m *promMetrics) IncUpkeepStateEvictions(registryAddress, state string) {
+	m.upkeepStateEvictions.WithLabelValues(registryAddress, state).Inc()
+}
+
+func (m *promMetrics) IncPipelinePanics(registryAddress string) {
+	m.pipelinePanics.WithLabelValues(registryAddress).Inc()
+}
+
+func (m *promMetrics) SetInsufficientBalance(registryAddress string, insufficient bool) {
+	value := 0.0
+	if insufficient {
+		value = 1
+	}
+	m.insufficientBalance.WithLabelValues(registryAddress).Set(value)
+}
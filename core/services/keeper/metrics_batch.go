@@ -0,0 +1,309 @@
+package keeper
+
+import (
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+)
+
+var _ Metrics = (*metricsBatch)(nil)
+
+// metricsBatch buffers metric updates and applies them to the underlying Metrics on flush, so
+// that the metrics registry is updated from a single goroutine once per head instead of
+// concurrently from every execution.
+type metricsBatch struct {
+	metrics Metrics
+
+	mu                    sync.Mutex
+	reorgReevaluations    map[string]int
+	gasPriceConfidence    map[string]float64
+	emptyMailboxRetrieves map[string]int
+	queueWaits            map[string][]time.Duration
+	executionTimeouts     map[string]int
+	implausibleGasPrices  map[string]int
+	baseFeeOverpayments   map[string]int
+	headToBroadcast       map[string][]time.Duration
+	eligibleUpkeeps       map[string]int
+	pipelineRuns          map[pipelineRunsKey]int
+	executeDurations      map[string][]time.Duration
+	blockGasLimitExceeded map[string]int
+	idle                  map[string]bool
+	skippedHeads          map[string]int
+	duplicateHeads        map[string]int
+	overdueUpkeeps        map[string]int
+	upkeepStateEvictions  map[upkeepStateEvictionsKey]int
+	pipelinePanics        map[string]int
+	insufficientBalance   map[string]bool
+}
+
+type pipelineRunsKey struct {
+	registryAddress string
+	status          pipeline.RunStatus
+}
+
+type upkeepStateEvictionsKey struct {
+	registryAddress string
+	state           string
+}
+
+func newMetricsBatch(metrics Metrics) *metricsBatch {
+	return &metricsBatch{
+		metrics:               metrics,
+		reorgReevaluations:    make(map[string]int),
+		gasPriceConfidence:    make(map[string]float64),
+		emptyMailboxRetrieves: make(map[string]int),
+		queueWaits:            make(map[string][]time.Duration),
+		executionTimeouts:     make(map[string]int),
+		implausibleGasPrices:  make(map[string]int),
+		baseFeeOverpayments:   make(map[string]int),
+		headToBroadcast:       make(map[string][]time.Duration),
+		eligibleUpkeeps:       make(map[string]int),
+		pipelineRuns:          make(map[pipelineRunsKey]int),
+		executeDurations:      make(map[string][]time.Duration),
+		blockGasLimitExceeded: make(map[string]int),
+		idle:                  make(map[string]bool),
+		skippedHeads:          make(map[string]int),
+		duplicateHeads:        make(map[string]int),
+		overdueUpkeeps:        make(map[string]int),
+		upkeepStateEvictions:  make(map[upkeepStateEvictionsKey]int),
+		pipelinePanics:        make(map[string]int),
+		insufficientBalance:   make(map[string]bool),
+	}
+}
+
+func (b *metricsBatch) AddReorgReevaluations(registryAddress string, upkeeps int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.reorgReevaluations[registryAddress] += upkeeps
+}
+
+func (b *metricsBatch) SetGasPriceConfidence(registryAddress string, confidence float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.gasPriceConfidence[registryAddress] = confidence
+}
+
+func (b *metricsBatch) IncEmptyMailboxRetrieves(registryAddress string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.emptyMailboxRetrieves[registryAddress]++
+}
+
+func (b *metricsBatch) ObserveQueueWait(registryAddress string, wait time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.queueWaits[registryAddress] = append(b.queueWaits[registryAddress], wait)
+}
+
+func (b *metricsBatch) IncExecutionTimeouts(registryAddress string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.executionTimeouts[registryAddress]++
+}
+
+func (b *metricsBatch) IncImplausibleGasPrices(registryAddress string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.implausibleGasPrices[registryAddress]++
+}
+
+func (b *metricsBatch) IncBaseFeeOverpayments(registryAddress string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.baseFeeOverpayments[registryAddress]++
+}
+
+func (b *metricsBatch) ObserveHeadToBroadcast(registryAddress string, latency time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.headToBroadcast[registryAddress] = append(b.headToBroadcast[registryAddress], latency)
+}
+
+func (b *metricsBatch) AddEligibleUpkeeps(registryAddress string, upkeeps int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.eligibleUpkeeps[registryAddress] += upkeeps
+}
+
+func (b *metricsBatch) IncPipelineRuns(registryAddress string, status pipeline.RunStatus) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.pipelineRuns[pipelineRunsKey{registryAddress, status}]++
+}
+
+func (b *metricsBatch) ObserveExecuteDuration(registryAddress string, duration time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.executeDurations[registryAddress] = append(b.executeDurations[registryAddress], duration)
+}
+
+func (b *metricsBatch) IncBlockGasLimitExceeded(registryAddress string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.blockGasLimitExceeded[registryAddress]++
+}
+
+func (b *metricsBatch) SetIdle(registryAddress string, idle bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.idle[registryAddress] = idle
+}
+
+func (b *metricsBatch) AddSkippedHeads(registryAddress string, heads int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.skippedHeads[registryAddress] += heads
+}
+
+func (b *metricsBatch) IncDuplicateHeads(registryAddress string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.duplicateHeads[registryAddress]++
+}
+
+func (b *metricsBatch) IncOverdueUpkeeps(registryAddress string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.overdueUpkeeps[registryAddress]++
+}
+
+func (b *metricsBatch) IncUpkeepStateEvictions(registryAddress, state string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.upkeepStateEvictions[upkeepStateEvictionsKey{registryAddress: registryAddress, state: state}]++
+}
+
+func (b *metricsBatch) IncPipelinePanics(registryAddress string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.pipelinePanics[registryAddress]++
+}
+
+func (b *metricsBatch) SetInsufficientBalance(registryAddress string, insufficient bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.insufficientBalance[registryAddress] = insufficient
+}
+
+// AddActiveExecutions isn't buffered since leaked executions would otherwise prevent the flush that reports them
+func (b *metricsBatch) AddActiveExecutions(registryAddress string, delta int) {
+	b.metrics.AddActiveExecutions(registryAddress, delta)
+}
+
+// flush applies the buffered updates to the underlying Metrics and empties the batch
+func (b *metricsBatch) flush() {
+	b.mu.Lock()
+	batch := newMetricsBatch(b.metrics)
+	batch.reorgReevaluations, b.reorgReevaluations = b.reorgReevaluations, batch.reorgReevaluations
+	batch.gasPriceConfidence, b.gasPriceConfidence = b.gasPriceConfidence, batch.gasPriceConfidence
+	batch.emptyMailboxRetrieves, b.emptyMailboxRetrieves = b.emptyMailboxRetrieves, batch.emptyMailboxRetrieves
+	batch.queueWaits, b.queueWaits = b.queueWaits, batch.queueWaits
+	batch.executionTimeouts, b.executionTimeouts = b.executionTimeouts, batch.executionTimeouts
+	batch.implausibleGasPrices, b.implausibleGasPrices = b.implausibleGasPrices, batch.implausibleGasPrices
+	batch.baseFeeOverpayments, b.baseFeeOverpayments = b.baseFeeOverpayments, batch.baseFeeOverpayments
+	batch.headToBroadcast, b.headToBroadcast = b.headToBroadcast, batch.headToBroadcast
+	batch.eligibleUpkeeps, b.eligibleUpkeeps = b.eligibleUpkeeps, batch.eligibleUpkeeps
+	batch.pipelineRuns, b.pipelineRuns = b.pipelineRuns, batch.pipelineRuns
+	batch.executeDurations, b.executeDurations = b.executeDurations, batch.executeDurations
+	batch.blockGasLimitExceeded, b.blockGasLimitExceeded = b.blockGasLimitExceeded, batch.blockGasLimitExceeded
+	batch.idle, b.idle = b.idle, batch.idle
+	batch.skippedHeads, b.skippedHeads = b.skippedHeads, batch.skippedHeads
+	batch.duplicateHeads, b.duplicateHeads = b.duplicateHeads, batch.duplicateHeads
+	batch.overdueUpkeeps, b.overdueUpkeeps = b.overdueUpkeeps, batch.overdueUpkeeps
+	batch.upkeepStateEvictions, b.upkeepStateEvictions = b.upkeepStateEvictions, batch.upkeepStateEvictions
+	batch.pipelinePanics, b.pipelinePanics = b.pipelinePanics, batch.pipelinePanics
+	batch.insufficientBalance, b.insufficientBalance = b.insufficientBalance, batch.insufficientBalance
+	b.mu.Unlock()
+
+	for registryAddress, upkeeps := range batch.reorgReevaluations {
+		b.metrics.AddReorgReevaluations(registryAddress, upkeeps)
+	}
+	for registryAddress, confidence := range batch.gasPriceConfidence {
+		b.metrics.SetGasPriceConfidence(registryAddress, confidence)
+	}
+	for registryAddress, n := range batch.emptyMailboxRetrieves {
+		for i := 0; i < n; i++ {
+			b.metrics.IncEmptyMailboxRetrieves(registryAddress)
+		}
+	}
+	for registryAddress, waits := range batch.queueWaits {
+		for _, wait := range waits {
+			b.metrics.ObserveQueueWait(registryAddress, wait)
+		}
+	}
+	for registryAddress, n := range batch.executionTimeouts {
+		for i := 0; i < n; i++ {
+			b.metrics.IncExecutionTimeouts(registryAddress)
+		}
+	}
+	for registryAddress, n := range batch.implausibleGasPrices {
+		for i := 0; i < n; i++ {
+			b.metrics.IncImplausibleGasPrices(registryAddress)
+		}
+	}
+	for registryAddress, n := range batch.baseFeeOverpayments {
+		for i := 0; i < n; i++ {
+			b.metrics.IncBaseFeeOverpayments(registryAddress)
+		}
+	}
+	for registryAddress, latencies := range batch.headToBroadcast {
+		for _, latency := range latencies {
+			b.metrics.ObserveHeadToBroadcast(registryAddress, latency)
+		}
+	}
+	for registryAddress, upkeeps := range batch.eligibleUpkeeps {
+		b.metrics.AddEligibleUpkeeps(registryAddress, upkeeps)
+	}
+	for key, n := range batch.pipelineRuns {
+		for i := 0; i < n; i++ {
+			b.metrics.IncPipelineRuns(key.registryAddress, key.status)
+		}
+	}
+	for registryAddress, durations := range batch.executeDurations {
+		for _, duration := range durations {
+			b.metrics.ObserveExecuteDuration(registryAddress, duration)
+		}
+	}
+	for registryAddress, n := range batch.blockGasLimitExceeded {
+		for i := 0; i < n; i++ {
+			b.metrics.IncBlockGasLimitExceeded(registryAddress)
+		}
+	}
+	for registryAddress, idle := range batch.idle {
+		b.metrics.SetIdle(registryAddress, idle)
+	}
+	for registryAddress, heads := range batch.skippedHeads {
+		b.metrics.AddSkippedHeads(registryAddress, heads)
+	}
+	for registryAddress, n := range batch.duplicateHeads {
+		for i := 0; i < n; i++ {
+			b.metrics.IncDuplicateHeads(registryAddress)
+		}
+	}
+	for registryAddress, n := range batch.overdueUpkeeps {
+		for i := 0; i < n; i++ {
+			b.metrics.IncOverdueUpkeeps(registryAddress)
+		}
+	}
+	for key, n := range batch.upkeepStateEvictions {
+		for i := 0; i < n; i++ {
+			b.metrics.IncUpkeepStateEvictions(key.registryAddress, key.state)
+		}
+	}
+	for registryAddress, n := range batch.pipelinePanics {
+		for i := 0; i < n; i++ {
+			b.metrics.IncPipelinePanics(registryAddress)
+		}
+	}
+	for registryAddress, insufficient := range batch.insufficientBalance {
+		b.metrics.SetInsufficientBalance(registryAddress, insufficient)
+	}
+}
+
+// flushMetrics applies the metric updates buffered because of KeeperBatchMetricUpdates
+func (ex *UpkeepExecuter) flushMetrics() {
+	if batch, ok := ex.metrics.(*metricsBatch); ok {
+		batch.flush()
+	}
+}
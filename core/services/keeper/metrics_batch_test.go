@@ -0,0 +1,78 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+)
+
+type recordingMetrics struct {
+	reorgReevaluations    int
+	emptyMailboxRetrieves int
+	queueWaits            []time.Duration
+	executionTimeouts     int
+	idle                  []bool
+	skippedHeads          int
+	overdueUpkeeps        int
+	upkeepStateEvictions  []string
+	insufficientBalance   []bool
+	implausibleGasPrices  int
+	headToBroadcast       []time.Duration
+}
+
+func (m *recordingMetrics) AddReorgReevaluations(_ string, upkeeps int) {
+	m.reorgReevaluations += upkeeps
+}
+func (m *recordingMetrics) SetGasPriceConfidence(string, float64) {}
+func (m *recordingMetrics) IncEmptyMailboxRetrieves(string)       { m.emptyMailboxRetrieves++ }
+func (m *recordingMetrics) ObserveQueueWait(_ string, wait time.Duration) {
+	m.queueWaits = append(m.queueWaits, wait)
+}
+func (m *recordingMetrics) IncExecutionTimeouts(string)    { m.executionTimeouts++ }
+func (m *recordingMetrics) IncImplausibleGasPrices(string) { m.implausibleGasPrices++ }
+func (m *recordingMetrics) IncBaseFeeOverpayments(string)  {}
+func (m *recordingMetrics) ObserveHeadToBroadcast(_ string, latency time.Duration) {
+	m.headToBroadcast = append(m.headToBroadcast, latency)
+}
+func (m *recordingMetrics) AddActiveExecutions(string, int)              {}
+func (m *recordingMetrics) AddEligibleUpkeeps(string, int)               {}
+func (m *recordingMetrics) IncPipelineRuns(string, pipeline.RunStatus)   {}
+func (m *recordingMetrics) ObserveExecuteDuration(string, time.Duration) {}
+func (m *recordingMetrics) IncBlockGasLimitExceeded(string)              {}
+func (m *recordingMetrics) SetIdle(_ string, idle bool)                  { m.idle = append(m.idle, idle) }
+func (m *recordingMetrics) AddSkippedHeads(_ string, heads int)          { m.skippedHeads += heads }
+func (m *recordingMetrics) IncDuplicateHeads(string)                     {}
+func (m *recordingMetrics) IncOverdueUpkeeps(string)                     { m.overdueUpkeeps++ }
+func (m *recordingMetrics) IncPipelinePanics(string)                     {}
+func (m *recordingMetrics) SetInsufficientBalance(_ string, insufficient bool) {
+	m.insufficientBalance = append(m.insufficientBalance, insufficient)
+}
+func (m *recordingMetrics) IncUpkeepStateEvictions(_, state string) {
+	m.upkeepStateEvictions = append(m.upkeepStateEvictions, state)
+}
+
+func TestMetricsBatch_Flush(t *testing.T) {
+	t.Parallel()
+
+	recorder := &recordingMetrics{}
+	batch := newMetricsBatch(recorder)
+
+	batch.AddReorgReevaluations("0x1", 2)
+	batch.AddReorgReevaluations("0x1", 3)
+	batch.ObserveQueueWait("0x1", time.Second)
+	batch.ObserveQueueWait("0x1", 2*time.Second)
+	batch.IncExecutionTimeouts("0x1")
+	require.Zero(t, recorder.reorgReevaluations)
+
+	batch.flush()
+	require.Equal(t, 5, recorder.reorgReevaluations)
+	require.Equal(t, []time.Duration{time.Second, 2 * time.Second}, recorder.queueWaits)
+	require.Equal(t, 1, recorder.executionTimeouts)
+
+	batch.flush()
+	require.Equal(t, 5, recorder.reorgReevaluations)
+	require.Equal(t, 1, recorder.executionTimeouts)
+}
@@ -0,0 +1,56 @@
+package keeper
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// MetricType is the Prometheus type of a metric
+type MetricType string
+
+const (
+	MetricTypeCounter   MetricType = "counter"
+	MetricTypeGauge     MetricType = "gauge"
+	MetricTypeHistogram MetricType = "histogram"
+)
+
+// MetricDescriptor describes a metric emitted by the keeper, for generating dashboards and alerts
+type MetricDescriptor struct {
+	Name   string     `json:"name"`
+	Type   MetricType `json:"type"`
+	Help   string     `json:"help"`
+	Labels []string   `json:"labels"`
+}
+
+// KeeperMetricsManifest returns the descriptors of every metric the keeper emits
+func KeeperMetricsManifest() []MetricDescriptor {
+	manifest := make([]MetricDescriptor, len(defaultMetrics.descriptors))
+	copy(manifest, defaultMetrics.descriptors)
+	return manifest
+}
+
+// describingFactory creates metrics and records their descriptors, so that the manifest
+// can't drift from the metrics that are actually registered
+type describingFactory struct {
+	factory     promauto.Factory
+	descriptors []MetricDescriptor
+}
+
+func (f *describingFactory) describe(name string, metricType MetricType, help string, labels []string) {
+	f.descriptors = append(f.descriptors, MetricDescriptor{Name: name, Type: metricType, Help: help, Labels: labels})
+}
+
+func (f *describingFactory) counterVec(opts prometheus.CounterOpts, labels []string) *prometheus.CounterVec {
+	f.describe(opts.Name, MetricTypeCounter, opts.Help, labels)
+	return f.factory.NewCounterVec(opts, labels)
+}
+
+func (f *describingFactory) gaugeVec(opts prometheus.GaugeOpts, labels []string) *prometheus.GaugeVec {
+	f.describe(opts.Name, MetricTypeGauge, opts.Help, labels)
+	return f.factory.NewGaugeVec(opts, labels)
+}
+
+func (f *describingFactory) histogramVec(opts prometheus.HistogramOpts, labels []string) *prometheus.HistogramVec {
+	f.describe(opts.Name, MetricTypeHistogram, opts.Help, labels)
+	return f.factory.NewHistogramVec(opts, labels)
+}
@@ -0,0 +1,27 @@
+package keeper_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/services/keeper"
+)
+
+func TestKeeperMetricsManifest(t *testing.T) {
+	t.Parallel()
+
+	manifest := keeper.KeeperMetricsManifest()
+	require.NotEmpty(t, manifest)
+
+	names := make(map[string]struct{})
+	for _, metric := range manifest {
+		require.True(t, strings.HasPrefix(metric.Name, "keeper_"), metric.Name)
+		require.NotEmpty(t, metric.Help, metric.Name)
+		require.Contains(t, []keeper.MetricType{keeper.MetricTypeCounter, keeper.MetricTypeGauge, keeper.MetricTypeHistogram}, metric.Type)
+		require.NotContains(t, names, metric.Name)
+		names[metric.Name] = struct{}{}
+	}
+	require.Contains(t, names, "keeper_active_executions")
+}
@@ -0,0 +1,44 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+)
+
+func TestPromMetrics(t *testing.T) {
+	t.Parallel()
+
+	m := newPromMetrics(prometheus.NewRegistry())
+	var metrics Metrics = m
+
+	metrics.AddReorgReevaluations("0x1", 3)
+	metrics.AddReorgReevaluations("0x1", 2)
+	metrics.AddReorgReevaluations("0x2", 1)
+	require.Equal(t, 5.0, testutil.ToFloat64(m.reorgReevaluations.WithLabelValues("0x1")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.reorgReevaluations.WithLabelValues("0x2")))
+
+	metrics.AddActiveExecutions("0x1", 2)
+	metrics.AddActiveExecutions("0x1", -1)
+	require.Equal(t, 1.0, testutil.ToFloat64(m.activeExecutions.WithLabelValues("0x1")))
+
+	metrics.IncPipelineRuns("0x1", pipeline.RunStatusCompleted)
+	metrics.IncPipelineRuns("0x1", pipeline.RunStatusErrored)
+	metrics.IncPipelineRuns("0x1", pipeline.RunStatusErrored)
+	require.Equal(t, 1.0, testutil.ToFloat64(m.pipelineRuns.WithLabelValues("0x1", string(pipeline.RunStatusCompleted))))
+	require.Equal(t, 2.0, testutil.ToFloat64(m.pipelineRuns.WithLabelValues("0x1", string(pipeline.RunStatusErrored))))
+
+	metrics.SetIdle("0x1", true)
+	require.Equal(t, 1.0, testutil.ToFloat64(m.idle.WithLabelValues("0x1")))
+	metrics.SetIdle("0x1", false)
+	require.Equal(t, 0.0, testutil.ToFloat64(m.idle.WithLabelValues("0x1")))
+
+	metrics.ObserveQueueWait("0x1", time.Second)
+	metrics.ObserveQueueWait("0x1", 2*time.Second)
+	require.Equal(t, 1, testutil.CollectAndCount(m.queueWaitSeconds))
+}
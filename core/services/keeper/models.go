@@ -1,6 +1,16 @@
 package keeper
 
-import "github.com/smartcontractkit/chainlink/core/services/keystore/keys/ethkey"
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/services/keystore/keys/ethkey"
+)
 
 type Registry struct {
 	ID                int32 `gorm:"primary_key"`
@@ -11,6 +21,11 @@ type Registry struct {
 	JobID             int32
 	KeeperIndex       int32
 	NumKeepers        int32
+	// Paused is the paused state of the registry contract when it was last synced, performs revert while it is paused
+	Paused bool
+	// Version is the KeeperRegistry version set by the registryVersion of the keeper job, the executer
+	// packs its calls to the registry for it. Empty means DefaultRegistryVersion.
+	Version string
 }
 
 func (Registry) TableName() string {
@@ -26,4 +41,64 @@ type UpkeepRegistration struct {
 	Registry            Registry
 	UpkeepID            int64
 	PositioningConstant int32
+	// LastError and LastErrorBlockHeight record the last failed execution of the upkeep. FailureCount is the
+	// number of failed executions since the last successful one.
+	LastError            null.String
+	LastErrorBlockHeight null.Int
+	FailureCount         int32
+}
+
+// ExecutionCounts tallies the executions of an upkeep by outcome. Every execution checks the upkeep,
+// so Checks less Performs and Skips is the number of failed executions.
+type ExecutionCounts struct {
+	Checks   int64
+	Performs int64
+	Skips    int64
+}
+
+// ExecutionStats are the execution counts and the last run and failure of an upkeep of a job
+type ExecutionStats struct {
+	RegistryAddress ethkey.EIP55Address
+	UpkeepID        int64
+	ExecutionCounts
+	LastRunBlockHeight   int64
+	LastError            null.String
+	LastErrorBlockHeight null.Int
+	FailureCount         int32
+}
+
+// upkeepKey identifies an upkeep among the registries of a job, upkeep IDs are only unique within a registry
+type upkeepKey struct {
+	registry common.Address
+	upkeepID int64
+}
+
+func (upkeep UpkeepRegistration) key() upkeepKey {
+	return upkeepKey{registry: upkeep.Registry.ContractAddress.Address(), upkeepID: upkeep.UpkeepID}
+}
+
+// ConfigSnapshot is the effective keeper config of a job when it was last started
+type ConfigSnapshot struct {
+	JobID     int32        `gorm:"primary_key"`
+	Snapshot  ConfigValues `gorm:"type:jsonb"`
+	UpdatedAt time.Time
+}
+
+func (ConfigSnapshot) TableName() string {
+	return "keeper_config_snapshots"
+}
+
+// ConfigValues maps keeper config names to their formatted values
+type ConfigValues map[string]string
+
+func (v *ConfigValues) Scan(value interface{}) error {
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.Errorf("ConfigValues#Scan received a value of type %T", value)
+	}
+	return json.Unmarshal(bytes, v)
+}
+
+func (v ConfigValues) Value() (driver.Value, error) {
+	return json.Marshal(v)
 }
@@ -0,0 +1,82 @@
+package keeper
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/services/postgres"
+)
+
+// headNonceGap holds whether the nonce gaps of the sending keys were within KeeperNonceGapTolerance on a head.
+// They are measured once per head, by the first execution of the head about to run its pipeline.
+type headNonceGap struct {
+	headNumber int64
+	once       sync.Once
+	within     bool
+}
+
+// nonceGap returns the number of transactions from address that the tx manager has broadcast
+// past the nonce the chain is waiting for. These transactions can't be mined until the
+// transaction holding the missing nonce is.
+func (ex *UpkeepExecuter) nonceGap(ctx context.Context, address common.Address) (int64, error) {
+	pendingNonce, err := ex.ethClient.PendingNonceAt(ctx, address)
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to get pending nonce")
+	}
+	nextNonce, err := ex.orm.NextNonceForKey(ctx, address, ex.ethClient.ChainID())
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to get next nonce")
+	}
+	if gap := nextNonce - int64(pendingNonce); gap > 0 {
+		return gap, nil
+	}
+	return 0, nil
+}
+
+// withinNonceGapTolerance reports whether upkeeps may be performed on headNumber given the nonce gaps of
+// the sending keys. The ethtx task of the keeper pipeline sends every perform from the least recently used
+// sending key of the node, so performing is deferred if any of them has a gap above the tolerance.
+// If a gap can't be determined performing goes ahead.
+func (ex *UpkeepExecuter) withinNonceGapTolerance(headNumber int64) bool {
+	ex.nonceGapMu.Lock()
+	check := ex.headNonceGap
+	if check == nil || check.headNumber != headNumber {
+		check = &headNonceGap{headNumber: headNumber}
+		ex.headNonceGap = check
+	}
+	ex.nonceGapMu.Unlock()
+
+	check.once.Do(func() {
+		check.within = ex.measureNonceGaps()
+	})
+	return check.within
+}
+
+// measureNonceGaps measures the nonce gap of every sending key, returning false if one exceeds KeeperNonceGapTolerance
+func (ex *UpkeepExecuter) measureNonceGaps() bool {
+	ctx, cancel := postgres.DefaultQueryCtx()
+	defer cancel()
+
+	keys, err := ex.orm.SendingKeys(ctx)
+	if err != nil {
+		ex.logger.Warnw("unable to check for a nonce gap", "error", err)
+		return true
+	}
+	tolerance := int64(ex.config.KeeperNonceGapTolerance())
+	for _, key := range keys {
+		gap, err := ex.nonceGap(ctx, key)
+		if err != nil {
+			ex.logger.Warnw("unable to check for a nonce gap", "fromAddress", key.Hex(), "error", err)
+			continue
+		}
+		if gap > tolerance {
+			ex.logger.Warnw("deferring upkeeps until the nonce gap is filled",
+				"fromAddress", key.Hex(), "gap", gap, "tolerance", tolerance)
+			return false
+		}
+	}
+	return true
+}
@@ -1,8 +1,13 @@
 package keeper
 
 import (
+	"bytes"
 	"context"
+	"math/big"
+	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
@@ -38,10 +43,11 @@ func (korm ORM) Registries(ctx context.Context) ([]Registry, error) {
 	return registries, err
 }
 
-func (korm ORM) RegistryForJob(ctx context.Context, jobID int32) (Registry, error) {
+// RegistryForJob returns the registry at registryAddress that is serviced by the job
+func (korm ORM) RegistryForJob(ctx context.Context, jobID int32, registryAddress ethkey.EIP55Address) (Registry, error) {
 	var registry Registry
 	err := korm.getDB(ctx).
-		First(&registry, "job_id = ?", jobID).
+		First(&registry, "job_id = ? AND contract_address = ?", jobID, registryAddress).
 		Error
 	return registry, err
 }
@@ -49,9 +55,9 @@ func (korm ORM) RegistryForJob(ctx context.Context, jobID int32) (Registry, erro
 func (korm ORM) UpsertRegistry(ctx context.Context, registry *Registry) error {
 	return korm.getDB(ctx).
 		Clauses(clause.OnConflict{
-			Columns: []clause.Column{{Name: "job_id"}},
+			Columns: []clause.Column{{Name: "contract_address"}},
 			DoUpdates: clause.AssignmentColumns(
-				[]string{"keeper_index", "check_gas", "block_count_per_turn", "num_keepers"},
+				[]string{"keeper_index", "check_gas", "block_count_per_turn", "num_keepers", "paused", "version"},
 			),
 		}).
 		Create(registry).
@@ -68,22 +74,25 @@ func (korm ORM) UpsertUpkeep(ctx context.Context, registration *UpkeepRegistrati
 		Error
 }
 
-func (korm ORM) BatchDeleteUpkeepsForJob(ctx context.Context, jobID int32, upkeedIDs []int64) (int64, error) {
+func (korm ORM) BatchDeleteUpkeepsForJob(ctx context.Context, jobID int32, registryAddress ethkey.EIP55Address, upkeedIDs []int64) (int64, error) {
 	exec := korm.getDB(ctx).
 		Exec(
 			`DELETE FROM upkeep_registrations WHERE registry_id = (
-			SELECT id from keeper_registries where job_id = ?
+			SELECT id from keeper_registries where job_id = ? AND contract_address = ?
 		) AND upkeep_id IN (?)`,
 			jobID,
+			registryAddress,
 			upkeedIDs,
 		)
 	return exec.RowsAffected, exec.Error
 }
 
+// EligibleUpkeepsForRegistry returns the upkeeps of the registry this node's turn is on at blockNumber.
+// Upkeeps run during the last gracePeriod blocks, or less than minConfirmations blocks before blockNumber, aren't eligible.
 func (korm ORM) EligibleUpkeepsForRegistry(
 	ctx context.Context,
 	registryAddress ethkey.EIP55Address,
-	blockNumber, gracePeriod int64,
+	blockNumber, gracePeriod, minConfirmations int64,
 ) ([]UpkeepRegistration, error) {
 	var upkeeps []UpkeepRegistration
 	err := korm.getDB(ctx).
@@ -96,19 +105,32 @@ func (korm ORM) EligibleUpkeepsForRegistry(
 			(
 				upkeep_registrations.last_run_block_height = 0 OR (
 					upkeep_registrations.last_run_block_height + ? < ? AND
+					? - upkeep_registrations.last_run_block_height >= ? AND
 					upkeep_registrations.last_run_block_height < (? - (? % keeper_registries.block_count_per_turn))
 				)
 			) AND
 			keeper_registries.keeper_index = (
 				upkeep_registrations.positioning_constant + ((? - (? % keeper_registries.block_count_per_turn)) / keeper_registries.block_count_per_turn)
 			) % keeper_registries.num_keepers
-		`, registryAddress, gracePeriod, blockNumber, blockNumber, blockNumber, blockNumber, blockNumber).
+		`, registryAddress, gracePeriod, blockNumber, blockNumber, minConfirmations, blockNumber, blockNumber, blockNumber, blockNumber).
 		Find(&upkeeps).
 		Error
 
 	return upkeeps, err
 }
 
+// UpkeepForJob returns the upkeep of the registry at registryAddress serviced by the job, along with its registry
+func (korm ORM) UpkeepForJob(ctx context.Context, jobID int32, registryAddress ethkey.EIP55Address, upkeepID int64) (UpkeepRegistration, error) {
+	var upkeep UpkeepRegistration
+	err := korm.getDB(ctx).
+		Preload("Registry").
+		Joins("INNER JOIN keeper_registries ON keeper_registries.id = upkeep_registrations.registry_id").
+		Where("keeper_registries.job_id = ? AND keeper_registries.contract_address = ? AND upkeep_registrations.upkeep_id = ?", jobID, registryAddress, upkeepID).
+		Take(&upkeep).
+		Error
+	return upkeep, err
+}
+
 // LowestUnsyncedID returns the largest upkeepID + 1, indicating the expected next upkeepID
 // to sync from the contract
 func (korm ORM) LowestUnsyncedID(ctx context.Context, regID int32) (int64, error) {
@@ -122,20 +144,188 @@ func (korm ORM) LowestUnsyncedID(ctx context.Context, regID int32) (int64, error
 	return nextID, err
 }
 
-func (korm ORM) SetLastRunHeightForUpkeepOnJob(ctx context.Context, jobID int32, upkeepID, height int64) error {
+func (korm ORM) SetLastRunHeightForUpkeepOnJob(ctx context.Context, jobID int32, registryAddress ethkey.EIP55Address, upkeepID, height int64) error {
+	return korm.getDB(ctx).
+		Exec(`UPDATE upkeep_registrations
+		SET last_run_block_height = ?, failure_count = 0
+		WHERE upkeep_id = ? AND
+		registry_id = (
+			SELECT id FROM keeper_registries WHERE job_id = ? AND contract_address = ?
+		);`,
+			height,
+			upkeepID,
+			jobID,
+			registryAddress,
+		).Error
+}
+
+// SetLastErrorForUpkeepOnJob records a failed execution of the upkeep at height and increments its failure count,
+// which is reset by SetLastRunHeightForUpkeepOnJob
+func (korm ORM) SetLastErrorForUpkeepOnJob(ctx context.Context, jobID int32, registryAddress ethkey.EIP55Address, upkeepID, height int64, runErr error) error {
 	return korm.getDB(ctx).
 		Exec(`UPDATE upkeep_registrations
-		SET last_run_block_height = ?
+		SET last_error = ?, last_error_block_height = ?, failure_count = failure_count + 1
 		WHERE upkeep_id = ? AND
 		registry_id = (
-			SELECT id FROM keeper_registries WHERE job_id = ?
+			SELECT id FROM keeper_registries WHERE job_id = ? AND contract_address = ?
 		);`,
+			runErr.Error(),
 			height,
 			upkeepID,
 			jobID,
+			registryAddress,
 		).Error
 }
 
+// AddExecutionCounts adds counts to the execution counts of the upkeep registrations, keyed by their ID
+func (korm ORM) AddExecutionCounts(ctx context.Context, counts map[int32]ExecutionCounts) error {
+	if len(counts) == 0 {
+		return nil
+	}
+	values := make([]string, 0, len(counts))
+	args := make([]interface{}, 0, 4*len(counts))
+	for id, c := range counts {
+		values = append(values, "(?::int, ?::bigint, ?::bigint, ?::bigint)")
+		args = append(args, id, c.Checks, c.Performs, c.Skips)
+	}
+	return korm.getDB(ctx).
+		Exec(`UPDATE upkeep_registrations
+		SET check_count = upkeep_registrations.check_count + counts.checks,
+			perform_count = upkeep_registrations.perform_count + counts.performs,
+			skip_count = upkeep_registrations.skip_count + counts.skips
+		FROM (VALUES `+strings.Join(values, ", ")+`) AS counts (id, checks, performs, skips)
+		WHERE upkeep_registrations.id = counts.id;`,
+			args...,
+		).Error
+}
+
+// ExecutionStats returns the execution stats of the upkeeps of the job, ordered by registry and upkeep ID
+func (korm ORM) ExecutionStats(ctx context.Context, jobID int32) ([]ExecutionStats, error) {
+	var stats []ExecutionStats
+	err := korm.getDB(ctx).
+		Raw(`SELECT keeper_registries.contract_address AS registry_address, upkeep_registrations.upkeep_id,
+			upkeep_registrations.check_count AS checks, upkeep_registrations.perform_count AS performs,
+			upkeep_registrations.skip_count AS skips, upkeep_registrations.last_run_block_height,
+			upkeep_registrations.last_error, upkeep_registrations.last_error_block_height,
+			upkeep_registrations.failure_count
+		FROM upkeep_registrations
+		INNER JOIN keeper_registries ON keeper_registries.id = upkeep_registrations.registry_id
+		WHERE keeper_registries.job_id = ?
+		ORDER BY keeper_registries.contract_address ASC, upkeep_registrations.upkeep_id ASC;`,
+			jobID,
+		).
+		Scan(&stats).
+		Error
+	return stats, err
+}
+
+// ConfigSnapshotForJob returns the config values saved when the job was last started, or nil if there are none
+func (korm ORM) ConfigSnapshotForJob(ctx context.Context, jobID int32) (ConfigValues, error) {
+	var snapshot ConfigSnapshot
+	err := korm.getDB(ctx).
+		First(&snapshot, "job_id = ?", jobID).
+		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return snapshot.Snapshot, err
+}
+
+func (korm ORM) UpsertConfigSnapshot(ctx context.Context, snapshot *ConfigSnapshot) error {
+	return korm.getDB(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "job_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"snapshot", "updated_at"}),
+		}).
+		Create(snapshot).
+		Error
+}
+
+// IndexedBlockNumberForJob returns the block number of the highest log consumed for the job.
+// The boolean result is false if the job hasn't consumed any log.
+func (korm ORM) IndexedBlockNumberForJob(ctx context.Context, jobID int32) (int64, bool, error) {
+	var blockNumber *int64
+	err := korm.getDB(ctx).
+		Raw(`SELECT MAX(block_number) FROM log_broadcasts WHERE job_id = ? AND consumed`, jobID).
+		Row().
+		Scan(&blockNumber)
+	if err != nil {
+		return 0, false, errors.Wrap(err, "failed to load indexed block number")
+	}
+	if blockNumber == nil {
+		return 0, false, nil
+	}
+	return *blockNumber, true, nil
+}
+
+// SendingKeys returns the addresses of the node's sending keys, which the ethtx task sends transactions from
+func (korm ORM) SendingKeys(ctx context.Context) ([]common.Address, error) {
+	var addresses []ethkey.EIP55Address
+	err := korm.getDB(ctx).
+		Model(&ethkey.State{}).
+		Where("NOT is_funding").
+		Order("address").
+		Pluck("address", &addresses).
+		Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load sending keys")
+	}
+	keys := make([]common.Address, len(addresses))
+	for i, address := range addresses {
+		keys[i] = address.Address()
+	}
+	return keys, nil
+}
+
+// NextNonceForKey returns the nonce the tx manager will use for the next transaction from address
+func (korm ORM) NextNonceForKey(ctx context.Context, address common.Address, chainID *big.Int) (int64, error) {
+	return bulletprooftxmanager.GetNextNonce(korm.getDB(ctx), address, chainID)
+}
+
+// PendingPerform is a perform transaction of an upkeep that hasn't been confirmed yet
+type PendingPerform struct {
+	UpkeepID    int64
+	FromAddress common.Address
+}
+
+// PendingPerforms returns the performs to the registry that the tx manager has sent from one of
+// fromAddresses and that haven't been confirmed yet
+func (korm ORM) PendingPerforms(ctx context.Context, fromAddresses []common.Address, registryAddress common.Address) ([]PendingPerform, error) {
+	if len(fromAddresses) == 0 {
+		return nil, nil
+	}
+	var txes []struct {
+		FromAddress    common.Address
+		EncodedPayload []byte
+	}
+	err := korm.getDB(ctx).
+		Table("eth_txes").
+		Select("from_address, encoded_payload").
+		Where("from_address IN ? AND to_address = ? AND state IN ?", fromAddresses, registryAddress, []bulletprooftxmanager.EthTxState{
+			bulletprooftxmanager.EthTxUnstarted,
+			bulletprooftxmanager.EthTxInProgress,
+			bulletprooftxmanager.EthTxUnconfirmed,
+		}).
+		Scan(&txes).
+		Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load pending performs")
+	}
+	selector := RegistryABI.Methods["performUpkeep"].ID
+	var performs []PendingPerform
+	for _, tx := range txes {
+		// performUpkeep(uint256 id, bytes performData) starts with the selector followed by the upkeep ID
+		if len(tx.EncodedPayload) < 36 || !bytes.Equal(tx.EncodedPayload[:4], selector) {
+			continue
+		}
+		performs = append(performs, PendingPerform{
+			UpkeepID:    new(big.Int).SetBytes(tx.EncodedPayload[4:36]).Int64(),
+			FromAddress: tx.FromAddress,
+		})
+	}
+	return performs, nil
+}
+
 func (korm ORM) getDB(ctx context.Context) *gorm.DB {
 	return postgres.TxFromContext(ctx, korm.DB).WithContext(ctx)
 }
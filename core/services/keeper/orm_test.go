@@ -2,11 +2,14 @@ package keeper_test
 
 import (
 	"context"
+	"math/big"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
 	"gorm.io/gorm"
 
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
@@ -14,6 +17,8 @@ import (
 	"github.com/smartcontractkit/chainlink/core/internal/testutils/pgtest"
 	"github.com/smartcontractkit/chainlink/core/services/bulletprooftxmanager"
 	"github.com/smartcontractkit/chainlink/core/services/keeper"
+	"github.com/smartcontractkit/chainlink/core/services/log"
+	"github.com/smartcontractkit/chainlink/core/utils"
 )
 
 var checkData = common.Hex2Bytes("ABC123")
@@ -110,7 +115,7 @@ func TestKeeperDB_BatchDeleteUpkeepsForJob(t *testing.T) {
 
 	cltest.AssertCount(t, db, &keeper.UpkeepRegistration{}, 3)
 
-	_, err := orm.BatchDeleteUpkeepsForJob(context.Background(), job.ID, []int64{0, 2})
+	_, err := orm.BatchDeleteUpkeepsForJob(context.Background(), job.ID, registry.ContractAddress, []int64{0, 2})
 	require.NoError(t, err)
 	cltest.AssertCount(t, db, &keeper.UpkeepRegistration{}, 1)
 
@@ -151,7 +156,7 @@ func TestKeeperDB_EligibleUpkeeps_BlockCountPerTurn(t *testing.T) {
 
 	cltest.AssertCount(t, db, &keeper.UpkeepRegistration{}, 5)
 
-	eligibleUpkeeps, err := orm.EligibleUpkeepsForRegistry(context.Background(), registry.ContractAddress, blockheight, gracePeriod)
+	eligibleUpkeeps, err := orm.EligibleUpkeepsForRegistry(context.Background(), registry.ContractAddress, blockheight, gracePeriod, 0)
 	assert.NoError(t, err)
 
 	require.Len(t, eligibleUpkeeps, 3)
@@ -194,13 +199,60 @@ func TestKeeperDB_EligibleUpkeeps_GracePeriod(t *testing.T) {
 
 	cltest.AssertCount(t, db, &keeper.UpkeepRegistration{}, 3)
 
-	eligibleUpkeeps, err := orm.EligibleUpkeepsForRegistry(context.Background(), registry.ContractAddress, blockheight, gracePeriod)
+	eligibleUpkeeps, err := orm.EligibleUpkeepsForRegistry(context.Background(), registry.ContractAddress, blockheight, gracePeriod, 0)
 	assert.NoError(t, err)
 	assert.Len(t, eligibleUpkeeps, 2)
 	assert.Equal(t, int64(0), eligibleUpkeeps[0].UpkeepID)
 	assert.Equal(t, int64(1), eligibleUpkeeps[1].UpkeepID)
 }
 
+func TestKeeperDB_EligibleUpkeeps_MinConfirmations(t *testing.T) {
+	t.Parallel()
+	db, _, orm := setupKeeperDB(t)
+	ethKeyStore := cltest.NewKeyStore(t, db).Eth()
+
+	blockheight := int64(120)
+	minConfirmations := int64(30)
+
+	registry, _ := cltest.MustInsertKeeperRegistry(t, db, ethKeyStore)
+	upkeeps := [4]keeper.UpkeepRegistration{
+		newUpkeep(registry, 0),
+		newUpkeep(registry, 1),
+		newUpkeep(registry, 2),
+		newUpkeep(registry, 3),
+	}
+
+	upkeeps[0].LastRunBlockHeight = 0  // Never run
+	upkeeps[1].LastRunBlockHeight = 89 // 31 blocks deep
+	upkeeps[2].LastRunBlockHeight = 90 // 30 blocks deep, right at the threshold
+	upkeeps[3].LastRunBlockHeight = 91 // 29 blocks deep (EXCLUDE)
+
+	for _, upkeep := range upkeeps {
+		err := orm.UpsertUpkeep(context.Background(), &upkeep)
+		require.NoError(t, err)
+	}
+
+	cltest.AssertCount(t, db, &keeper.UpkeepRegistration{}, 4)
+
+	eligibleUpkeeps, err := orm.EligibleUpkeepsForRegistry(context.Background(), registry.ContractAddress, blockheight, 0, minConfirmations)
+	assert.NoError(t, err)
+	require.Len(t, eligibleUpkeeps, 3)
+	assert.Equal(t, int64(0), eligibleUpkeeps[0].UpkeepID)
+	assert.Equal(t, int64(1), eligibleUpkeeps[1].UpkeepID)
+	assert.Equal(t, int64(2), eligibleUpkeeps[2].UpkeepID)
+
+	// one block later the last upkeep is deep enough
+	eligibleUpkeeps, err = orm.EligibleUpkeepsForRegistry(context.Background(), registry.ContractAddress, blockheight+1, 0, minConfirmations)
+	assert.NoError(t, err)
+	assert.Len(t, eligibleUpkeeps, 4)
+
+	// the grace period still applies
+	eligibleUpkeeps, err = orm.EligibleUpkeepsForRegistry(context.Background(), registry.ContractAddress, blockheight, 30, minConfirmations)
+	assert.NoError(t, err)
+	require.Len(t, eligibleUpkeeps, 2)
+	assert.Equal(t, int64(1), eligibleUpkeeps[1].UpkeepID)
+}
+
 func TestKeeperDB_EligibleUpkeeps_KeepersRotate(t *testing.T) {
 	t.Parallel()
 	db, config, orm := setupKeeperDB(t)
@@ -216,15 +268,15 @@ func TestKeeperDB_EligibleUpkeeps_KeepersRotate(t *testing.T) {
 
 	// out of 5 valid block ranges, with 5 keepers, we are eligible
 	// to submit on exactly 1 of them
-	list1, err := orm.EligibleUpkeepsForRegistry(context.Background(), registry.ContractAddress, 20, 0)
+	list1, err := orm.EligibleUpkeepsForRegistry(context.Background(), registry.ContractAddress, 20, 0, 0)
 	require.NoError(t, err)
-	list2, err := orm.EligibleUpkeepsForRegistry(context.Background(), registry.ContractAddress, 41, 0)
+	list2, err := orm.EligibleUpkeepsForRegistry(context.Background(), registry.ContractAddress, 41, 0, 0)
 	require.NoError(t, err)
-	list3, err := orm.EligibleUpkeepsForRegistry(context.Background(), registry.ContractAddress, 62, 0)
+	list3, err := orm.EligibleUpkeepsForRegistry(context.Background(), registry.ContractAddress, 62, 0, 0)
 	require.NoError(t, err)
-	list4, err := orm.EligibleUpkeepsForRegistry(context.Background(), registry.ContractAddress, 83, 0)
+	list4, err := orm.EligibleUpkeepsForRegistry(context.Background(), registry.ContractAddress, 83, 0, 0)
 	require.NoError(t, err)
-	list5, err := orm.EligibleUpkeepsForRegistry(context.Background(), registry.ContractAddress, 104, 0)
+	list5, err := orm.EligibleUpkeepsForRegistry(context.Background(), registry.ContractAddress, 104, 0, 0)
 	require.NoError(t, err)
 
 	totalEligible := len(list1) + len(list2) + len(list3) + len(list4) + len(list5)
@@ -249,15 +301,15 @@ func TestKeeperDB_EligibleUpkeeps_KeepersCycleAllUpkeeps(t *testing.T) {
 	cltest.AssertCount(t, db, &keeper.UpkeepRegistration{}, 1000)
 
 	// in a full cycle, each node should be responsible for each upkeep exactly once
-	list1, err := orm.EligibleUpkeepsForRegistry(context.Background(), registry.ContractAddress, 20, 0) // someone eligible
+	list1, err := orm.EligibleUpkeepsForRegistry(context.Background(), registry.ContractAddress, 20, 0, 0) // someone eligible
 	require.NoError(t, err)
-	list2, err := orm.EligibleUpkeepsForRegistry(context.Background(), registry.ContractAddress, 40, 0) // someone eligible
+	list2, err := orm.EligibleUpkeepsForRegistry(context.Background(), registry.ContractAddress, 40, 0, 0) // someone eligible
 	require.NoError(t, err)
-	list3, err := orm.EligibleUpkeepsForRegistry(context.Background(), registry.ContractAddress, 60, 0) // someone eligible
+	list3, err := orm.EligibleUpkeepsForRegistry(context.Background(), registry.ContractAddress, 60, 0, 0) // someone eligible
 	require.NoError(t, err)
-	list4, err := orm.EligibleUpkeepsForRegistry(context.Background(), registry.ContractAddress, 80, 0) // someone eligible
+	list4, err := orm.EligibleUpkeepsForRegistry(context.Background(), registry.ContractAddress, 80, 0, 0) // someone eligible
 	require.NoError(t, err)
-	list5, err := orm.EligibleUpkeepsForRegistry(context.Background(), registry.ContractAddress, 100, 0) // someone eligible
+	list5, err := orm.EligibleUpkeepsForRegistry(context.Background(), registry.ContractAddress, 100, 0, 0) // someone eligible
 	require.NoError(t, err)
 
 	totalEligible := len(list1) + len(list2) + len(list3) + len(list4) + len(list5)
@@ -278,15 +330,36 @@ func TestKeeperDB_EligibleUpkeeps_FiltersByRegistry(t *testing.T) {
 	cltest.AssertCount(t, db, keeper.Registry{}, 2)
 	cltest.AssertCount(t, db, &keeper.UpkeepRegistration{}, 2)
 
-	list1, err := orm.EligibleUpkeepsForRegistry(context.Background(), registry1.ContractAddress, 20, 0)
+	list1, err := orm.EligibleUpkeepsForRegistry(context.Background(), registry1.ContractAddress, 20, 0, 0)
 	require.NoError(t, err)
-	list2, err := orm.EligibleUpkeepsForRegistry(context.Background(), registry2.ContractAddress, 20, 0)
+	list2, err := orm.EligibleUpkeepsForRegistry(context.Background(), registry2.ContractAddress, 20, 0, 0)
 	require.NoError(t, err)
 
 	assert.Equal(t, 1, len(list1))
 	assert.Equal(t, 1, len(list2))
 }
 
+func TestKeeperDB_UpkeepForJob(t *testing.T) {
+	t.Parallel()
+	db, config, orm := setupKeeperDB(t)
+	ethKeyStore := cltest.NewKeyStore(t, db).Eth()
+
+	registry, j := cltest.MustInsertKeeperRegistry(t, db, ethKeyStore)
+	upkeep := cltest.MustInsertUpkeepForRegistry(t, db, config, registry)
+	otherRegistry, _ := cltest.MustInsertKeeperRegistry(t, db, ethKeyStore)
+
+	found, err := orm.UpkeepForJob(context.Background(), j.ID, registry.ContractAddress, upkeep.UpkeepID)
+	require.NoError(t, err)
+	assert.Equal(t, upkeep.ID, found.ID)
+	// preloads registry data
+	assert.Equal(t, registry.ContractAddress, found.Registry.ContractAddress)
+
+	_, err = orm.UpkeepForJob(context.Background(), j.ID, otherRegistry.ContractAddress, upkeep.UpkeepID)
+	require.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+	_, err = orm.UpkeepForJob(context.Background(), j.ID, registry.ContractAddress, upkeep.UpkeepID+1)
+	require.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+}
+
 func TestKeeperDB_NextUpkeepID(t *testing.T) {
 	t.Parallel()
 	db, _, orm := setupKeeperDB(t)
@@ -323,8 +396,196 @@ func TestKeeperDB_SetLastRunHeightForUpkeepOnJob(t *testing.T) {
 	registry, j := cltest.MustInsertKeeperRegistry(t, db, ethKeyStore)
 	upkeep := cltest.MustInsertUpkeepForRegistry(t, db, config, registry)
 
-	orm.SetLastRunHeightForUpkeepOnJob(context.Background(), j.ID, upkeep.UpkeepID, 100)
+	orm.SetLastRunHeightForUpkeepOnJob(context.Background(), j.ID, registry.ContractAddress, upkeep.UpkeepID, 100)
 	assertLastRunHeight(t, db, upkeep, 100)
-	orm.SetLastRunHeightForUpkeepOnJob(context.Background(), j.ID, upkeep.UpkeepID, 0)
+	orm.SetLastRunHeightForUpkeepOnJob(context.Background(), j.ID, registry.ContractAddress, upkeep.UpkeepID, 0)
 	assertLastRunHeight(t, db, upkeep, 0)
+
+	t.Run("only updates the upkeep of the given registry", func(t *testing.T) {
+		otherRegistry := registry
+		otherRegistry.ID = 0
+		otherRegistry.ContractAddress = cltest.NewEIP55Address()
+		require.NoError(t, db.Create(&otherRegistry).Error)
+		otherUpkeep := cltest.MustInsertUpkeepForRegistry(t, db, config, otherRegistry)
+		require.Equal(t, upkeep.UpkeepID, otherUpkeep.UpkeepID)
+
+		require.NoError(t, orm.SetLastRunHeightForUpkeepOnJob(context.Background(), j.ID, otherRegistry.ContractAddress, otherUpkeep.UpkeepID, 100))
+		assertLastRunHeight(t, db, otherUpkeep, 100)
+		assertLastRunHeight(t, db, upkeep, 0)
+	})
+}
+
+func TestKeeperDB_ExecutionStats(t *testing.T) {
+	t.Parallel()
+	db, config, orm := setupKeeperDB(t)
+	ethKeyStore := cltest.NewKeyStore(t, db).Eth()
+	ctx := context.Background()
+
+	registry, j := cltest.MustInsertKeeperRegistry(t, db, ethKeyStore)
+	succeeding := cltest.MustInsertUpkeepForRegistry(t, db, config, registry)
+	failing := cltest.MustInsertUpkeepForRegistry(t, db, config, registry)
+	otherRegistry, _ := cltest.MustInsertKeeperRegistry(t, db, ethKeyStore)
+	otherJobs := cltest.MustInsertUpkeepForRegistry(t, db, config, otherRegistry)
+
+	// counts of several heads add up
+	require.NoError(t, orm.AddExecutionCounts(ctx, map[int32]keeper.ExecutionCounts{
+		succeeding.ID: {Checks: 2, Performs: 1, Skips: 1},
+		failing.ID:    {Checks: 2, Skips: 1},
+		otherJobs.ID:  {Checks: 1, Performs: 1},
+	}))
+	require.NoError(t, orm.AddExecutionCounts(ctx, map[int32]keeper.ExecutionCounts{
+		succeeding.ID: {Checks: 1, Performs: 1},
+		failing.ID:    {Checks: 1},
+	}))
+	require.NoError(t, orm.AddExecutionCounts(ctx, nil))
+	require.NoError(t, orm.SetLastRunHeightForUpkeepOnJob(ctx, j.ID, registry.ContractAddress, succeeding.UpkeepID, 101))
+	require.NoError(t, orm.SetLastErrorForUpkeepOnJob(ctx, j.ID, registry.ContractAddress, failing.UpkeepID, 100, errors.New("first failure")))
+	require.NoError(t, orm.SetLastErrorForUpkeepOnJob(ctx, j.ID, registry.ContractAddress, failing.UpkeepID, 102, errors.New("second failure")))
+
+	stats, err := orm.ExecutionStats(ctx, j.ID)
+	require.NoError(t, err)
+	require.Equal(t, []keeper.ExecutionStats{
+		{
+			RegistryAddress:    registry.ContractAddress,
+			UpkeepID:           succeeding.UpkeepID,
+			ExecutionCounts:    keeper.ExecutionCounts{Checks: 3, Performs: 2, Skips: 1},
+			LastRunBlockHeight: 101,
+		},
+		{
+			RegistryAddress:      registry.ContractAddress,
+			UpkeepID:             failing.UpkeepID,
+			ExecutionCounts:      keeper.ExecutionCounts{Checks: 3, Skips: 1},
+			LastError:            null.StringFrom("second failure"),
+			LastErrorBlockHeight: null.IntFrom(102),
+			FailureCount:         2,
+		},
+	}, stats)
+}
+
+func TestKeeperDB_SetLastErrorForUpkeepOnJob(t *testing.T) {
+	t.Parallel()
+	db, config, orm := setupKeeperDB(t)
+	ethKeyStore := cltest.NewKeyStore(t, db).Eth()
+
+	registry, j := cltest.MustInsertKeeperRegistry(t, db, ethKeyStore)
+	upkeep := cltest.MustInsertUpkeepForRegistry(t, db, config, registry)
+
+	loadUpkeep := func() keeper.UpkeepRegistration {
+		var reload keeper.UpkeepRegistration
+		require.NoError(t, db.First(&reload, upkeep.ID).Error)
+		return reload
+	}
+
+	require.NoError(t, orm.SetLastErrorForUpkeepOnJob(context.Background(), j.ID, registry.ContractAddress, upkeep.UpkeepID, 100, errors.New("first failure")))
+	require.NoError(t, orm.SetLastErrorForUpkeepOnJob(context.Background(), j.ID, registry.ContractAddress, upkeep.UpkeepID, 101, errors.New("second failure")))
+	reload := loadUpkeep()
+	assert.Equal(t, int32(2), reload.FailureCount)
+	assert.Equal(t, null.StringFrom("second failure"), reload.LastError)
+	assert.Equal(t, null.IntFrom(101), reload.LastErrorBlockHeight)
+
+	require.NoError(t, orm.SetLastRunHeightForUpkeepOnJob(context.Background(), j.ID, registry.ContractAddress, upkeep.UpkeepID, 102))
+	reload = loadUpkeep()
+	assert.Equal(t, int32(0), reload.FailureCount)
+	// the last error is kept for operators after the upkeep recovers
+	assert.Equal(t, null.StringFrom("second failure"), reload.LastError)
+
+	require.NoError(t, orm.SetLastErrorForUpkeepOnJob(context.Background(), j.ID, registry.ContractAddress, upkeep.UpkeepID, 103, errors.New("third failure")))
+	assert.Equal(t, int32(1), loadUpkeep().FailureCount)
+}
+
+func TestKeeperDB_ConfigSnapshot(t *testing.T) {
+	t.Parallel()
+	db, _, orm := setupKeeperDB(t)
+	ethKeyStore := cltest.NewKeyStore(t, db).Eth()
+
+	_, j := cltest.MustInsertKeeperRegistry(t, db, ethKeyStore)
+
+	snapshot, err := orm.ConfigSnapshotForJob(context.Background(), j.ID)
+	require.NoError(t, err)
+	require.Nil(t, snapshot)
+
+	values := keeper.ConfigValues{"KeeperGasPriceBufferPercent": "20"}
+	require.NoError(t, orm.UpsertConfigSnapshot(context.Background(), &keeper.ConfigSnapshot{JobID: j.ID, Snapshot: values}))
+	values["KeeperGasPriceBufferPercent"] = "30"
+	require.NoError(t, orm.UpsertConfigSnapshot(context.Background(), &keeper.ConfigSnapshot{JobID: j.ID, Snapshot: values}))
+
+	snapshot, err = orm.ConfigSnapshotForJob(context.Background(), j.ID)
+	require.NoError(t, err)
+	require.Equal(t, keeper.ConfigValues{"KeeperGasPriceBufferPercent": "30"}, snapshot)
+}
+
+func TestKeeperDB_IndexedBlockNumberForJob(t *testing.T) {
+	t.Parallel()
+	db, _, orm := setupKeeperDB(t)
+	ethKeyStore := cltest.NewKeyStore(t, db).Eth()
+
+	_, j := cltest.MustInsertKeeperRegistry(t, db, ethKeyStore)
+
+	_, exists, err := orm.IndexedBlockNumberForJob(context.Background(), j.ID)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	logORM := log.NewORM(db, cltest.FixtureChainID)
+	require.NoError(t, logORM.MarkBroadcastConsumed(db, utils.NewHash(), 42, 0, j.ID))
+	require.NoError(t, logORM.MarkBroadcastConsumed(db, utils.NewHash(), 40, 1, j.ID))
+
+	indexed, exists, err := orm.IndexedBlockNumberForJob(context.Background(), j.ID)
+	require.NoError(t, err)
+	require.True(t, exists)
+	require.Equal(t, int64(42), indexed)
+}
+
+func TestKeeperDB_SendingKeys(t *testing.T) {
+	t.Parallel()
+	db, _, orm := setupKeeperDB(t)
+	ethKeyStore := cltest.NewKeyStore(t, db).Eth()
+
+	_, first := cltest.MustInsertRandomKey(t, ethKeyStore)
+	_, second := cltest.MustInsertRandomKey(t, ethKeyStore)
+	// funding keys aren't sent from
+	cltest.MustInsertRandomKey(t, ethKeyStore, true)
+
+	keys, err := orm.SendingKeys(context.Background())
+	require.NoError(t, err)
+	require.ElementsMatch(t, []common.Address{first, second}, keys)
+}
+
+func TestKeeperDB_PendingPerforms(t *testing.T) {
+	t.Parallel()
+	db, _, orm := setupKeeperDB(t)
+	ethKeyStore := cltest.NewKeyStore(t, db).Eth()
+
+	registry, _ := cltest.MustInsertKeeperRegistry(t, db, ethKeyStore)
+	fromAddress, registryAddress := registry.FromAddress.Address(), registry.ContractAddress.Address()
+	// the ethtx task may send a perform from any sending key
+	_, otherAddress := cltest.MustInsertRandomKey(t, ethKeyStore)
+	_, unusedAddress := cltest.MustInsertRandomKey(t, ethKeyStore)
+
+	insertPerform := func(upkeepID int64, from common.Address, state bulletprooftxmanager.EthTxState) {
+		payload, err := keeper.RegistryABI.Pack("performUpkeep", big.NewInt(upkeepID), common.Hex2Bytes("1234"))
+		require.NoError(t, err)
+		etx := cltest.NewEthTx(t, from)
+		etx.ToAddress = registryAddress
+		etx.EncodedPayload = payload
+		etx.State = state
+		if state == bulletprooftxmanager.EthTxFatalError {
+			etx.Error = null.StringFrom("something exploded")
+		}
+		require.NoError(t, db.Save(&etx).Error)
+	}
+	insertPerform(3, fromAddress, bulletprooftxmanager.EthTxUnstarted)
+	insertPerform(4, fromAddress, bulletprooftxmanager.EthTxFatalError)
+	insertPerform(5, otherAddress, bulletprooftxmanager.EthTxUnstarted)
+	cltest.MustInsertUnstartedEthTx(t, db, fromAddress)
+
+	performs, err := orm.PendingPerforms(context.Background(), []common.Address{fromAddress, otherAddress}, registryAddress)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []keeper.PendingPerform{
+		{UpkeepID: 3, FromAddress: fromAddress},
+		{UpkeepID: 5, FromAddress: otherAddress},
+	}, performs)
+
+	performs, err = orm.PendingPerforms(context.Background(), []common.Address{unusedAddress}, registryAddress)
+	require.NoError(t, err)
+	require.Empty(t, performs)
 }
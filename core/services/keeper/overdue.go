@@ -0,0 +1,51 @@
+package keeper
+
+import (
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+// observeOverdue tracks the consecutive heads on which an upkeep was eligible without this node performing it.
+// Reverted checks and errored runs mean the upkeep didn't need performing, so like performs they end the streak.
+// When the streak reaches KeeperOverdueWarnHeads a warning is logged, telling an upkeep that keeps getting
+// skipped apart from one that is only eligible while another keeper holds the turn.
+func (ex *UpkeepExecuter) observeOverdue(upkeep UpkeepRegistration, head eth.Head, outcome executionOutcome, decision upkeepDecision) {
+	threshold := ex.config.KeeperOverdueWarnHeads()
+	if threshold == 0 {
+		return
+	}
+	if outcome == executionPerformed || decision.reason == decisionCheckReverted || decision.reason == decisionRunErrored {
+		ex.resetOverdue(upkeep)
+		return
+	}
+
+	ex.overdueMu.Lock()
+	var heads uint32
+	if value, ok := ex.overdueHeads.get(upkeep.key()); ok {
+		heads = value.(uint32)
+	}
+	heads++
+	ex.overdueHeads.set(upkeep.key(), heads)
+	ex.overdueMu.Unlock()
+
+	if heads != threshold {
+		return
+	}
+	ex.logger.Warnw("upkeep has been eligible for many heads without being performed by this node",
+		"upkeepID", upkeep.UpkeepID, "registryAddress", upkeep.Registry.ContractAddress.Hex(),
+		"blockNum", head.Number, "heads", heads, "lastReason", decision.reason)
+	ex.metrics.IncOverdueUpkeeps(upkeep.Registry.ContractAddress.Hex())
+	ex.events.Emit(Event{
+		Type:            EventOverdue,
+		RegistryAddress: upkeep.Registry.ContractAddress.Address(),
+		UpkeepID:        upkeep.UpkeepID,
+		BlockNumber:     head.Number,
+		Data:            map[string]interface{}{"heads": heads, "lastReason": decision.reason},
+	})
+}
+
+// resetOverdue ends the streak of eligible heads of upkeep, when it is performed or no longer eligible
+func (ex *UpkeepExecuter) resetOverdue(upkeep UpkeepRegistration) {
+	ex.overdueMu.Lock()
+	defer ex.overdueMu.Unlock()
+	ex.overdueHeads.delete(upkeep.key())
+}
@@ -0,0 +1,64 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/configtest"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+func TestObserveOverdue(t *testing.T) {
+	t.Parallel()
+
+	newExecuter := func(t *testing.T, warnHeads int64) (*UpkeepExecuter, *recordingMetrics) {
+		recorder := &recordingMetrics{}
+		return newTestExecuter(t, testExecuterDeps{metrics: recorder}, func(c *configtest.TestGeneralConfig) {
+			c.Overrides.KeeperOverdueWarnHeads = null.IntFrom(warnHeads)
+		}), recorder
+	}
+	upkeep := UpkeepRegistration{UpkeepID: 1}
+	skipped := upkeepDecision{reason: decisionGasPriceAboveMaximum}
+
+	t.Run("warns once the streak reaches the threshold", func(t *testing.T) {
+		ex, recorder := newExecuter(t, 3)
+		for i := int64(0); i < 5; i++ {
+			ex.observeOverdue(upkeep, eth.Head{Number: 20 + i}, executionSkipped, skipped)
+		}
+		require.Equal(t, 1, recorder.overdueUpkeeps)
+		heads, ok := ex.overdueHeads.get(upkeep.key())
+		require.True(t, ok)
+		require.Equal(t, uint32(5), heads)
+	})
+
+	t.Run("performs and reverted checks end the streak", func(t *testing.T) {
+		ex, recorder := newExecuter(t, 3)
+		ex.observeOverdue(upkeep, eth.Head{Number: 20}, executionSkipped, skipped)
+		ex.observeOverdue(upkeep, eth.Head{Number: 21}, executionSkipped, skipped)
+		ex.observeOverdue(upkeep, eth.Head{Number: 22}, executionPerformed, upkeepDecision{reason: decisionPerformed})
+		ex.observeOverdue(upkeep, eth.Head{Number: 23}, executionSkipped, skipped)
+		ex.observeOverdue(upkeep, eth.Head{Number: 24}, executionSkipped, skipped)
+		ex.observeOverdue(upkeep, eth.Head{Number: 25}, executionSkipped, upkeepDecision{reason: decisionCheckReverted})
+		ex.observeOverdue(upkeep, eth.Head{Number: 26}, executionSkipped, skipped)
+		require.Zero(t, recorder.overdueUpkeeps)
+	})
+
+	t.Run("leaving the eligible set ends the streak", func(t *testing.T) {
+		ex, recorder := newExecuter(t, 2)
+		ex.observeOverdue(upkeep, eth.Head{Number: 20}, executionSkipped, skipped)
+		ex.resetOverdue(upkeep)
+		ex.observeOverdue(upkeep, eth.Head{Number: 40}, executionSkipped, skipped)
+		require.Zero(t, recorder.overdueUpkeeps)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		ex, recorder := newExecuter(t, 0)
+		for i := int64(0); i < 5; i++ {
+			ex.observeOverdue(upkeep, eth.Head{Number: 20 + i}, executionSkipped, skipped)
+		}
+		require.Zero(t, recorder.overdueUpkeeps)
+		require.Zero(t, ex.overdueHeads.len())
+	})
+}
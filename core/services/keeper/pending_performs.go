@@ -0,0 +1,57 @@
+package keeper
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/services/postgres"
+)
+
+// excludePendingPerforms drops the upkeeps this node has an unconfirmed perform for, since checking
+// and performing them again would only waste gas. The performs are sent from any of the sending keys,
+// which the ethtx task of the keeper pipeline picks from. The pending performs are loaded once per head.
+func (ex *UpkeepExecuter) excludePendingPerforms(head eth.Head, upkeeps []UpkeepRegistration) []UpkeepRegistration {
+	if len(upkeeps) == 0 {
+		return upkeeps
+	}
+
+	ctx, cancel := postgres.DefaultQueryCtx()
+	defer cancel()
+
+	fromAddresses, err := ex.orm.SendingKeys(ctx)
+	if err != nil {
+		ex.logger.Warnw("unable to load pending performs, not excluding any upkeeps", "blockheight", head.Number, "error", err)
+		return upkeeps
+	}
+	// the key each pending perform was sent from, by upkeep
+	pending := make(map[upkeepKey]common.Address)
+	for _, registryAddress := range ex.job.KeeperSpec.RegistryAddresses() {
+		performs, err := ex.orm.PendingPerforms(ctx, fromAddresses, registryAddress.Address())
+		if err != nil {
+			ex.logger.Warnw("unable to load pending performs, not excluding any upkeeps", "blockheight", head.Number, "error", err)
+			return upkeeps
+		}
+		for _, perform := range performs {
+			pending[upkeepKey{registry: registryAddress.Address(), upkeepID: perform.UpkeepID}] = perform.FromAddress
+		}
+	}
+	if len(pending) == 0 {
+		return upkeeps
+	}
+
+	var excluded []int64
+	var excludedFrom []string
+	remaining := upkeeps[:0]
+	for _, upkeep := range upkeeps {
+		if fromAddress, exists := pending[upkeep.key()]; exists {
+			excluded = append(excluded, upkeep.UpkeepID)
+			excludedFrom = append(excludedFrom, fromAddress.Hex())
+			continue
+		}
+		remaining = append(remaining, upkeep)
+	}
+	if len(excluded) > 0 {
+		ex.logger.Debugw("excluding upkeeps with a pending perform", "blockheight", head.Number, "upkeepIDs", excluded, "fromAddresses", excludedFrom)
+	}
+	return remaining
+}
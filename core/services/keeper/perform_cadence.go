@@ -0,0 +1,35 @@
+package keeper
+
+import (
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+// cadenceWindow returns the KeeperPerformCadenceBlocks window of the head for the upkeep.
+// Windows are offset by the upkeep ID so that upkeeps don't all perform on the same blocks.
+func cadenceWindow(upkeepID int64, head eth.Head, cadence uint32) int64 {
+	return (head.Number + upkeepID%int64(cadence)) / int64(cadence)
+}
+
+// performScheduled returns false if the upkeep was already performed in the current
+// KeeperPerformCadenceBlocks window
+func (ex *UpkeepExecuter) performScheduled(upkeep UpkeepRegistration, head eth.Head) bool {
+	cadence := ex.config.KeeperPerformCadenceBlocks()
+	if cadence == 0 {
+		return true
+	}
+	ex.cadenceMu.Lock()
+	defer ex.cadenceMu.Unlock()
+	performedWindow, performed := ex.performedWindows.get(upkeep.key())
+	return !performed || cadenceWindow(upkeep.UpkeepID, head, cadence) > performedWindow.(int64)
+}
+
+// setPerformedWindow records that the upkeep was performed in the window of head
+func (ex *UpkeepExecuter) setPerformedWindow(upkeep UpkeepRegistration, head eth.Head) {
+	cadence := ex.config.KeeperPerformCadenceBlocks()
+	if cadence == 0 {
+		return
+	}
+	ex.cadenceMu.Lock()
+	defer ex.cadenceMu.Unlock()
+	ex.performedWindows.set(upkeep.key(), cadenceWindow(upkeep.UpkeepID, head, cadence))
+}
@@ -0,0 +1,26 @@
+package keeper
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+func TestCadenceWindow(t *testing.T) {
+	t.Parallel()
+
+	head := func(n int64) eth.Head {
+		return eth.NewHead(big.NewInt(n), utils.NewHash(), utils.NewHash(), 0, utils.NewBigI(0))
+	}
+
+	require.Equal(t, int64(0), cadenceWindow(0, head(9), 10))
+	require.Equal(t, int64(1), cadenceWindow(0, head(10), 10))
+	// upkeep 3 moves to the next window 3 blocks earlier
+	require.Equal(t, int64(0), cadenceWindow(3, head(6), 10))
+	require.Equal(t, int64(1), cadenceWindow(3, head(7), 10))
+	require.Equal(t, int64(1), cadenceWindow(13, head(7), 10))
+}
@@ -0,0 +1,36 @@
+package keeper
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// PerformedUpkeep describes an upkeep performed by the executer. The ethtx task of the pipeline only
+// hands the perform transaction to the tx manager, so it's not broadcast yet and has no hash when the
+// callback is called, the pipeline run identifies it instead.
+type PerformedUpkeep struct {
+	RegistryAddress common.Address
+	UpkeepID        int64
+	BlockNumber     int64
+	GasPrice        *big.Int
+	PipelineRunID   int64
+}
+
+// PerformCallback is called once for every pipeline run of the executer that completed, after the last
+// run height of the upkeep was set. It's called from the execution goroutines and must not block.
+type PerformCallback func(PerformedUpkeep)
+
+// notifyPerformed calls the PerformCallback of the executer, if it has one
+func (ex *UpkeepExecuter) notifyPerformed(upkeep UpkeepRegistration, headNumber int64, gasPrice *big.Int, runID int64) {
+	if ex.onPerform == nil {
+		return
+	}
+	ex.onPerform(PerformedUpkeep{
+		RegistryAddress: upkeep.Registry.ContractAddress.Address(),
+		UpkeepID:        upkeep.UpkeepID,
+		BlockNumber:     headNumber,
+		GasPrice:        new(big.Int).Set(gasPrice),
+		PipelineRunID:   runID,
+	})
+}
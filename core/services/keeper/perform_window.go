@@ -0,0 +1,70 @@
+package keeper
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// performWindow is a daily UTC time range, given as "HH:MM-HH:MM", during which an upkeep may be performed.
+// Windows whose end is before their start wrap around midnight.
+type performWindow struct {
+	start, end time.Duration
+}
+
+func parsePerformWindow(s string) (performWindow, error) {
+	parts := strings.Split(strings.TrimSpace(s), "-")
+	if len(parts) != 2 {
+		return performWindow{}, errors.Errorf("invalid perform window %q, expected HH:MM-HH:MM", s)
+	}
+	start, err := parseTimeOfDay(parts[0])
+	if err != nil {
+		return performWindow{}, errors.Wrapf(err, "invalid perform window %q", s)
+	}
+	end, err := parseTimeOfDay(parts[1])
+	if err != nil {
+		return performWindow{}, errors.Wrapf(err, "invalid perform window %q", s)
+	}
+	if start == end {
+		return performWindow{}, errors.Errorf("invalid perform window %q, start and end must differ", s)
+	}
+	return performWindow{start: start, end: end}, nil
+}
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func (w performWindow) contains(t time.Time) bool {
+	t = t.UTC()
+	offset := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second
+	if w.start < w.end {
+		return offset >= w.start && offset < w.end
+	}
+	return offset >= w.start || offset < w.end
+}
+
+func (w performWindow) String() string {
+	return fmt.Sprintf("%02d:%02d-%02d:%02d",
+		int(w.start.Hours()), int(w.start.Minutes())%60, int(w.end.Hours()), int(w.end.Minutes())%60)
+}
+
+// parsePerformWindows parses all windows, returning nil if none are configured
+func parsePerformWindows(windows []string) ([]performWindow, error) {
+	var parsed []performWindow
+	for _, s := range windows {
+		w, err := parsePerformWindow(s)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, w)
+	}
+	return parsed, nil
+}
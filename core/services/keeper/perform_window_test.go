@@ -0,0 +1,48 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPerformWindow(t *testing.T) {
+	t.Parallel()
+
+	at := func(hour, minute int) time.Time {
+		return time.Date(2021, 9, 1, hour, minute, 0, 0, time.UTC)
+	}
+
+	t.Run("daytime window", func(t *testing.T) {
+		w, err := parsePerformWindow("09:00-17:30")
+		require.NoError(t, err)
+		assert.Equal(t, "09:00-17:30", w.String())
+		assert.False(t, w.contains(at(8, 59)))
+		assert.True(t, w.contains(at(9, 0)))
+		assert.True(t, w.contains(at(17, 29)))
+		assert.False(t, w.contains(at(17, 30)))
+	})
+
+	t.Run("window wrapping midnight", func(t *testing.T) {
+		w, err := parsePerformWindow("22:00-02:00")
+		require.NoError(t, err)
+		assert.True(t, w.contains(at(23, 0)))
+		assert.True(t, w.contains(at(1, 59)))
+		assert.False(t, w.contains(at(12, 0)))
+	})
+
+	t.Run("converts to UTC", func(t *testing.T) {
+		w, err := parsePerformWindow("09:00-10:00")
+		require.NoError(t, err)
+		assert.True(t, w.contains(at(9, 30).In(time.FixedZone("UTC+5", 5*60*60))))
+	})
+
+	t.Run("invalid windows", func(t *testing.T) {
+		for _, s := range []string{"", "09:00", "9am-5pm", "09:00-25:00", "09:00-09:00"} {
+			_, err := parsePerformWindow(s)
+			assert.Error(t, err, s)
+		}
+	})
+}
@@ -0,0 +1,27 @@
+package keeper
+
+import (
+	"context"
+	"runtime/debug"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+)
+
+// runPipeline runs the pipeline of upkeep. If KeeperRecoverPipelinePanics is set, a panic of the run is
+// recovered and returned as an error, so that a bad pipeline fails its run instead of taking down the node.
+func (ex *UpkeepExecuter) runPipeline(ctx context.Context, run *pipeline.Run, upkeep UpkeepRegistration, lggr logger.Logger) (err error) {
+	if ex.config.KeeperRecoverPipelinePanics() {
+		defer func() {
+			if r := recover(); r != nil {
+				lggr.Errorw("pipeline run of upkeep panicked", "panic", r, "stacktrace", string(debug.Stack()))
+				ex.metrics.IncPipelinePanics(upkeep.Registry.ContractAddress.Hex())
+				err = errors.Errorf("pipeline run panicked: %v", r)
+			}
+		}()
+	}
+	_, err = ex.pr.Run(ctx, run, ex.logger, true, nil)
+	return err
+}
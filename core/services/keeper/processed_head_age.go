@@ -0,0 +1,26 @@
+package keeper
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// setLastProcessed records that the executer processed a head now
+func (ex *UpkeepExecuter) setLastProcessed() {
+	ex.lastProcessedAt.Store(ex.clock.Now().UnixNano())
+}
+
+// processedHeadAgeHealthy returns an error if the executer hasn't processed a head for more than
+// KeeperMaxProcessedHeadAge, which usually means it stopped receiving heads
+func (ex *UpkeepExecuter) processedHeadAgeHealthy() error {
+	maxAge := ex.config.KeeperMaxProcessedHeadAge()
+	if maxAge <= 0 {
+		return nil
+	}
+	age := ex.clock.Now().Sub(time.Unix(0, ex.lastProcessedAt.Load()))
+	if age > maxAge {
+		return errors.Errorf("no head processed for %s, more than KeeperMaxProcessedHeadAge of %s", age.Round(time.Millisecond), maxAge)
+	}
+	return nil
+}
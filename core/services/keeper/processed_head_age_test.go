@@ -0,0 +1,59 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/configtest"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func TestUpkeepExecuter_Healthy_ProcessedHeadAge(t *testing.T) {
+	t.Parallel()
+
+	newExecuter := func(t *testing.T, maxAge time.Duration) (*UpkeepExecuter, *fakeClock) {
+		clock := &fakeClock{now: time.Unix(1_000_000, 0)}
+		ex := newTestExecuter(t, testExecuterDeps{}, func(c *configtest.TestGeneralConfig) {
+			c.Overrides.KeeperMaxProcessedHeadAge = &maxAge
+		})
+		ex.clock = clock
+		require.NoError(t, ex.StartOnce("UpkeepExecuter", func() error {
+			ex.setLastProcessed()
+			return nil
+		}))
+		return ex, clock
+	}
+
+	t.Run("unhealthy once no head was processed for longer than the threshold", func(t *testing.T) {
+		ex, clock := newExecuter(t, time.Minute)
+		require.NoError(t, ex.Healthy())
+
+		clock.advance(time.Minute)
+		require.NoError(t, ex.Healthy())
+
+		clock.advance(time.Second)
+		require.Error(t, ex.Healthy())
+
+		ex.setLastProcessed()
+		require.NoError(t, ex.Healthy())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		ex, clock := newExecuter(t, 0)
+		clock.advance(24 * time.Hour)
+		require.NoError(t, ex.Healthy())
+	})
+}
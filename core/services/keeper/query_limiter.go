@@ -0,0 +1,47 @@
+package keeper
+
+import (
+	"sync"
+	"time"
+)
+
+// QueryLimiter spaces out database queries so that they don't exceed an aggregate rate.
+// A single QueryLimiter is shared by every executer of a node so that executers receiving
+// the same head don't all query the database at once.
+type QueryLimiter struct {
+	mu   sync.Mutex
+	next time.Time
+}
+
+// NewQueryLimiter is the constructor of QueryLimiter
+func NewQueryLimiter() *QueryLimiter {
+	return &QueryLimiter{}
+}
+
+// wait blocks until the next free slot at rps queries per second. It returns false
+// if chStop is closed first. A nil limiter or a zero rate never blocks.
+func (l *QueryLimiter) wait(rps uint32, chStop <-chan struct{}) bool {
+	if l == nil || rps == 0 {
+		return true
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	slot := l.next
+	l.next = slot.Add(time.Second / time.Duration(rps))
+	l.mu.Unlock()
+
+	delay := time.Until(slot)
+	if delay <= 0 {
+		return true
+	}
+	select {
+	case <-time.After(delay):
+		return true
+	case <-chStop:
+		return false
+	}
+}
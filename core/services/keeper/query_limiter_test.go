@@ -0,0 +1,38 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryLimiter_Wait(t *testing.T) {
+	t.Parallel()
+
+	t.Run("zero rate never blocks", func(t *testing.T) {
+		l := NewQueryLimiter()
+		start := time.Now()
+		for i := 0; i < 100; i++ {
+			require.True(t, l.wait(0, nil))
+		}
+		require.Less(t, time.Since(start), 100*time.Millisecond)
+	})
+
+	t.Run("spaces out queries at the rate", func(t *testing.T) {
+		l := NewQueryLimiter()
+		start := time.Now()
+		for i := 0; i < 5; i++ {
+			require.True(t, l.wait(50, nil))
+		}
+		require.GreaterOrEqual(t, time.Since(start), 80*time.Millisecond)
+	})
+
+	t.Run("returns false when stopped", func(t *testing.T) {
+		l := NewQueryLimiter()
+		chStop := make(chan struct{})
+		close(chStop)
+		require.True(t, l.wait(1, chStop))
+		require.False(t, l.wait(1, chStop))
+	})
+}
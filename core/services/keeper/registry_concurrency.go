@@ -0,0 +1,75 @@
+package keeper
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// registryConcurrency is the number of registries of the job processed concurrently,
+// 1 if they are processed one after another
+func (ex *UpkeepExecuter) registryConcurrency() int {
+	if concurrency := int(ex.config.KeeperRegistryConcurrency()); concurrency > 1 {
+		return concurrency
+	}
+	return 1
+}
+
+// forEachRegistry calls fn with the index of each of n registries, running up to
+// KeeperRegistryConcurrency calls at once. It returns once every call has returned.
+func (ex *UpkeepExecuter) forEachRegistry(n int, fn func(i int)) {
+	concurrency := ex.registryConcurrency()
+	if concurrency == 1 {
+		for i := 0; i < n; i++ {
+			fn(i)
+		}
+		return
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// processRegistries calls process with the upkeeps of every registry together, or with the upkeeps of
+// each registry separately if KeeperRegistryConcurrency allows processing several registries at once.
+// Registries processed concurrently still share the execution queue and the simulation limit.
+func (ex *UpkeepExecuter) processRegistries(upkeeps []UpkeepRegistration, process func([]UpkeepRegistration)) {
+	if ex.registryConcurrency() == 1 {
+		process(upkeeps)
+		return
+	}
+	byRegistry := groupByRegistry(upkeeps)
+	ex.forEachRegistry(len(byRegistry), func(i int) {
+		process(byRegistry[i])
+	})
+}
+
+// groupByRegistry splits upkeeps by registry, in the order each registry first appears,
+// keeping the order of the upkeeps of a registry
+func groupByRegistry(upkeeps []UpkeepRegistration) [][]UpkeepRegistration {
+	indexes := make(map[common.Address]int)
+	var byRegistry [][]UpkeepRegistration
+	for _, upkeep := range upkeeps {
+		registry := upkeep.Registry.ContractAddress.Address()
+		i, exists := indexes[registry]
+		if !exists {
+			i = len(byRegistry)
+			indexes[registry] = i
+			byRegistry = append(byRegistry, nil)
+		}
+		byRegistry[i] = append(byRegistry[i], upkeep)
+	}
+	return byRegistry
+}
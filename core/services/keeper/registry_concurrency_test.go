@@ -0,0 +1,109 @@
+package keeper
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/configtest"
+	"github.com/smartcontractkit/chainlink/core/services/keystore/keys/ethkey"
+)
+
+func TestGroupByRegistry(t *testing.T) {
+	t.Parallel()
+
+	registryA := Registry{ContractAddress: ethkey.EIP55AddressFromAddress(common.HexToAddress("0x1"))}
+	registryB := Registry{ContractAddress: ethkey.EIP55AddressFromAddress(common.HexToAddress("0x2"))}
+	upkeeps := []UpkeepRegistration{
+		{UpkeepID: 1, Registry: registryA},
+		{UpkeepID: 2, Registry: registryB},
+		{UpkeepID: 3, Registry: registryA},
+		{UpkeepID: 1, Registry: registryB},
+	}
+
+	byRegistry := groupByRegistry(upkeeps)
+	require.Len(t, byRegistry, 2)
+	assert.Equal(t, []UpkeepRegistration{upkeeps[0], upkeeps[2]}, byRegistry[0])
+	assert.Equal(t, []UpkeepRegistration{upkeeps[1], upkeeps[3]}, byRegistry[1])
+	assert.Empty(t, groupByRegistry(nil))
+}
+
+func TestUpkeepExecuter_ProcessRegistries(t *testing.T) {
+	t.Parallel()
+
+	var upkeeps []UpkeepRegistration
+	for i := int64(1); i <= 3; i++ {
+		registry := Registry{ContractAddress: ethkey.EIP55AddressFromAddress(common.BigToAddress(big.NewInt(i)))}
+		upkeeps = append(upkeeps, UpkeepRegistration{UpkeepID: 1, Registry: registry}, UpkeepRegistration{UpkeepID: 2, Registry: registry})
+	}
+
+	t.Run("processes the upkeeps of every registry together by default", func(t *testing.T) {
+		ex := newTestExecuter(t, testExecuterDeps{})
+
+		var processed [][]UpkeepRegistration
+		ex.processRegistries(upkeeps, func(registryUpkeeps []UpkeepRegistration) {
+			processed = append(processed, registryUpkeeps)
+		})
+		assert.Equal(t, [][]UpkeepRegistration{upkeeps}, processed)
+	})
+
+	t.Run("processes up to KeeperRegistryConcurrency registries at once", func(t *testing.T) {
+		const concurrency = 2
+		ex := newTestExecuter(t, testExecuterDeps{}, func(c *configtest.TestGeneralConfig) {
+			c.Overrides.KeeperRegistryConcurrency = null.IntFrom(concurrency)
+		})
+
+		release := make(chan struct{})
+		var mu sync.Mutex
+		var active, maxActive int
+		var processed [][]UpkeepRegistration
+		returned := make(chan struct{})
+		go func() {
+			defer close(returned)
+			ex.processRegistries(upkeeps, func(registryUpkeeps []UpkeepRegistration) {
+				mu.Lock()
+				active++
+				if active > maxActive {
+					maxActive = active
+				}
+				processed = append(processed, registryUpkeeps)
+				mu.Unlock()
+				<-release
+				mu.Lock()
+				active--
+				mu.Unlock()
+			})
+		}()
+
+		inFlight := func() (int, int) {
+			mu.Lock()
+			defer mu.Unlock()
+			return active, len(processed)
+		}
+		require.Eventually(t, func() bool {
+			active, _ := inFlight()
+			return active == concurrency
+		}, 5*time.Second, 10*time.Millisecond)
+		// the third registry waits for one of the first two
+		require.Never(t, func() bool {
+			_, started := inFlight()
+			return started > concurrency
+		}, 200*time.Millisecond, 10*time.Millisecond)
+
+		close(release)
+		select {
+		case <-returned:
+		case <-time.After(5 * time.Second):
+			t.Fatal("processRegistries did not return")
+		}
+
+		assert.Equal(t, concurrency, maxActive)
+		assert.ElementsMatch(t, [][]UpkeepRegistration{upkeeps[0:2], upkeeps[2:4], upkeeps[4:6]}, processed)
+	})
+}
@@ -0,0 +1,29 @@
+package keeper
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+)
+
+// errGasPriceAboveRegistryMaximum is returned by applyRegistryMaxGasPrice if the gas price exceeds the
+// maximum gas price the registry reimburses and KeeperClampToRegistryMaxGasPrice is disabled
+var errGasPriceAboveRegistryMaximum = errors.New("gas price exceeds the maximum gas price the registry reimburses")
+
+// applyRegistryMaxGasPrice caps gasPrice at the maximum gas price the registry reimburses the perform with,
+// as returned by checkUpkeep. The keeper pays for the difference of a perform above it, so the gas price is
+// clamped to it, or the upkeep skipped if KeeperClampToRegistryMaxGasPrice is disabled. It's a no-op if
+// the registry doesn't return a maximum.
+func (ex *UpkeepExecuter) applyRegistryMaxGasPrice(gasPrice *big.Int, checked *checkUpkeepResult, lggr logger.Logger) (*big.Int, error) {
+	if checked == nil || checked.MaxValidGasPrice == nil || gasPrice.Cmp(checked.MaxValidGasPrice) <= 0 {
+		return gasPrice, nil
+	}
+	if !ex.config.KeeperClampToRegistryMaxGasPrice() {
+		return nil, errors.Wrapf(errGasPriceAboveRegistryMaximum, "gas price %s, registry maximum %s", gasPrice, checked.MaxValidGasPrice)
+	}
+	lggr.Debugw("clamping gas price to the maximum gas price the registry reimburses",
+		"gasPrice", gasPrice, "maxValidGasPrice", checked.MaxValidGasPrice)
+	return new(big.Int).Set(checked.MaxValidGasPrice), nil
+}
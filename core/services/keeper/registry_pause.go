@@ -0,0 +1,38 @@
+package keeper
+
+import (
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+// excludePausedRegistries drops the upkeeps of the registries that were paused on-chain when they were last synced.
+// Performs revert while a registry is paused, so none of its upkeeps are executed until the registry
+// synchronizer syncs it unpaused. allPaused is true if there were upkeeps and all their registries are paused.
+// It must only be called from the run goroutine.
+func (ex *UpkeepExecuter) excludePausedRegistries(head eth.Head, upkeeps []UpkeepRegistration) (remaining []UpkeepRegistration, allPaused bool) {
+	if len(upkeeps) == 0 {
+		return upkeeps, false
+	}
+	remaining = upkeeps[:0]
+	for _, upkeep := range upkeeps {
+		if !ex.registryPaused(head, upkeep.Registry) {
+			remaining = append(remaining, upkeep)
+		}
+	}
+	return remaining, len(remaining) == 0
+}
+
+// registryPaused returns the paused state of registry, logging it if it changed since the last processed head
+func (ex *UpkeepExecuter) registryPaused(head eth.Head, registry Registry) bool {
+	address := registry.ContractAddress.Address()
+	if registry.Paused != ex.pausedRegistries[address] {
+		if registry.Paused {
+			ex.logger.Warnw("registry is paused, skipping its upkeeps until it is unpaused",
+				"blockheight", head.Number, "registryAddress", registry.ContractAddress.Hex())
+		} else {
+			ex.logger.Infow("registry is unpaused, resuming its upkeeps",
+				"blockheight", head.Number, "registryAddress", registry.ContractAddress.Hex())
+		}
+		ex.pausedRegistries[address] = registry.Paused
+	}
+	return registry.Paused
+}
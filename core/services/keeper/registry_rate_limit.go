@@ -0,0 +1,35 @@
+package keeper
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+// limitPerRegistry keeps the first KeeperMaxUpkeepsPerBlock upkeeps of every registry to execute on head,
+// so that a head with many eligible upkeeps doesn't fire all of their performs at a registry at once. The
+// limit applies per head, the upkeeps left out are still eligible and deferred to the next head.
+func (ex *UpkeepExecuter) limitPerRegistry(head eth.Head, upkeeps []UpkeepRegistration) []UpkeepRegistration {
+	limit := ex.config.KeeperMaxUpkeepsPerBlock()
+	if limit == 0 {
+		return upkeeps
+	}
+
+	executions := make(map[common.Address]uint32)
+	deferred := make(map[common.Address][]int64)
+	remaining := upkeeps[:0]
+	for _, upkeep := range upkeeps {
+		registry := upkeep.Registry.ContractAddress.Address()
+		if executions[registry] >= limit {
+			deferred[registry] = append(deferred[registry], upkeep.UpkeepID)
+			continue
+		}
+		executions[registry]++
+		remaining = append(remaining, upkeep)
+	}
+	for registry, upkeepIDs := range deferred {
+		ex.logger.Debugw("KeeperMaxUpkeepsPerBlock reached, deferring upkeeps to the next head",
+			"blockheight", head.Number, "registryAddress", registry.Hex(), "upkeepIDs", upkeepIDs)
+	}
+	return remaining
+}
@@ -0,0 +1,61 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/configtest"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/services/keystore/keys/ethkey"
+)
+
+func TestLimitPerRegistry(t *testing.T) {
+	t.Parallel()
+
+	newExecuter := func(t *testing.T, limit int64) *UpkeepExecuter {
+		return newTestExecuter(t, testExecuterDeps{}, func(c *configtest.TestGeneralConfig) {
+			c.Overrides.KeeperMaxUpkeepsPerBlock = null.IntFrom(limit)
+		})
+	}
+	// upkeepsOf returns n upkeeps of the registry at address, their IDs starting at firstID
+	upkeepsOf := func(address string, firstID int64, n int) []UpkeepRegistration {
+		registry := Registry{ContractAddress: ethkey.EIP55AddressFromAddress(common.HexToAddress(address))}
+		upkeeps := make([]UpkeepRegistration, n)
+		for i := range upkeeps {
+			upkeeps[i] = UpkeepRegistration{UpkeepID: firstID + int64(i), Registry: registry}
+		}
+		return upkeeps
+	}
+	upkeepIDs := func(upkeeps []UpkeepRegistration) []int64 {
+		ids := make([]int64, len(upkeeps))
+		for i, upkeep := range upkeeps {
+			ids[i] = upkeep.UpkeepID
+		}
+		return ids
+	}
+	head := eth.Head{Number: 20}
+
+	t.Run("executes the first upkeeps up to the limit", func(t *testing.T) {
+		upkeeps := newExecuter(t, 5).limitPerRegistry(head, upkeepsOf("0x1", 0, 20))
+		require.Equal(t, []int64{0, 1, 2, 3, 4}, upkeepIDs(upkeeps))
+	})
+
+	t.Run("limits every registry separately", func(t *testing.T) {
+		upkeeps := append(upkeepsOf("0x1", 0, 3), upkeepsOf("0x2", 10, 3)...)
+		upkeeps = newExecuter(t, 2).limitPerRegistry(head, upkeeps)
+		require.Equal(t, []int64{0, 1, 10, 11}, upkeepIDs(upkeeps))
+	})
+
+	t.Run("resets on every head", func(t *testing.T) {
+		ex := newExecuter(t, 5)
+		require.Len(t, ex.limitPerRegistry(head, upkeepsOf("0x1", 0, 20)), 5)
+		require.Len(t, ex.limitPerRegistry(eth.Head{Number: 21}, upkeepsOf("0x1", 5, 15)), 5)
+	})
+
+	t.Run("0 disables the limit", func(t *testing.T) {
+		require.Len(t, newExecuter(t, 0).limitPerRegistry(head, upkeepsOf("0x1", 0, 20)), 20)
+	})
+}
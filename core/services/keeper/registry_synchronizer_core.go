@@ -8,6 +8,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/core/internal/gethwrappers/generated/keeper_registry_wrapper"
 	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
 	"github.com/smartcontractkit/chainlink/core/services/job"
 	"github.com/smartcontractkit/chainlink/core/services/log"
 	"github.com/smartcontractkit/chainlink/core/utils"
@@ -22,6 +23,7 @@ var (
 type RegistrySynchronizer struct {
 	chStop           chan struct{}
 	contract         *keeper_registry_wrapper.KeeperRegistry
+	ethClient        eth.Client
 	interval         time.Duration
 	job              job.Job
 	jrm              job.ORM
@@ -46,6 +48,7 @@ type MailRoom struct {
 func NewRegistrySynchronizer(
 	job job.Job,
 	contract *keeper_registry_wrapper.KeeperRegistry,
+	ethClient eth.Client,
 	orm ORM,
 	jrm job.ORM,
 	logBroadcaster log.Broadcaster,
@@ -62,6 +65,7 @@ func NewRegistrySynchronizer(
 	return &RegistrySynchronizer{
 		chStop:           make(chan struct{}),
 		contract:         contract,
+		ethClient:        ethClient,
 		interval:         syncInterval,
 		job:              job,
 		jrm:              jrm,
@@ -84,6 +88,8 @@ func (rs *RegistrySynchronizer) Start() error {
 			LogsWithTopics: map[common.Hash][][]log.Topic{
 				keeper_registry_wrapper.KeeperRegistryKeepersUpdated{}.Topic():   nil,
 				keeper_registry_wrapper.KeeperRegistryConfigSet{}.Topic():        nil,
+				keeper_registry_wrapper.KeeperRegistryPaused{}.Topic():           nil,
+				keeper_registry_wrapper.KeeperRegistryUnpaused{}.Topic():         nil,
 				keeper_registry_wrapper.KeeperRegistryUpkeepCanceled{}.Topic():   nil,
 				keeper_registry_wrapper.KeeperRegistryUpkeepRegistered{}.Topic(): nil,
 				keeper_registry_wrapper.KeeperRegistryUpkeepPerformed{}.Topic():  nil,
@@ -86,7 +86,7 @@ func (rs *RegistrySynchronizer) handleUpkeepCancelled(broadcast log.Broadcast) {
 	}
 	ctx, cancel := postgres.DefaultQueryCtx()
 	defer cancel()
-	affected, err := rs.orm.BatchDeleteUpkeepsForJob(ctx, rs.job.ID, []int64{broadcastedLog.Id.Int64()})
+	affected, err := rs.orm.BatchDeleteUpkeepsForJob(ctx, rs.job.ID, rs.job.KeeperSpec.ContractAddress, []int64{broadcastedLog.Id.Int64()})
 	if err != nil {
 		rs.logger.With("error", err).Error("unable to batch delete upkeeps")
 		return
@@ -104,7 +104,7 @@ func (rs *RegistrySynchronizer) handleUpkeepRegisteredLogs(done func()) {
 	defer done()
 	ctx, cancel := postgres.DefaultQueryCtx()
 	defer cancel()
-	registry, err := rs.orm.RegistryForJob(ctx, rs.job.ID)
+	registry, err := rs.orm.RegistryForJob(ctx, rs.job.ID, rs.job.KeeperSpec.ContractAddress)
 	if err != nil {
 		rs.logger.With("error", err).Error("unable to find registry for job")
 		return
@@ -188,7 +188,7 @@ func (rs *RegistrySynchronizer) handleUpkeepPerformed(broadcast log.Broadcast) {
 	defer cancel()
 
 	// set last run to 0 so that keeper can resume checkUpkeep()
-	err = rs.orm.SetLastRunHeightForUpkeepOnJob(ctx, rs.job.ID, log.Id.Int64(), 0)
+	err = rs.orm.SetLastRunHeightForUpkeepOnJob(ctx, rs.job.ID, rs.job.KeeperSpec.ContractAddress, log.Id.Int64(), 0)
 	if err != nil {
 		rs.logger.With("error", err).Error("failed to set last run to 0")
 		return
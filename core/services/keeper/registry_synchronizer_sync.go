@@ -2,9 +2,9 @@ package keeper
 
 import (
 	"encoding/binary"
-	"math/big"
 	"sync"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/pkg/errors"
 
 	"github.com/smartcontractkit/chainlink/core/services/keystore/keys/ethkey"
@@ -57,11 +57,14 @@ func (rs *RegistrySynchronizer) addNewUpkeeps(reg Registry) error {
 		return errors.Wrap(err, "unable to find next ID for registry")
 	}
 
-	countOnContractBig, err := rs.contract.GetUpkeepCount(nil)
+	version, err := rs.registryVersion()
+	if err != nil {
+		return err
+	}
+	countOnContract, err := version.getUpkeepCount(rs.callRegistry)
 	if err != nil {
 		return errors.Wrapf(err, "unable to get upkeep count")
 	}
-	countOnContract := countOnContractBig.Int64()
 
 	if nextUpkeepID > countOnContract {
 		return errors.New("invariant, contract should always have at least as many upkeeps as DB")
@@ -103,7 +106,11 @@ func (rs *RegistrySynchronizer) syncUpkeepWithCallback(registry Registry, upkeep
 }
 
 func (rs *RegistrySynchronizer) syncUpkeep(registry Registry, upkeepID int64) error {
-	upkeepConfig, err := rs.contract.GetUpkeep(nil, big.NewInt(upkeepID))
+	version, err := rs.registryVersion()
+	if err != nil {
+		return err
+	}
+	checkData, executeGas, err := version.getUpkeepConfig(rs.callRegistry, upkeepID)
 	if err != nil {
 		return errors.Wrap(err, "failed to get upkeep config")
 	}
@@ -112,8 +119,8 @@ func (rs *RegistrySynchronizer) syncUpkeep(registry Registry, upkeepID int64) er
 		return errors.Wrap(err, "failed to calc positioning constant")
 	}
 	newUpkeep := UpkeepRegistration{
-		CheckData:           upkeepConfig.CheckData,
-		ExecuteGas:          uint64(upkeepConfig.ExecuteGas),
+		CheckData:           checkData,
+		ExecuteGas:          executeGas,
 		RegistryID:          registry.ID,
 		PositioningConstant: positioningConstant,
 		UpkeepID:            upkeepID,
@@ -128,17 +135,17 @@ func (rs *RegistrySynchronizer) syncUpkeep(registry Registry, upkeepID int64) er
 }
 
 func (rs *RegistrySynchronizer) deleteCanceledUpkeeps() error {
-	canceledBigs, err := rs.contract.GetCanceledUpkeepList(nil)
+	version, err := rs.registryVersion()
 	if err != nil {
-		return errors.Wrap(err, "failed to get canceled upkeep list")
+		return err
 	}
-	canceled := make([]int64, len(canceledBigs))
-	for idx, upkeepID := range canceledBigs {
-		canceled[idx] = upkeepID.Int64()
+	canceled, err := version.getCanceledUpkeepList(rs.callRegistry)
+	if err != nil {
+		return errors.Wrap(err, "failed to get canceled upkeep list")
 	}
 	ctx, cancel := postgres.DefaultQueryCtx()
 	defer cancel()
-	if _, err := rs.orm.BatchDeleteUpkeepsForJob(ctx, rs.job.ID, canceled); err != nil {
+	if _, err := rs.orm.BatchDeleteUpkeepsForJob(ctx, rs.job.ID, rs.job.KeeperSpec.ContractAddress, canceled); err != nil {
 		return errors.Wrap(err, "failed to batch delete upkeeps from job")
 	}
 
@@ -149,19 +156,19 @@ func (rs *RegistrySynchronizer) deleteCanceledUpkeeps() error {
 func (rs *RegistrySynchronizer) newRegistryFromChain() (Registry, error) {
 	fromAddress := rs.job.KeeperSpec.FromAddress
 	contractAddress := rs.job.KeeperSpec.ContractAddress
-	config, err := rs.contract.GetConfig(nil)
+	version, err := rs.registryVersion()
+	if err != nil {
+		return Registry{}, err
+	}
+	state, err := version.getRegistryState(rs.callRegistry)
 	if err != nil {
 		ctx, cancel := postgres.DefaultQueryCtx()
 		defer cancel()
 		rs.jrm.RecordError(ctx, rs.job.ID, err.Error())
 		return Registry{}, errors.Wrap(err, "failed to get contract config")
 	}
-	keeperAddresses, err := rs.contract.GetKeeperList(nil)
-	if err != nil {
-		return Registry{}, errors.Wrap(err, "failed to get keeper list")
-	}
 	keeperIndex := int32(-1)
-	for idx, address := range keeperAddresses {
+	for idx, address := range state.Keepers {
 		if address == fromAddress.Address() {
 			keeperIndex = int32(idx)
 		}
@@ -169,18 +176,37 @@ func (rs *RegistrySynchronizer) newRegistryFromChain() (Registry, error) {
 	if keeperIndex == -1 {
 		rs.logger.Warnf("unable to find %s in keeper list on registry %s", fromAddress.Hex(), contractAddress.Hex())
 	}
+	paused, err := version.getPaused(rs.callRegistry)
+	if err != nil {
+		return Registry{}, errors.Wrap(err, "failed to get paused state")
+	}
 
 	return Registry{
-		BlockCountPerTurn: int32(config.BlockCountPerTurn.Int64()),
-		CheckGas:          int32(config.CheckGasLimit),
+		BlockCountPerTurn: state.BlockCountPerTurn,
+		CheckGas:          state.CheckGas,
 		ContractAddress:   contractAddress,
 		FromAddress:       fromAddress,
 		JobID:             rs.job.ID,
 		KeeperIndex:       keeperIndex,
-		NumKeepers:        int32(len(keeperAddresses)),
+		NumKeepers:        int32(len(state.Keepers)),
+		Paused:            paused,
+		Version:           version.name,
 	}, nil
 }
 
+// registryVersion returns the registryVersion of the registry of the job
+func (rs *RegistrySynchronizer) registryVersion() (registryVersion, error) {
+	return registryVersionFor(rs.job.KeeperSpec.RegistryVersion)
+}
+
+// callRegistry makes an eth_call to the registry of the job, it's canceled when the synchronizer is closed
+func (rs *RegistrySynchronizer) callRegistry(data []byte) ([]byte, error) {
+	ctx, cancel := utils.ContextFromChan(rs.chStop)
+	defer cancel()
+	to := rs.contract.Address()
+	return rs.ethClient.CallContract(ctx, ethereum.CallMsg{To: &to, Data: data}, nil)
+}
+
 // CalcPositioningConstant calculates a positioning constant.
 // The positioning constant is fixed because upkeepID and registryAddress are immutable
 func CalcPositioningConstant(upkeepID int64, registryAddress ethkey.EIP55Address) (int32, error) {
@@ -52,6 +52,17 @@ func setupRegistrySync(t *testing.T) (
 	*mocks.Client,
 	*logmocks.Broadcaster,
 	job.Job,
+) {
+	return setupRegistrySyncForVersion(t, "")
+}
+
+// setupRegistrySyncForVersion sets up a synchronizer for a job whose registry has registryVersion
+func setupRegistrySyncForVersion(t *testing.T, registryVersion string) (
+	*gorm.DB,
+	*keeper.RegistrySynchronizer,
+	*mocks.Client,
+	*logmocks.Broadcaster,
+	job.Job,
 ) {
 	config := cltest.NewTestGeneralConfig(t)
 	db := pgtest.NewGormDB(t)
@@ -61,6 +72,7 @@ func setupRegistrySync(t *testing.T) (
 	lbMock.Test(t)
 	lbMock.On("AddDependents", 1).Maybe()
 	j := cltest.MustInsertKeeperJob(t, db, cltest.NewEIP55Address(), cltest.NewEIP55Address())
+	j.KeeperSpec.RegistryVersion = registryVersion
 	cfg := cltest.NewTestGeneralConfig(t)
 	cc := evmtest.NewChainSet(t, evmtest.TestChainOpts{DB: db, Client: ethClient, LogBroadcaster: lbMock, GeneralConfig: cfg})
 	keyStore := cltest.NewKeyStore(t, db)
@@ -78,7 +90,7 @@ func setupRegistrySync(t *testing.T) (
 	lbMock.On("IsConnected").Return(true).Maybe()
 
 	orm := keeper.NewORM(db, nil, config, bulletprooftxmanager.SendEveryStrategy{})
-	synchronizer := keeper.NewRegistrySynchronizer(j, contract, orm, jpv2.Jrm, lbMock, syncInterval, 1, logger.Default)
+	synchronizer := keeper.NewRegistrySynchronizer(j, contract, ethClient, orm, jpv2.Jrm, lbMock, syncInterval, 1, logger.Default)
 	return db, synchronizer, ethClient, lbMock, j
 }
 
@@ -101,6 +113,7 @@ func Test_RegistrySynchronizer_Start(t *testing.T) {
 	canceledUpkeeps := []*big.Int{big.NewInt(1)}
 	registryMock.MockResponse("getConfig", registryConfig).Once()
 	registryMock.MockResponse("getKeeperList", []common.Address{fromAddress}).Once()
+	registryMock.MockResponse("paused", false).Once()
 	registryMock.MockResponse("getCanceledUpkeepList", canceledUpkeeps).Once()
 	registryMock.MockResponse("getUpkeepCount", big.NewInt(0)).Once()
 
@@ -132,6 +145,7 @@ func Test_RegistrySynchronizer_FullSync(t *testing.T) {
 	canceledUpkeeps := []*big.Int{big.NewInt(1)}
 	registryMock.MockResponse("getConfig", registryConfig).Once()
 	registryMock.MockResponse("getKeeperList", []common.Address{fromAddress}).Once()
+	registryMock.MockResponse("paused", false).Once()
 	registryMock.MockResponse("getCanceledUpkeepList", canceledUpkeeps).Once()
 	registryMock.MockResponse("getUpkeepCount", big.NewInt(3)).Once()
 	registryMock.MockResponse("getUpkeep", upkeepConfig).Times(3) // sync all 3, then delete
@@ -150,6 +164,7 @@ func Test_RegistrySynchronizer_FullSync(t *testing.T) {
 	require.Equal(t, int32(20), registry.BlockCountPerTurn)
 	require.Equal(t, int32(0), registry.KeeperIndex)
 	require.Equal(t, int32(1), registry.NumKeepers)
+	require.Equal(t, keeper.DefaultRegistryVersion, registry.Version)
 	require.Equal(t, upkeepConfig.CheckData, upkeepRegistration.CheckData)
 	require.Equal(t, uint64(upkeepConfig.ExecuteGas), upkeepRegistration.ExecuteGas)
 
@@ -160,6 +175,7 @@ func Test_RegistrySynchronizer_FullSync(t *testing.T) {
 	canceledUpkeeps = []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(3)}
 	registryMock.MockResponse("getConfig", registryConfig).Once()
 	registryMock.MockResponse("getKeeperList", []common.Address{fromAddress}).Once()
+	registryMock.MockResponse("paused", false).Once()
 	registryMock.MockResponse("getCanceledUpkeepList", canceledUpkeeps).Once()
 	registryMock.MockResponse("getUpkeepCount", big.NewInt(5)).Once()
 	registryMock.MockResponse("getUpkeep", upkeepConfig).Times(2) // two new upkeeps to sync
@@ -172,6 +188,47 @@ func Test_RegistrySynchronizer_FullSync(t *testing.T) {
 	ethMock.AssertExpectations(t)
 }
 
+func Test_RegistrySynchronizer_FullSync_RegistryVersion(t *testing.T) {
+	db, synchronizer, ethMock, _, job := setupRegistrySyncForVersion(t, "1.3")
+
+	contractAddress := job.KeeperSpec.ContractAddress.Address()
+	fromAddress := job.KeeperSpec.FromAddress.Address()
+
+	// 1.3 registries return their config and keepers from getState, and getUpkeep also returns
+	// the amount spent and the paused state of the upkeep
+	registryMock := cltest.NewContractMockReceiver(t, ethMock, keeper.ExportedRegistryABI("1.3"), contractAddress)
+	registryMock.MockResponse("getState",
+		uint32(1), big.NewInt(0), big.NewInt(0), big.NewInt(2),
+		uint32(100), uint32(0), big.NewInt(30), uint32(2_500_000), big.NewInt(3600), uint16(1), big.NewInt(0), uint32(5_000_000), big.NewInt(1), big.NewInt(1), common.Address{}, common.Address{},
+		[]common.Address{cltest.NewAddress(), fromAddress},
+	).Once()
+	registryMock.MockResponse("paused", false).Once()
+	registryMock.MockResponse("getCanceledUpkeepList", []*big.Int{}).Once()
+	registryMock.MockResponse("getUpkeepCount", big.NewInt(2)).Once()
+	registryMock.MockResponse("getUpkeep",
+		upkeepConfig.Target, upkeepConfig.ExecuteGas, upkeepConfig.CheckData, upkeepConfig.Balance,
+		upkeepConfig.LastKeeper, upkeepConfig.Admin, upkeepConfig.MaxValidBlocknumber, big.NewInt(42), false,
+	).Times(2)
+
+	synchronizer.ExportedFullSync()
+
+	cltest.AssertCount(t, db, keeper.Registry{}, 1)
+	cltest.AssertCount(t, db, keeper.UpkeepRegistration{}, 2)
+
+	var registry keeper.Registry
+	var upkeepRegistration keeper.UpkeepRegistration
+	require.NoError(t, db.First(&registry).Error)
+	require.NoError(t, db.First(&upkeepRegistration).Error)
+	require.Equal(t, "1.3", registry.Version)
+	require.Equal(t, int32(30), registry.BlockCountPerTurn)
+	require.Equal(t, int32(2_500_000), registry.CheckGas)
+	require.Equal(t, int32(1), registry.KeeperIndex)
+	require.Equal(t, int32(2), registry.NumKeepers)
+	require.Equal(t, upkeepConfig.CheckData, upkeepRegistration.CheckData)
+	require.Equal(t, uint64(upkeepConfig.ExecuteGas), upkeepRegistration.ExecuteGas)
+	ethMock.AssertExpectations(t)
+}
+
 func Test_RegistrySynchronizer_ConfigSetLog(t *testing.T) {
 	db, synchronizer, ethMock, lb, job := setupRegistrySync(t)
 
@@ -180,6 +237,7 @@ func Test_RegistrySynchronizer_ConfigSetLog(t *testing.T) {
 
 	registryMock := cltest.NewContractMockReceiver(t, ethMock, keeper.RegistryABI, contractAddress)
 	registryMock.MockResponse("getKeeperList", []common.Address{fromAddress}).Once()
+	registryMock.MockResponse("paused", false).Once()
 	registryMock.MockResponse("getConfig", registryConfig).Once()
 	registryMock.MockResponse("getCanceledUpkeepList", []*big.Int{}).Once()
 	registryMock.MockResponse("getUpkeepCount", big.NewInt(0)).Once()
@@ -192,6 +250,7 @@ func Test_RegistrySynchronizer_ConfigSetLog(t *testing.T) {
 
 	registryConfig.BlockCountPerTurn = big.NewInt(40) // change from default
 	registryMock.MockResponse("getKeeperList", []common.Address{fromAddress}).Once()
+	registryMock.MockResponse("paused", false).Once()
 	registryMock.MockResponse("getConfig", registryConfig).Once()
 
 	head := cltest.MustInsertHead(t, db, 1)
@@ -224,6 +283,7 @@ func Test_RegistrySynchronizer_KeepersUpdatedLog(t *testing.T) {
 
 	registryMock := cltest.NewContractMockReceiver(t, ethMock, keeper.RegistryABI, contractAddress)
 	registryMock.MockResponse("getKeeperList", []common.Address{fromAddress}).Once()
+	registryMock.MockResponse("paused", false).Once()
 	registryMock.MockResponse("getConfig", registryConfig).Once()
 	registryMock.MockResponse("getCanceledUpkeepList", []*big.Int{}).Once()
 	registryMock.MockResponse("getUpkeepCount", big.NewInt(0)).Once()
@@ -237,6 +297,7 @@ func Test_RegistrySynchronizer_KeepersUpdatedLog(t *testing.T) {
 	addresses := []common.Address{fromAddress, cltest.NewAddress()} // change from default
 	registryMock.MockResponse("getConfig", registryConfig).Once()
 	registryMock.MockResponse("getKeeperList", addresses).Once()
+	registryMock.MockResponse("paused", false).Once()
 
 	head := cltest.MustInsertHead(t, db, 1)
 	rawLog := types.Log{BlockHash: head.Hash}
@@ -260,6 +321,52 @@ func Test_RegistrySynchronizer_KeepersUpdatedLog(t *testing.T) {
 	logBroadcast.AssertExpectations(t)
 }
 
+func Test_RegistrySynchronizer_PausedLog(t *testing.T) {
+	db, synchronizer, ethMock, lb, job := setupRegistrySync(t)
+
+	contractAddress := job.KeeperSpec.ContractAddress.Address()
+	fromAddress := job.KeeperSpec.FromAddress.Address()
+
+	registryMock := cltest.NewContractMockReceiver(t, ethMock, keeper.RegistryABI, contractAddress)
+	registryMock.MockResponse("getKeeperList", []common.Address{fromAddress}).Once()
+	registryMock.MockResponse("paused", false).Once()
+	registryMock.MockResponse("getConfig", registryConfig).Once()
+	registryMock.MockResponse("getCanceledUpkeepList", []*big.Int{}).Once()
+	registryMock.MockResponse("getUpkeepCount", big.NewInt(0)).Once()
+
+	require.NoError(t, synchronizer.Start())
+	defer synchronizer.Close()
+	cltest.WaitForCount(t, db, keeper.Registry{}, 1)
+	var registry keeper.Registry
+	require.NoError(t, db.First(&registry).Error)
+	require.False(t, registry.Paused)
+
+	registryMock.MockResponse("getConfig", registryConfig).Once()
+	registryMock.MockResponse("getKeeperList", []common.Address{fromAddress}).Once()
+	registryMock.MockResponse("paused", true).Once()
+
+	head := cltest.MustInsertHead(t, db, 1)
+	rawLog := types.Log{BlockHash: head.Hash}
+	log := keeper_registry_wrapper.KeeperRegistryPaused{}
+	logBroadcast := new(logmocks.Broadcast)
+	logBroadcast.On("DecodedLog").Return(&log)
+	logBroadcast.On("RawLog").Return(rawLog)
+	logBroadcast.On("String").Maybe().Return("")
+	lb.On("MarkConsumed", mock.Anything, mock.Anything).Return(nil)
+	lb.On("WasAlreadyConsumed", mock.Anything, mock.Anything).Return(false, nil)
+
+	// Do the thing
+	synchronizer.HandleLog(logBroadcast)
+	synchronizer.ExportedProcessLogs()
+
+	cltest.AssertRecordEventually(t, db, &registry, func() bool {
+		return registry.Paused
+	})
+	cltest.AssertCount(t, db, keeper.Registry{}, 1)
+	ethMock.AssertExpectations(t)
+	logBroadcast.AssertExpectations(t)
+}
+
 func Test_RegistrySynchronizer_UpkeepCanceledLog(t *testing.T) {
 	db, synchronizer, ethMock, lb, job := setupRegistrySync(t)
 
@@ -269,6 +376,7 @@ func Test_RegistrySynchronizer_UpkeepCanceledLog(t *testing.T) {
 	registryMock := cltest.NewContractMockReceiver(t, ethMock, keeper.RegistryABI, contractAddress)
 	registryMock.MockResponse("getConfig", registryConfig).Once()
 	registryMock.MockResponse("getKeeperList", []common.Address{fromAddress}).Once()
+	registryMock.MockResponse("paused", false).Once()
 	registryMock.MockResponse("getCanceledUpkeepList", []*big.Int{}).Once()
 	registryMock.MockResponse("getUpkeepCount", big.NewInt(3)).Once()
 	registryMock.MockResponse("getUpkeep", upkeepConfig).Times(3)
@@ -306,6 +414,7 @@ func Test_RegistrySynchronizer_UpkeepRegisteredLog(t *testing.T) {
 	registryMock := cltest.NewContractMockReceiver(t, ethMock, keeper.RegistryABI, contractAddress)
 	registryMock.MockResponse("getConfig", registryConfig).Once()
 	registryMock.MockResponse("getKeeperList", []common.Address{fromAddress}).Once()
+	registryMock.MockResponse("paused", false).Once()
 	registryMock.MockResponse("getCanceledUpkeepList", []*big.Int{}).Once()
 	registryMock.MockResponse("getUpkeepCount", big.NewInt(0)).Once()
 
@@ -345,6 +454,7 @@ func Test_RegistrySynchronizer_UpkeepPerformedLog(t *testing.T) {
 	registryMock := cltest.NewContractMockReceiver(t, ethMock, keeper.RegistryABI, contractAddress)
 	registryMock.MockResponse("getConfig", registryConfig).Once()
 	registryMock.MockResponse("getKeeperList", []common.Address{fromAddress}).Once()
+	registryMock.MockResponse("paused", false).Once()
 	registryMock.MockResponse("getCanceledUpkeepList", []*big.Int{}).Once()
 	registryMock.MockResponse("getUpkeepCount", big.NewInt(1)).Once()
 	registryMock.MockResponse("getUpkeep", upkeepConfig).Once()
@@ -0,0 +1,165 @@
+package keeper
+
+import (
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+// DefaultRegistryVersion is the KeeperRegistry version of registries whose keeper job doesn't set registryVersion
+const DefaultRegistryVersion = "1.1"
+
+// registryABIv1_2 holds the registry functions called by the executer and the synchronizer as of KeeperRegistry 1.2.
+// Its getUpkeep also returns the amount spent by the upkeep, and getState replaces getConfig and getKeeperList.
+// The state and config structs returned by getState only hold static types, so they are encoded in place and
+// are declared here field by field.
+const registryABIv1_2 = `[
+	{"type":"function","name":"getUpkeepCount","stateMutability":"view",
+	 "inputs":[],
+	 "outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"getCanceledUpkeepList","stateMutability":"view",
+	 "inputs":[],
+	 "outputs":[{"name":"","type":"uint256[]"}]},
+	{"type":"function","name":"paused","stateMutability":"view",
+	 "inputs":[],
+	 "outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"getState","stateMutability":"view",
+	 "inputs":[],
+	 "outputs":[{"name":"nonce","type":"uint32"},{"name":"ownerLinkBalance","type":"uint96"},{"name":"expectedLinkBalance","type":"uint256"},{"name":"numUpkeeps","type":"uint256"},
+	            {"name":"paymentPremiumPPB","type":"uint32"},{"name":"flatFeeMicroLink","type":"uint32"},{"name":"blockCountPerTurn","type":"uint24"},{"name":"checkGasLimit","type":"uint32"},{"name":"stalenessSeconds","type":"uint24"},{"name":"gasCeilingMultiplier","type":"uint16"},{"name":"minUpkeepSpend","type":"uint96"},{"name":"maxPerformGas","type":"uint32"},{"name":"fallbackGasPrice","type":"uint256"},{"name":"fallbackLinkPrice","type":"uint256"},{"name":"transcoder","type":"address"},{"name":"registrar","type":"address"},
+	            {"name":"keepers","type":"address[]"}]},
+	{"type":"function","name":"checkUpkeep","stateMutability":"nonpayable",
+	 "inputs":[{"name":"id","type":"uint256"},{"name":"from","type":"address"}],
+	 "outputs":[{"name":"performData","type":"bytes"},{"name":"maxLinkPayment","type":"uint256"},{"name":"gasLimit","type":"uint256"},{"name":"adjustedGasWei","type":"uint256"},{"name":"linkEth","type":"uint256"}]},
+	{"type":"function","name":"performUpkeep","stateMutability":"nonpayable",
+	 "inputs":[{"name":"id","type":"uint256"},{"name":"performData","type":"bytes"}],
+	 "outputs":[{"name":"success","type":"bool"}]},
+	{"type":"function","name":"getUpkeep","stateMutability":"view",
+	 "inputs":[{"name":"id","type":"uint256"}],
+	 "outputs":[{"name":"target","type":"address"},{"name":"executeGas","type":"uint32"},{"name":"checkData","type":"bytes"},{"name":"balance","type":"uint96"},{"name":"lastKeeper","type":"address"},{"name":"admin","type":"address"},{"name":"maxValidBlocknumber","type":"uint64"},{"name":"amountSpent","type":"uint96"}]}
+]`
+
+// registryABIv1_3 is registryABIv1_2 with the paused state of the upkeep returned by getUpkeep
+const registryABIv1_3 = `[
+	{"type":"function","name":"getUpkeepCount","stateMutability":"view",
+	 "inputs":[],
+	 "outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"getCanceledUpkeepList","stateMutability":"view",
+	 "inputs":[],
+	 "outputs":[{"name":"","type":"uint256[]"}]},
+	{"type":"function","name":"paused","stateMutability":"view",
+	 "inputs":[],
+	 "outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"getState","stateMutability":"view",
+	 "inputs":[],
+	 "outputs":[{"name":"nonce","type":"uint32"},{"name":"ownerLinkBalance","type":"uint96"},{"name":"expectedLinkBalance","type":"uint256"},{"name":"numUpkeeps","type":"uint256"},
+	            {"name":"paymentPremiumPPB","type":"uint32"},{"name":"flatFeeMicroLink","type":"uint32"},{"name":"blockCountPerTurn","type":"uint24"},{"name":"checkGasLimit","type":"uint32"},{"name":"stalenessSeconds","type":"uint24"},{"name":"gasCeilingMultiplier","type":"uint16"},{"name":"minUpkeepSpend","type":"uint96"},{"name":"maxPerformGas","type":"uint32"},{"name":"fallbackGasPrice","type":"uint256"},{"name":"fallbackLinkPrice","type":"uint256"},{"name":"transcoder","type":"address"},{"name":"registrar","type":"address"},
+	            {"name":"keepers","type":"address[]"}]},
+	{"type":"function","name":"checkUpkeep","stateMutability":"nonpayable",
+	 "inputs":[{"name":"id","type":"uint256"},{"name":"from","type":"address"}],
+	 "outputs":[{"name":"performData","type":"bytes"},{"name":"maxLinkPayment","type":"uint256"},{"name":"gasLimit","type":"uint256"},{"name":"adjustedGasWei","type":"uint256"},{"name":"linkEth","type":"uint256"}]},
+	{"type":"function","name":"performUpkeep","stateMutability":"nonpayable",
+	 "inputs":[{"name":"id","type":"uint256"},{"name":"performData","type":"bytes"}],
+	 "outputs":[{"name":"success","type":"bool"}]},
+	{"type":"function","name":"getUpkeep","stateMutability":"view",
+	 "inputs":[{"name":"id","type":"uint256"}],
+	 "outputs":[{"name":"target","type":"address"},{"name":"executeGas","type":"uint32"},{"name":"checkData","type":"bytes"},{"name":"balance","type":"uint96"},{"name":"lastKeeper","type":"address"},{"name":"admin","type":"address"},{"name":"maxValidBlocknumber","type":"uint64"},{"name":"amountSpent","type":"uint96"},{"name":"paused","type":"bool"}]}
+]`
+
+// registryVersions maps the supported KeeperRegistry versions to the ABI their calls are packed and decoded with
+var registryVersions = map[string]registryVersion{
+	"1.1": {name: "1.1", abi: RegistryABI},
+	"1.2": {name: "1.2", abi: eth.MustGetABI(registryABIv1_2)},
+	"1.3": {name: "1.3", abi: eth.MustGetABI(registryABIv1_3)},
+}
+
+// registryVersion packs the calls the executer and the synchronizer make to a KeeperRegistry version and decodes their results
+type registryVersion struct {
+	name string
+	abi  abi.ABI
+}
+
+// registryVersionName returns version, or DefaultRegistryVersion if it's empty
+func registryVersionName(version string) string {
+	if version == "" {
+		return DefaultRegistryVersion
+	}
+	return version
+}
+
+// registryVersionFor returns the registryVersion named version, DefaultRegistryVersion if it's empty
+func registryVersionFor(version string) (registryVersion, error) {
+	version = registryVersionName(version)
+	v, exists := registryVersions[version]
+	if !exists {
+		return registryVersion{}, errors.Errorf("unsupported registry version %q, supported versions are %s", version, supportedRegistryVersions())
+	}
+	return v, nil
+}
+
+func supportedRegistryVersions() string {
+	versions := make([]string, 0, len(registryVersions))
+	for version := range registryVersions {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+	return strings.Join(versions, ", ")
+}
+
+func (v registryVersion) packCheckUpkeep(upkeepID int64, from common.Address) ([]byte, error) {
+	data, err := v.abi.Pack("checkUpkeep", big.NewInt(upkeepID), from)
+	return data, errors.Wrapf(err, "unable to construct checkUpkeep data for registry version %s", v.name)
+}
+
+func (v registryVersion) packPerformUpkeep(upkeepID int64, performData []byte) ([]byte, error) {
+	data, err := v.abi.Pack("performUpkeep", big.NewInt(upkeepID), performData)
+	return data, errors.Wrapf(err, "unable to construct performUpkeep data for registry version %s", v.name)
+}
+
+func (v registryVersion) packGetUpkeep(upkeepID int64) ([]byte, error) {
+	data, err := v.abi.Pack("getUpkeep", big.NewInt(upkeepID))
+	return data, errors.Wrapf(err, "unable to construct getUpkeep data for registry version %s", v.name)
+}
+
+// unpackCheckUpkeep decodes the return data of a checkUpkeep call
+func (v registryVersion) unpackCheckUpkeep(out []byte) (checkUpkeepResult, error) {
+	var result checkUpkeepResult
+	values, err := v.abi.Unpack("checkUpkeep", out)
+	if err != nil {
+		return result, errors.Wrap(err, "unable to unpack checkUpkeep result")
+	}
+	var ok bool
+	if result.PerformData, ok = values[0].([]byte); !ok {
+		return result, errors.Errorf("expected performData to be []byte, got %T", values[0])
+	}
+	if result.MaxLinkPayment, ok = values[1].(*big.Int); !ok {
+		return result, errors.Errorf("expected maxLinkPayment to be *big.Int, got %T", values[1])
+	}
+	if result.GasLimit, ok = values[2].(*big.Int); !ok {
+		return result, errors.Errorf("expected gasLimit to be *big.Int, got %T", values[2])
+	}
+	if len(values) > 3 {
+		if adjustedGasWei, ok := values[3].(*big.Int); ok && adjustedGasWei.Sign() > 0 {
+			result.MaxValidGasPrice = adjustedGasWei
+		}
+	}
+	return result, nil
+}
+
+// unpackGetUpkeep decodes the return data of a getUpkeep call, the values of all versions start with
+// target, executeGas, checkData and balance
+func (v registryVersion) unpackGetUpkeep(out []byte) ([]interface{}, error) {
+	values, err := v.abi.Unpack("getUpkeep", out)
+	return values, errors.Wrap(err, "unable to unpack getUpkeep result")
+}
+
+// registryVersion returns the registryVersion of the registry of upkeep
+func (ex *UpkeepExecuter) registryVersion(upkeep UpkeepRegistration) (registryVersion, error) {
+	return registryVersionFor(upkeep.Registry.Version)
+}
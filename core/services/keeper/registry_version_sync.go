@@ -0,0 +1,136 @@
+package keeper
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// registryCall makes an eth_call to a registry with data and returns its return data
+type registryCall func(data []byte) ([]byte, error)
+
+// registryState is the part of the config and keepers of a registry that is kept in its Registry
+type registryState struct {
+	BlockCountPerTurn int32
+	CheckGas          int32
+	Keepers           []common.Address
+}
+
+// callMethod packs a call of method with args, makes it with call and unpacks its return values
+func (v registryVersion) callMethod(call registryCall, method string, args ...interface{}) ([]interface{}, error) {
+	data, err := v.abi.Pack(method, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to construct %s data for registry version %s", method, v.name)
+	}
+	out, err := call(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s call failed", method)
+	}
+	values, err := v.abi.Unpack(method, out)
+	return values, errors.Wrapf(err, "unable to unpack %s result", method)
+}
+
+// hasGetState returns true if the registry version returns its config and keepers from getState
+func (v registryVersion) hasGetState() bool {
+	_, exists := v.abi.Methods["getState"]
+	return exists
+}
+
+// getRegistryState returns the config and keepers of the registry, with a getState call as of 1.2 and
+// getConfig and getKeeperList calls before
+func (v registryVersion) getRegistryState(call registryCall) (registryState, error) {
+	var state registryState
+	var blockCountPerTurn, checkGasLimit, keepers interface{}
+	if v.hasGetState() {
+		values, err := v.callMethod(call, "getState")
+		if err != nil {
+			return state, err
+		}
+		blockCountPerTurn, checkGasLimit, keepers = values[6], values[7], values[16]
+	} else {
+		values, err := v.callMethod(call, "getConfig")
+		if err != nil {
+			return state, err
+		}
+		blockCountPerTurn, checkGasLimit = values[1], values[2]
+		if values, err = v.callMethod(call, "getKeeperList"); err != nil {
+			return state, err
+		}
+		keepers = values[0]
+	}
+
+	blockCount, ok := blockCountPerTurn.(*big.Int)
+	if !ok {
+		return state, errors.Errorf("expected blockCountPerTurn to be *big.Int, got %T", blockCountPerTurn)
+	}
+	checkGas, ok := checkGasLimit.(uint32)
+	if !ok {
+		return state, errors.Errorf("expected checkGasLimit to be uint32, got %T", checkGasLimit)
+	}
+	if state.Keepers, ok = keepers.([]common.Address); !ok {
+		return state, errors.Errorf("expected keepers to be []common.Address, got %T", keepers)
+	}
+	state.BlockCountPerTurn = int32(blockCount.Int64())
+	state.CheckGas = int32(checkGas)
+	return state, nil
+}
+
+// getPaused returns true if the registry is paused
+func (v registryVersion) getPaused(call registryCall) (bool, error) {
+	values, err := v.callMethod(call, "paused")
+	if err != nil {
+		return false, err
+	}
+	paused, ok := values[0].(bool)
+	if !ok {
+		return false, errors.Errorf("expected paused to be bool, got %T", values[0])
+	}
+	return paused, nil
+}
+
+// getUpkeepCount returns the number of upkeeps ever registered on the registry
+func (v registryVersion) getUpkeepCount(call registryCall) (int64, error) {
+	values, err := v.callMethod(call, "getUpkeepCount")
+	if err != nil {
+		return 0, err
+	}
+	count, ok := values[0].(*big.Int)
+	if !ok {
+		return 0, errors.Errorf("expected upkeep count to be *big.Int, got %T", values[0])
+	}
+	return count.Int64(), nil
+}
+
+// getCanceledUpkeepList returns the IDs of the canceled upkeeps of the registry
+func (v registryVersion) getCanceledUpkeepList(call registryCall) ([]int64, error) {
+	values, err := v.callMethod(call, "getCanceledUpkeepList")
+	if err != nil {
+		return nil, err
+	}
+	canceledBigs, ok := values[0].([]*big.Int)
+	if !ok {
+		return nil, errors.Errorf("expected canceled upkeep list to be []*big.Int, got %T", values[0])
+	}
+	canceled := make([]int64, len(canceledBigs))
+	for idx, upkeepID := range canceledBigs {
+		canceled[idx] = upkeepID.Int64()
+	}
+	return canceled, nil
+}
+
+// getUpkeepConfig returns the check data and execute gas of the upkeep
+func (v registryVersion) getUpkeepConfig(call registryCall, upkeepID int64) (checkData []byte, executeGas uint64, err error) {
+	values, err := v.callMethod(call, "getUpkeep", big.NewInt(upkeepID))
+	if err != nil {
+		return nil, 0, err
+	}
+	gas, ok := values[1].(uint32)
+	if !ok {
+		return nil, 0, errors.Errorf("expected executeGas to be uint32, got %T", values[1])
+	}
+	if checkData, ok = values[2].([]byte); !ok {
+		return nil, 0, errors.Errorf("expected checkData to be []byte, got %T", values[2])
+	}
+	return checkData, uint64(gas), nil
+}
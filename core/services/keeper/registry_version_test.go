@@ -0,0 +1,188 @@
+package keeper
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	gasmocks "github.com/smartcontractkit/chainlink/core/services/gas/mocks"
+)
+
+func TestRegistryVersion_Selectors(t *testing.T) {
+	t.Parallel()
+
+	selector := func(signature string) []byte {
+		return crypto.Keccak256([]byte(signature))[:4]
+	}
+	from := common.HexToAddress("0x2ab9a2Dc53736b361b72d900CdF9F78F9406fbbb")
+
+	for _, name := range []string{"1.1", "1.2", "1.3"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			version, err := registryVersionFor(name)
+			require.NoError(t, err)
+
+			data, err := version.packCheckUpkeep(1, from)
+			require.NoError(t, err)
+			require.Equal(t, selector("checkUpkeep(uint256,address)"), data[:4])
+
+			data, err = version.packPerformUpkeep(1, []byte{0x12, 0x34})
+			require.NoError(t, err)
+			require.Equal(t, selector("performUpkeep(uint256,bytes)"), data[:4])
+
+			data, err = version.packGetUpkeep(1)
+			require.NoError(t, err)
+			require.Equal(t, selector("getUpkeep(uint256)"), data[:4])
+		})
+	}
+}
+
+func TestRegistryVersion_UnpackGetUpkeep(t *testing.T) {
+	t.Parallel()
+
+	target := common.HexToAddress("0x9E40733cC9df84636505f4e6Db28DCa0dC5D1bba")
+	admin := common.HexToAddress("0xa8037A20989AFcBC51798de9762b351D63ff462e")
+	balance := assets.Ether(5)
+
+	v1_1, err := registryVersionFor("1.1")
+	require.NoError(t, err)
+	out, err := v1_1.abi.Methods["getUpkeep"].Outputs.Pack(target, uint32(100_000), []byte{}, balance, common.Address{}, admin, uint64(1_000))
+	require.NoError(t, err)
+	values, err := v1_1.unpackGetUpkeep(out)
+	require.NoError(t, err)
+	require.Len(t, values, 7)
+	require.Equal(t, target, values[0])
+	require.Equal(t, balance.String(), values[3].(*big.Int).String())
+
+	v1_3, err := registryVersionFor("1.3")
+	require.NoError(t, err)
+	out, err = v1_3.abi.Methods["getUpkeep"].Outputs.Pack(target, uint32(100_000), []byte{}, balance, common.Address{}, admin, uint64(1_000), big.NewInt(42), true)
+	require.NoError(t, err)
+	values, err = v1_3.unpackGetUpkeep(out)
+	require.NoError(t, err)
+	require.Len(t, values, 9)
+	require.Equal(t, target, values[0])
+	require.Equal(t, balance.String(), values[3].(*big.Int).String())
+	require.Equal(t, true, values[8])
+}
+
+func TestRegistryVersion_GetRegistryState(t *testing.T) {
+	t.Parallel()
+
+	keepers := []common.Address{common.HexToAddress("0x2ab9a2Dc53736b361b72d900CdF9F78F9406fbbb"), common.HexToAddress("0xa8037A20989AFcBC51798de9762b351D63ff462e")}
+	// registryCalls returns a registryCall answering the calls of version with responses, keyed by method
+	registryCalls := func(t *testing.T, version registryVersion, responses map[string][]interface{}) (registryCall, *[]string) {
+		var called []string
+		return func(data []byte) ([]byte, error) {
+			method, err := version.abi.MethodById(data[:4])
+			require.NoError(t, err)
+			called = append(called, method.Name)
+			return method.Outputs.Pack(responses[method.Name]...)
+		}, &called
+	}
+
+	t.Run("1.1 reads getConfig and getKeeperList", func(t *testing.T) {
+		v1_1, err := registryVersionFor("1.1")
+		require.NoError(t, err)
+		require.False(t, v1_1.hasGetState())
+		call, called := registryCalls(t, v1_1, map[string][]interface{}{
+			"getConfig":     {uint32(100), big.NewInt(20), uint32(2_000_000), big.NewInt(3600), uint16(1), big.NewInt(1), big.NewInt(1)},
+			"getKeeperList": {keepers},
+		})
+
+		state, err := v1_1.getRegistryState(call)
+		require.NoError(t, err)
+		require.Equal(t, registryState{BlockCountPerTurn: 20, CheckGas: 2_000_000, Keepers: keepers}, state)
+		require.Equal(t, []string{"getConfig", "getKeeperList"}, *called)
+	})
+
+	for _, name := range []string{"1.2", "1.3"} {
+		name := name
+		t.Run(name+" reads getState", func(t *testing.T) {
+			version, err := registryVersionFor(name)
+			require.NoError(t, err)
+			require.True(t, version.hasGetState())
+			_, exists := version.abi.Methods["getConfig"]
+			require.False(t, exists)
+			call, called := registryCalls(t, version, map[string][]interface{}{
+				"getState": {
+					uint32(7), big.NewInt(0), big.NewInt(0), big.NewInt(3),
+					uint32(100), uint32(0), big.NewInt(40), uint32(5_000_000), big.NewInt(3600), uint16(1), big.NewInt(0), uint32(5_000_000), big.NewInt(1), big.NewInt(1), common.Address{}, common.Address{},
+					keepers,
+				},
+			})
+
+			state, err := version.getRegistryState(call)
+			require.NoError(t, err)
+			require.Equal(t, registryState{BlockCountPerTurn: 40, CheckGas: 5_000_000, Keepers: keepers}, state)
+			require.Equal(t, []string{"getState"}, *called)
+		})
+	}
+}
+
+func TestRegistryVersion_GetUpkeepConfig(t *testing.T) {
+	t.Parallel()
+
+	target := common.HexToAddress("0x9E40733cC9df84636505f4e6Db28DCa0dC5D1bba")
+	responses := map[string][]interface{}{
+		"1.1": {target, uint32(100_000), []byte{0x12}, big.NewInt(1), common.Address{}, common.Address{}, uint64(1_000)},
+		"1.2": {target, uint32(100_000), []byte{0x12}, big.NewInt(1), common.Address{}, common.Address{}, uint64(1_000), big.NewInt(42)},
+		"1.3": {target, uint32(100_000), []byte{0x12}, big.NewInt(1), common.Address{}, common.Address{}, uint64(1_000), big.NewInt(42), true},
+	}
+	for name, response := range responses {
+		name, response := name, response
+		t.Run(name, func(t *testing.T) {
+			version, err := registryVersionFor(name)
+			require.NoError(t, err)
+			call := func(data []byte) ([]byte, error) {
+				return version.abi.Methods["getUpkeep"].Outputs.Pack(response...)
+			}
+
+			checkData, executeGas, err := version.getUpkeepConfig(call, 1)
+			require.NoError(t, err)
+			require.Equal(t, []byte{0x12}, checkData)
+			require.Equal(t, uint64(100_000), executeGas)
+		})
+	}
+}
+
+func TestRegistryVersionFor(t *testing.T) {
+	t.Parallel()
+
+	version, err := registryVersionFor("")
+	require.NoError(t, err)
+	require.Equal(t, DefaultRegistryVersion, version.name)
+
+	_, err = registryVersionFor("2.0")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "1.1, 1.2, 1.3")
+}
+
+func TestEstimateGasPrice_RegistryVersion(t *testing.T) {
+	t.Parallel()
+
+	estimator := new(gasmocks.Estimator)
+	estimator.On("EstimateGas", mock.Anything, mock.Anything).Return(assets.GWei(60), uint64(0), nil)
+	ex := newTestExecuter(t, testExecuterDeps{estimator: estimator})
+	head := eth.Head{Number: 20}
+
+	upkeep := UpkeepRegistration{UpkeepID: 1, ExecuteGas: 100_000, Registry: Registry{Version: "1.3"}}
+	_, _, err := ex.estimateGasPrice(context.Background(), upkeep, head, []byte{0x12, 0x34}, nil)
+	require.NoError(t, err)
+	v1_3, err := registryVersionFor("1.3")
+	require.NoError(t, err)
+	expected, err := v1_3.packPerformUpkeep(1, []byte{0x12, 0x34})
+	require.NoError(t, err)
+	require.Equal(t, expected, estimator.Calls[0].Arguments.Get(0))
+
+	upkeep.Registry.Version = "2.0"
+	_, _, err = ex.estimateGasPrice(context.Background(), upkeep, head, []byte{0x12, 0x34}, nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,74 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+// startBatch records head as the head whose upkeeps are about to execute, returning the context that
+// is cancelled if a reorg replaces it. It must only be called from the run goroutine.
+func (ex *UpkeepExecuter) startBatch(head eth.Head) context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	ex.batchMu.Lock()
+	defer ex.batchMu.Unlock()
+	ex.batchHead, ex.cancelBatch = &head, cancel
+	return ctx
+}
+
+// endBatch releases the context of the batch once all its executions have finished
+func (ex *UpkeepExecuter) endBatch() {
+	ex.batchMu.Lock()
+	defer ex.batchMu.Unlock()
+	if ex.cancelBatch != nil {
+		ex.cancelBatch()
+	}
+	ex.batchHead, ex.cancelBatch = nil, nil
+}
+
+// startTriggerBatch records head, the block of a batch of trigger logs, as a head whose upkeeps are about to
+// execute like startBatch does. Log-triggered executions run alongside the batch of the last processed head,
+// so they are tracked separately. release must be called once the executions have finished.
+func (ex *UpkeepExecuter) startTriggerBatch(head eth.Head) (ctx context.Context, release func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ex.batchMu.Lock()
+	defer ex.batchMu.Unlock()
+	if ex.triggerBatches == nil {
+		ex.triggerBatches = make(map[*eth.Head]context.CancelFunc)
+	}
+	triggerHead := &head
+	ex.triggerBatches[triggerHead] = cancel
+	return ctx, func() {
+		ex.batchMu.Lock()
+		defer ex.batchMu.Unlock()
+		cancel()
+		delete(ex.triggerBatches, triggerHead)
+	}
+}
+
+// cancelReorgedBatch cancels the in-flight executions, log-triggered ones included, if head doesn't extend
+// the head they were triggered on, so that no perform is broadcast for a block that is no longer part of the chain.
+// It is called as heads arrive, while the run goroutine may be waiting on the executions.
+func (ex *UpkeepExecuter) cancelReorgedBatch(head eth.Head) {
+	ex.batchMu.Lock()
+	defer ex.batchMu.Unlock()
+	for triggerHead, cancel := range ex.triggerBatches {
+		if !replaces(head, *triggerHead) {
+			continue
+		}
+		ex.logger.Warnw("reorg replaced the block of in-flight log-triggered executions, cancelling them",
+			"blockheight", triggerHead.Number, "hash", triggerHead.Hash.Hex(),
+			"newBlockheight", head.Number, "newHash", head.Hash.Hex())
+		cancel()
+		delete(ex.triggerBatches, triggerHead)
+	}
+	if ex.batchHead == nil || !replaces(head, *ex.batchHead) {
+		return
+	}
+	ex.logger.Warnw("reorg replaced the head of in-flight upkeep executions, cancelling them",
+		"blockheight", ex.batchHead.Number, "hash", ex.batchHead.Hash.Hex(),
+		"newBlockheight", head.Number, "newHash", head.Hash.Hex())
+	ex.cancelBatch()
+	// the batch is only cancelled once, endBatch still releases it
+	ex.batchHead = nil
+}
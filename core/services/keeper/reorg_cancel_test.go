@@ -0,0 +1,162 @@
+package keeper
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	pipelinemocks "github.com/smartcontractkit/chainlink/core/services/pipeline/mocks"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+func TestUpkeepExecuter_CancelsExecutionsOnReorg(t *testing.T) {
+	t.Parallel()
+
+	// newExecuter returns an executer whose pipeline runs block until their context is cancelled,
+	// the returned channel receives the context of every run once it started
+	newExecuter := func(t *testing.T) (*UpkeepExecuter, chan context.Context) {
+		runs := make(chan context.Context, 1)
+		runner := new(pipelinemocks.Runner)
+		runner.On("Run", mock.Anything, mock.Anything, mock.Anything, true, mock.Anything).
+			Run(func(args mock.Arguments) {
+				ctx := args.Get(0).(context.Context)
+				runs <- ctx
+				<-ctx.Done()
+			}).
+			Return(false, context.Canceled)
+		return newTestExecuter(t, testExecuterDeps{db: pgtest.NewGormDB(t), runner: runner}), runs
+	}
+	// execute executes an upkeep triggered on head, returning a channel that receives its outcome
+	execute := func(ex *UpkeepExecuter, ctxBatch context.Context, head eth.Head) chan executionOutcome {
+		outcomes := make(chan executionOutcome, 1)
+		checked := &checkUpkeepResult{PerformData: []byte{}, MaxLinkPayment: big.NewInt(1)}
+		upkeep := UpkeepRegistration{UpkeepID: 1, ExecuteGas: 100_000}
+		go ex.execute(ctxBatch, upkeep, head, time.Now(), false, checked, nil, nil, func(outcome executionOutcome) { outcomes <- outcome })
+		return outcomes
+	}
+	head := eth.Head{Number: 20, Hash: utils.NewHash(), ParentHash: utils.NewHash()}
+
+	t.Run("cancels the runs of a head replaced by a reorg", func(t *testing.T) {
+		ex, runs := newExecuter(t)
+		ctxBatch := ex.startBatch(head)
+		outcomes := execute(ex, ctxBatch, head)
+
+		var ctxRun context.Context
+		select {
+		case ctxRun = <-runs:
+		case <-time.After(5 * time.Second):
+			t.Fatal("pipeline run wasn't started")
+		}
+		// a sibling of the head the run was triggered on
+		ex.OnNewLongestChain(context.Background(), eth.Head{Number: 20, Hash: utils.NewHash(), ParentHash: head.ParentHash})
+
+		select {
+		case outcome := <-outcomes:
+			require.Equal(t, executionSkipped, outcome)
+		case <-time.After(5 * time.Second):
+			t.Fatal("execution wasn't cancelled")
+		}
+		require.Equal(t, context.Canceled, ctxRun.Err())
+		require.Equal(t, context.Canceled, ctxBatch.Err())
+		ex.endBatch()
+	})
+
+	t.Run("heads extending the head don't cancel its runs", func(t *testing.T) {
+		ex, runs := newExecuter(t)
+		ctxBatch := ex.startBatch(head)
+		execute(ex, ctxBatch, head)
+
+		var ctxRun context.Context
+		select {
+		case ctxRun = <-runs:
+		case <-time.After(5 * time.Second):
+			t.Fatal("pipeline run wasn't started")
+		}
+		ex.OnNewLongestChain(context.Background(), eth.Head{Number: 21, Hash: utils.NewHash(), ParentHash: head.Hash})
+		ex.OnNewLongestChain(context.Background(), head)
+
+		require.NoError(t, ctxRun.Err())
+		require.NoError(t, ctxBatch.Err())
+		ex.endBatch()
+		require.Error(t, ctxRun.Err())
+	})
+}
+
+func TestUpkeepExecuter_CancelsLogTriggeredExecutionsOnReorg(t *testing.T) {
+	t.Parallel()
+
+	ex := newTestExecuter(t, testExecuterDeps{})
+	triggerHead := eth.Head{Number: 20, Hash: utils.NewHash()}
+
+	t.Run("cancels the executions of a block replaced by a reorg", func(t *testing.T) {
+		ctx, release := ex.startTriggerBatch(triggerHead)
+		defer release()
+
+		// a sibling of the block of the trigger log
+		ex.OnNewLongestChain(context.Background(), eth.Head{Number: 20, Hash: utils.NewHash()})
+		require.Error(t, ctx.Err())
+	})
+
+	t.Run("keeps the executions of a block extended by the new head", func(t *testing.T) {
+		ctx, release := ex.startTriggerBatch(triggerHead)
+
+		ex.OnNewLongestChain(context.Background(), eth.Head{Number: 21, Hash: utils.NewHash(), ParentHash: triggerHead.Hash})
+		require.NoError(t, ctx.Err())
+
+		release()
+		require.Error(t, ctx.Err())
+		ex.batchMu.Lock()
+		defer ex.batchMu.Unlock()
+		require.Empty(t, ex.triggerBatches)
+	})
+}
+
+func TestUpkeepExecuter_ObserveReorg(t *testing.T) {
+	t.Parallel()
+
+	previous := eth.Head{Number: 20, Hash: utils.NewHash(), ParentHash: utils.NewHash()}
+	tests := []struct {
+		name  string
+		head  eth.Head
+		reorg bool
+	}{
+		{"the previous head again", previous, false},
+		{"child of the previous head", eth.Head{Number: 21, Hash: utils.NewHash(), ParentHash: previous.Hash}, false},
+		{"next head of another chain", eth.Head{Number: 21, Hash: utils.NewHash(), ParentHash: utils.NewHash()}, true},
+		{"sibling of the previous head", eth.Head{Number: 20, Hash: utils.NewHash(), ParentHash: previous.ParentHash}, true},
+		{"head behind the previous head", eth.Head{Number: 18, Hash: utils.NewHash()}, true},
+		// heads may have been skipped on the same chain
+		{"head further ahead", eth.Head{Number: 25, Hash: utils.NewHash(), ParentHash: utils.NewHash()}, false},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := &recordingMetrics{}
+			ex := newTestExecuter(t, testExecuterDeps{metrics: recorder})
+			ex.lastHead = &previous
+			require.Equal(t, test.reorg, ex.isReorg(test.head))
+
+			ex.observeReorg(test.head, 3)
+			if test.reorg {
+				require.Equal(t, 3, recorder.reorgReevaluations)
+			} else {
+				require.Zero(t, recorder.reorgReevaluations)
+			}
+		})
+	}
+
+	t.Run("the first head isn't a reorg", func(t *testing.T) {
+		recorder := &recordingMetrics{}
+		ex := newTestExecuter(t, testExecuterDeps{metrics: recorder})
+		ex.observeReorg(previous, 3)
+		require.Zero(t, recorder.reorgReevaluations)
+	})
+}
@@ -0,0 +1,304 @@
+package keeper
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"math/big"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+// ReportFormat is the encoding of the report returned by GenerateReport
+type ReportFormat string
+
+const (
+	ReportFormatJSON ReportFormat = "json"
+	ReportFormatCSV  ReportFormat = "csv"
+)
+
+// ReportOpts are the options of GenerateReport
+type ReportOpts struct {
+	// Format is the encoding of the report, ReportFormatJSON if it's empty
+	Format ReportFormat
+}
+
+// Report summarizes the dry run replay of a block range by GenerateReport.
+// Performed counts the upkeeps that would have been performed, the replay never performs them.
+type Report struct {
+	FromBlock int64 `json:"fromBlock"`
+	ToBlock   int64 `json:"toBlock"`
+	// Heads counts the replayed blocks, blocks the eth node doesn't have are left out
+	Heads     int `json:"heads"`
+	Eligible  int `json:"eligible"`
+	Performed int `json:"performed"`
+	Skipped   int `json:"skipped"`
+	Failed    int `json:"failed"`
+	// EstimatedGasSpend is the gas price times the gas limit of the performs, in wei
+	EstimatedGasSpend *utils.Big       `json:"estimatedGasSpend"`
+	Upkeeps           []UpkeepReport   `json:"upkeeps"`
+	Decisions         []DecisionRecord `json:"decisions"`
+}
+
+// UpkeepReport holds the counts of a Report for a single upkeep
+type UpkeepReport struct {
+	RegistryAddress   common.Address `json:"registryAddress"`
+	UpkeepID          int64          `json:"upkeepID"`
+	Eligible          int            `json:"eligible"`
+	Performed         int            `json:"performed"`
+	Skipped           int            `json:"skipped"`
+	Failed            int            `json:"failed"`
+	EstimatedGasSpend *utils.Big     `json:"estimatedGasSpend"`
+}
+
+// DecisionRecord is what the replay decided for an upkeep eligible on a block
+type DecisionRecord struct {
+	BlockNumber     int64          `json:"blockNumber"`
+	RegistryAddress common.Address `json:"registryAddress"`
+	UpkeepID        int64          `json:"upkeepID"`
+	Reason          string         `json:"reason"`
+	Outcome         string         `json:"outcome"`
+	GasPrice        *utils.Big     `json:"gasPrice,omitempty"`
+	GasLimit        uint64         `json:"gasLimit,omitempty"`
+}
+
+// GenerateReport replays the blocks fromBlock to toBlock in dry run and returns a report of the decisions
+// made for the upkeeps eligible on them, encoded in opts.Format. Eligibility is evaluated against the
+// upkeep registrations in the database as they are now, and the performs are priced by the gas estimator
+// as it is now. The upkeeps are checked at the replayed blocks, which requires an archive node for old
+// blocks. The range may span at most KeeperReportMaxBlocks blocks, replaying stops when ctx is done.
+func (ex *UpkeepExecuter) GenerateReport(ctx context.Context, fromBlock, toBlock int64, opts ReportOpts) ([]byte, error) {
+	if fromBlock < 0 || toBlock < fromBlock {
+		return nil, errors.Errorf("invalid block range %d to %d", fromBlock, toBlock)
+	}
+	if maxBlocks := int64(ex.config.KeeperReportMaxBlocks()); toBlock-fromBlock+1 > maxBlocks {
+		return nil, errors.Errorf("block range %d to %d exceeds KeeperReportMaxBlocks of %d blocks", fromBlock, toBlock, maxBlocks)
+	}
+	format := opts.Format
+	if format == "" {
+		format = ReportFormatJSON
+	}
+	if format != ReportFormatJSON && format != ReportFormatCSV {
+		return nil, errors.Errorf("unsupported report format %q", format)
+	}
+
+	report, err := ex.replay(ctx, fromBlock, toBlock)
+	if err != nil {
+		return nil, err
+	}
+	if format == ReportFormatCSV {
+		return report.csv()
+	}
+	return json.Marshal(report)
+}
+
+// replay executes the upkeeps eligible on the blocks fromBlock to toBlock with a replay executer
+func (ex *UpkeepExecuter) replay(ctx context.Context, fromBlock, toBlock int64) (Report, error) {
+	sink := &decisionSink{}
+	replayer := ex.newReplayExecuter(sink)
+	defer close(replayer.chStop)
+
+	for blockNumber := fromBlock; blockNumber <= toBlock; blockNumber++ {
+		if err := ctx.Err(); err != nil {
+			return Report{}, errors.Wrapf(err, "replay cancelled at block %d", blockNumber)
+		}
+		head, err := ex.ethClient.HeadByNumber(ctx, big.NewInt(blockNumber))
+		if err != nil {
+			return Report{}, errors.Wrapf(err, "unable to get block %d", blockNumber)
+		}
+		if head == nil {
+			ex.logger.Debugw("block to replay not found, leaving it out of the report", "blockheight", blockNumber)
+			continue
+		}
+		sink.heads++
+
+		upkeeps, err := replayer.eligibleUpkeepsAt(head.Number)
+		if err != nil {
+			return Report{}, errors.Wrapf(err, "unable to load the upkeeps eligible on block %d", blockNumber)
+		}
+		upkeeps, _ = replayer.excludePausedRegistries(*head, upkeeps)
+		// the executions of the previous block have finished, and the executions of this block
+		// are started after its replay block is set
+		replayer.replayBlock = big.NewInt(head.Number)
+		replayer.processRegistries(upkeeps, func(upkeeps []UpkeepRegistration) {
+			replayer.executeUpkeeps(ctx, *head, time.Now(), false, upkeeps, replayer.newHeadGasPrices(), &headSummary{})
+		})
+		if err := ctx.Err(); err != nil {
+			return Report{}, errors.Wrapf(err, "replay cancelled at block %d", blockNumber)
+		}
+	}
+	return sink.report(fromBlock, toBlock), nil
+}
+
+// newReplayExecuter returns an executer for the job of ex that executes upkeeps in dry run and emits
+// their decisions to sink, with its own per-upkeep state and unregistered metrics
+func (ex *UpkeepExecuter) newReplayExecuter(sink EventSink) *UpkeepExecuter {
+	replayer := NewUpkeepExecuter(
+		ex.job,
+		ex.orm,
+		ex.pr,
+		ex.ethClient,
+		nil,
+		ex.gasEstimator,
+		ex.gasStrategy,
+		ex.logger.Named("Replay"),
+		replayConfig{ex.config},
+		ex.queryLimiter,
+		prometheus.NewRegistry(),
+		nil,
+	)
+	replayer.events = sink
+	return replayer
+}
+
+// replayConfig is the config of a replay executer. Upkeeps are checked one by one, at the replayed block.
+type replayConfig struct {
+	Config
+}
+
+func (replayConfig) KeeperDryRun() bool           { return true }
+func (replayConfig) KeeperAuditDecisions() bool   { return true }
+func (replayConfig) KeeperBatchCheckUpkeep() bool { return false }
+
+// decisionSink collects the decision events of a replay
+type decisionSink struct {
+	mu        sync.Mutex
+	heads     int
+	decisions []DecisionRecord
+}
+
+func (s *decisionSink) Emit(e Event) {
+	if e.Type != EventDecision {
+		return
+	}
+	record := DecisionRecord{
+		BlockNumber:     e.BlockNumber,
+		RegistryAddress: e.RegistryAddress,
+		UpkeepID:        e.UpkeepID,
+	}
+	record.Reason, _ = e.Data["reason"].(string)
+	record.Outcome, _ = e.Data["outcome"].(string)
+	if gasPrice, ok := e.Data["gasPrice"].(string); ok {
+		if price, ok := new(big.Int).SetString(gasPrice, 10); ok {
+			record.GasPrice = utils.NewBig(price)
+		}
+	}
+	record.GasLimit, _ = e.Data["gasLimit"].(uint64)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.decisions = append(s.decisions, record)
+}
+
+// report tallies the collected decisions, the upkeeps that reached the dry run would have been performed
+func (s *decisionSink) report(fromBlock, toBlock int64) Report {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sort.SliceStable(s.decisions, func(i, j int) bool {
+		a, b := s.decisions[i], s.decisions[j]
+		if a.BlockNumber != b.BlockNumber {
+			return a.BlockNumber < b.BlockNumber
+		}
+		if a.RegistryAddress != b.RegistryAddress {
+			return a.RegistryAddress.Hex() < b.RegistryAddress.Hex()
+		}
+		return a.UpkeepID < b.UpkeepID
+	})
+	report := Report{
+		FromBlock:         fromBlock,
+		ToBlock:           toBlock,
+		Heads:             s.heads,
+		EstimatedGasSpend: utils.NewBigI(0),
+		Upkeeps:           []UpkeepReport{},
+		Decisions:         append([]DecisionRecord{}, s.decisions...),
+	}
+	upkeeps := make(map[upkeepKey]int)
+	for _, record := range s.decisions {
+		key := upkeepKey{registry: record.RegistryAddress, upkeepID: record.UpkeepID}
+		i, ok := upkeeps[key]
+		if !ok {
+			i = len(report.Upkeeps)
+			upkeeps[key] = i
+			report.Upkeeps = append(report.Upkeeps, UpkeepReport{
+				RegistryAddress:   record.RegistryAddress,
+				UpkeepID:          record.UpkeepID,
+				EstimatedGasSpend: utils.NewBigI(0),
+			})
+		}
+		upkeep := &report.Upkeeps[i]
+		report.Eligible++
+		upkeep.Eligible++
+		switch {
+		case record.Reason == decisionDryRun:
+			report.Performed++
+			upkeep.Performed++
+			if record.GasPrice != nil {
+				spend := new(big.Int).Mul(record.GasPrice.ToInt(), new(big.Int).SetUint64(record.GasLimit))
+				report.EstimatedGasSpend = utils.NewBig(new(big.Int).Add(report.EstimatedGasSpend.ToInt(), spend))
+				upkeep.EstimatedGasSpend = utils.NewBig(new(big.Int).Add(upkeep.EstimatedGasSpend.ToInt(), spend))
+			}
+		case record.Outcome == executionFailed.String():
+			report.Failed++
+			upkeep.Failed++
+		default:
+			report.Skipped++
+			upkeep.Skipped++
+		}
+	}
+	sort.SliceStable(report.Upkeeps, func(i, j int) bool {
+		a, b := report.Upkeeps[i], report.Upkeeps[j]
+		if a.RegistryAddress != b.RegistryAddress {
+			return a.RegistryAddress.Hex() < b.RegistryAddress.Hex()
+		}
+		return a.UpkeepID < b.UpkeepID
+	})
+	return report
+}
+
+// reportCSVHeader is the header of CSV reports. Each row is a record of the kind in its first column:
+// the totals of the report, the stats of an upkeep or a decision record.
+var reportCSVHeader = []string{
+	"record", "blockNumber", "registryAddress", "upkeepID", "eligible", "performed", "skipped", "failed",
+	"estimatedGasSpend", "reason", "outcome", "gasPrice", "gasLimit",
+}
+
+// csv encodes the report as CSV
+func (r Report) csv() ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	itoa := func(i int) string { return strconv.Itoa(i) }
+	rows := [][]string{
+		reportCSVHeader,
+		{"total", "", "", "", itoa(r.Eligible), itoa(r.Performed), itoa(r.Skipped), itoa(r.Failed), r.EstimatedGasSpend.String(), "", "", "", ""},
+	}
+	for _, u := range r.Upkeeps {
+		rows = append(rows, []string{
+			"upkeep", "", u.RegistryAddress.Hex(), strconv.FormatInt(u.UpkeepID, 10),
+			itoa(u.Eligible), itoa(u.Performed), itoa(u.Skipped), itoa(u.Failed), u.EstimatedGasSpend.String(), "", "", "", "",
+		})
+	}
+	for _, d := range r.Decisions {
+		gasPrice, gasLimit := "", ""
+		if d.GasPrice != nil {
+			gasPrice, gasLimit = d.GasPrice.String(), strconv.FormatUint(d.GasLimit, 10)
+		}
+		rows = append(rows, []string{
+			"decision", strconv.FormatInt(d.BlockNumber, 10), d.RegistryAddress.Hex(), strconv.FormatInt(d.UpkeepID, 10),
+			"", "", "", "", "", d.Reason, d.Outcome, gasPrice, gasLimit,
+		})
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return nil, errors.Wrap(err, "unable to write CSV report")
+	}
+	return buf.Bytes(), nil
+}
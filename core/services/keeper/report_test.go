@@ -0,0 +1,129 @@
+package keeper_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/configtest"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/services/keeper"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+func Test_UpkeepExecuter_GenerateReport(t *testing.T) {
+	t.Parallel()
+
+	setupReplay := func(t *testing.T) (*keeper.UpkeepExecuter, keeper.UpkeepRegistration, func() []*big.Int) {
+		db, _, ethMock, executer, registry, upkeep, job, _, txm := setup(t, func(c *configtest.TestGeneralConfig) {
+			c.Overrides.KeeperReportMaxBlocks = null.IntFrom(3)
+		})
+		for _, number := range []int64{20, 21} {
+			head := eth.NewHead(big.NewInt(number), utils.NewHash(), utils.NewHash(), 1000, utils.NewBigI(0))
+			ethMock.On("HeadByNumber", mock.Anything, big.NewInt(number)).Return(&head, nil)
+		}
+		ethMock.On("HeadByNumber", mock.Anything, big.NewInt(22)).Return(nil, nil)
+		registryMock := cltest.NewContractMockReceiver(t, ethMock, keeper.RegistryABI, registry.ContractAddress.Address())
+		registryMock.MockResponse("checkUpkeep", checkUpkeepResponse)
+		t.Cleanup(func() {
+			// the replay never performs
+			cltest.AssertPipelineRunsStays(t, job.PipelineSpecID, db, 0)
+			assertLastRunHeight(t, db, upkeep, 0)
+			txm.AssertNotCalled(t, "CreateEthTransaction", mock.Anything, mock.Anything)
+		})
+
+		checkBlocks := func() []*big.Int {
+			var blocks []*big.Int
+			for _, call := range ethMock.Calls {
+				if call.Method == "CallContract" {
+					blocks = append(blocks, call.Arguments.Get(2).(*big.Int))
+				}
+			}
+			return blocks
+		}
+		return executer, upkeep, checkBlocks
+	}
+
+	t.Run("replays the range in dry run", func(t *testing.T) {
+		executer, upkeep, checkBlocks := setupReplay(t)
+
+		out, err := executer.GenerateReport(context.Background(), 20, 22, keeper.ReportOpts{})
+		require.NoError(t, err)
+
+		var report keeper.Report
+		require.NoError(t, json.Unmarshal(out, &report))
+		// block 22 isn't known to the node
+		require.Equal(t, 2, report.Heads)
+		require.Equal(t, 2, report.Eligible)
+		require.Equal(t, 2, report.Performed)
+		require.Equal(t, 0, report.Skipped)
+		require.Len(t, report.Upkeeps, 1)
+		require.Equal(t, upkeep.UpkeepID, report.Upkeeps[0].UpkeepID)
+		require.Equal(t, 2, report.Upkeeps[0].Performed)
+
+		require.Len(t, report.Decisions, 2)
+		spend := new(big.Int)
+		for i, decision := range report.Decisions {
+			require.Equal(t, int64(20+i), decision.BlockNumber)
+			require.Equal(t, "dry-run", decision.Reason)
+			require.NotNil(t, decision.GasPrice)
+			spend.Add(spend, new(big.Int).Mul(decision.GasPrice.ToInt(), new(big.Int).SetUint64(decision.GasLimit)))
+		}
+		require.Equal(t, spend.String(), report.EstimatedGasSpend.String())
+		require.Equal(t, spend.String(), report.Upkeeps[0].EstimatedGasSpend.String())
+
+		// the upkeeps are checked at the replayed blocks
+		require.Equal(t, []*big.Int{big.NewInt(20), big.NewInt(21)}, checkBlocks())
+	})
+
+	t.Run("encodes the report as CSV", func(t *testing.T) {
+		executer, upkeep, _ := setupReplay(t)
+
+		out, err := executer.GenerateReport(context.Background(), 20, 21, keeper.ReportOpts{Format: keeper.ReportFormatCSV})
+		require.NoError(t, err)
+
+		rows, err := csv.NewReader(bytes.NewReader(out)).ReadAll()
+		require.NoError(t, err)
+		// header, totals, one upkeep and its two decisions
+		require.Len(t, rows, 5)
+		require.Equal(t, "record", rows[0][0])
+		require.Equal(t, []string{"total", "2", "2"}, []string{rows[1][0], rows[1][4], rows[1][5]})
+		require.Equal(t, "upkeep", rows[2][0])
+		require.Equal(t, upkeep.Registry.ContractAddress.Hex(), rows[2][2])
+		require.Equal(t, []string{"decision", "20", "dry-run"}, []string{rows[3][0], rows[3][1], rows[3][9]})
+		require.Equal(t, []string{"decision", "21", "dry-run"}, []string{rows[4][0], rows[4][1], rows[4][9]})
+	})
+
+	t.Run("bounds the range", func(t *testing.T) {
+		executer, _, _ := setupReplay(t)
+
+		_, err := executer.GenerateReport(context.Background(), 20, 23, keeper.ReportOpts{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "KeeperReportMaxBlocks")
+
+		_, err = executer.GenerateReport(context.Background(), 21, 20, keeper.ReportOpts{})
+		require.Error(t, err)
+
+		_, err = executer.GenerateReport(context.Background(), 20, 21, keeper.ReportOpts{Format: "xml"})
+		require.Error(t, err)
+	})
+
+	t.Run("is cancellable", func(t *testing.T) {
+		executer, _, checkBlocks := setupReplay(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := executer.GenerateReport(ctx, 20, 21, keeper.ReportOpts{})
+		require.True(t, errors.Is(err, context.Canceled))
+		require.Empty(t, checkBlocks())
+	})
+}
@@ -0,0 +1,66 @@
+package keeper
+
+import (
+	"strings"
+
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+// notNeededRevertReason is the reason checkUpkeep reverts with when an upkeep has nothing to perform.
+// It is the expected outcome of most simulations and doesn't indicate a problem with the upkeep.
+const notNeededRevertReason = "upkeep not needed"
+
+// recordSimulation tracks the consecutive heads on which the checkUpkeep simulation of an eligible
+// upkeep reverted for an unexpected reason. When the streak reaches KeeperSimRevertStreakThreshold
+// an alert is raised and, if KeeperSimRevertAutoDisable is set, the upkeep is disabled.
+func (ex *UpkeepExecuter) recordSimulation(upkeep UpkeepRegistration, head eth.Head, err error) {
+	threshold := ex.config.KeeperSimRevertStreakThreshold()
+	if threshold == 0 {
+		return
+	}
+
+	ex.simRevertMu.Lock()
+	if err == nil || strings.Contains(err.Error(), notNeededRevertReason) {
+		ex.simRevertStreaks.delete(upkeep.key())
+		ex.simRevertMu.Unlock()
+		return
+	}
+	var streak uint32
+	if value, ok := ex.simRevertStreaks.get(upkeep.key()); ok {
+		streak = value.(uint32)
+	}
+	streak++
+	ex.simRevertStreaks.set(upkeep.key(), streak)
+	disable := streak >= threshold && ex.config.KeeperSimRevertAutoDisable()
+	if disable {
+		ex.disabledUpkeeps.set(upkeep.key(), struct{}{})
+	}
+	ex.simRevertMu.Unlock()
+
+	if streak != threshold {
+		return
+	}
+	ex.logger.Errorw("upkeep simulation keeps reverting, the upkeep is likely broken",
+		"upkeepID", upkeep.UpkeepID, "blockNum", head.Number, "streak", streak, "disabled", disable, "error", err)
+	ex.events.Emit(Event{
+		Type:            EventSimRevertStreak,
+		RegistryAddress: upkeep.Registry.ContractAddress.Address(),
+		UpkeepID:        upkeep.UpkeepID,
+		BlockNumber:     head.Number,
+		Data:            map[string]interface{}{"streak": streak, "disabled": disable, "error": err.Error()},
+	})
+}
+
+// isUpkeepDisabled returns true if the upkeep was disabled by KeeperSimRevertAutoDisable
+func (ex *UpkeepExecuter) isUpkeepDisabled(upkeep UpkeepRegistration) bool {
+	ex.simRevertMu.Lock()
+	defer ex.simRevertMu.Unlock()
+	_, disabled := ex.disabledUpkeeps.get(upkeep.key())
+	return disabled
+}
+
+func (ex *UpkeepExecuter) disabledUpkeepCount() int {
+	ex.simRevertMu.Lock()
+	defer ex.simRevertMu.Unlock()
+	return ex.disabledUpkeeps.len()
+}
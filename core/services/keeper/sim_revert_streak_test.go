@@ -0,0 +1,69 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/configtest"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+func TestUpkeepExecuter_RecordSimulation(t *testing.T) {
+	t.Parallel()
+
+	upkeep := UpkeepRegistration{UpkeepID: 1}
+	reverted := errors.New("checkUpkeep call failed: execution reverted: target reverted")
+	notNeeded := errors.New("checkUpkeep call failed: execution reverted: " + notNeededRevertReason)
+	newExecuter := func(t *testing.T, autoDisable bool) (*UpkeepExecuter, *recordingEventSink) {
+		sink := &recordingEventSink{}
+		ex := newTestExecuter(t, testExecuterDeps{events: sink}, func(c *configtest.TestGeneralConfig) {
+			c.Overrides.KeeperSimRevertStreakThreshold = null.IntFrom(3)
+			c.Overrides.KeeperSimRevertAutoDisable = null.BoolFrom(autoDisable)
+		})
+		return ex, sink
+	}
+
+	t.Run("alerts once the streak reaches the threshold", func(t *testing.T) {
+		ex, sink := newExecuter(t, false)
+
+		for i := int64(0); i < 4; i++ {
+			ex.recordSimulation(upkeep, eth.Head{Number: 20 + i}, reverted)
+		}
+		events := sink.ofType(EventSimRevertStreak)
+		require.Len(t, events, 1)
+		require.Equal(t, int64(22), events[0].BlockNumber)
+		require.Equal(t, uint32(3), events[0].Data["streak"])
+		require.Equal(t, false, events[0].Data["disabled"])
+		require.False(t, ex.isUpkeepDisabled(upkeep))
+	})
+
+	t.Run("expected reverts and successes end the streak", func(t *testing.T) {
+		ex, sink := newExecuter(t, false)
+
+		ex.recordSimulation(upkeep, eth.Head{Number: 20}, reverted)
+		ex.recordSimulation(upkeep, eth.Head{Number: 21}, reverted)
+		ex.recordSimulation(upkeep, eth.Head{Number: 22}, notNeeded)
+		ex.recordSimulation(upkeep, eth.Head{Number: 23}, reverted)
+		ex.recordSimulation(upkeep, eth.Head{Number: 24}, reverted)
+		ex.recordSimulation(upkeep, eth.Head{Number: 25}, nil)
+		ex.recordSimulation(upkeep, eth.Head{Number: 26}, reverted)
+		require.Empty(t, sink.ofType(EventSimRevertStreak))
+	})
+
+	t.Run("disables the upkeep with KeeperSimRevertAutoDisable", func(t *testing.T) {
+		ex, sink := newExecuter(t, true)
+
+		for i := int64(0); i < 3; i++ {
+			require.False(t, ex.isUpkeepDisabled(upkeep))
+			ex.recordSimulation(upkeep, eth.Head{Number: 20 + i}, reverted)
+		}
+		require.True(t, ex.isUpkeepDisabled(upkeep))
+		require.False(t, ex.isUpkeepDisabled(UpkeepRegistration{UpkeepID: 2}))
+		events := sink.ofType(EventSimRevertStreak)
+		require.Len(t, events, 1)
+		require.Equal(t, true, events[0].Data["disabled"])
+	})
+}
@@ -0,0 +1,133 @@
+package keeper
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/configtest"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+// blockingClient blocks every eth_call until release is closed, counting the calls in flight
+type blockingClient struct {
+	*eth.NullClient
+	release chan struct{}
+
+	mu        sync.Mutex
+	active    int
+	maxActive int
+	calls     int
+}
+
+func (c *blockingClient) call(ctx context.Context) {
+	c.mu.Lock()
+	c.active++
+	c.calls++
+	if c.active > c.maxActive {
+		c.maxActive = c.active
+	}
+	c.mu.Unlock()
+	select {
+	case <-c.release:
+	case <-ctx.Done():
+	}
+	c.mu.Lock()
+	c.active--
+	c.mu.Unlock()
+}
+
+func (c *blockingClient) CallContract(ctx context.Context, _ ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	c.call(ctx)
+	return nil, errors.New("reverted")
+}
+
+func (c *blockingClient) BatchCallContext(ctx context.Context, _ []rpc.BatchElem) error {
+	c.call(ctx)
+	return errors.New("batch failed")
+}
+
+func (c *blockingClient) inFlight() (active, calls int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.active, c.calls
+}
+
+func TestUpkeepExecuter_SimulationConcurrency(t *testing.T) {
+	t.Parallel()
+
+	const concurrency = 2
+	client := &blockingClient{NullClient: &eth.NullClient{CID: big.NewInt(1)}, release: make(chan struct{})}
+	ex := newTestExecuter(t, testExecuterDeps{ethClient: client}, func(c *configtest.TestGeneralConfig) {
+		c.Overrides.KeeperSimulationConcurrency = null.IntFrom(concurrency)
+	})
+	upkeep := UpkeepRegistration{UpkeepID: 1}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// one more simulation than there are slots, mixing every kind of simulation call
+	simulations := []func(){
+		func() { _, _ = ex.simulateUpkeep(ctx, upkeep, assets.GWei(60)) },
+		func() { _ = ex.simulatePerformUpkeep(ctx, upkeep, testPerformData, 500_000) },
+		func() {
+			_ = ex.checkUpkeepBatch(ctx, eth.Head{Number: 1}, []UpkeepRegistration{upkeep}, CheckBlockTagLatest, nil, map[upkeepKey]checkUpkeepResult{})
+		},
+	}
+	var wg sync.WaitGroup
+	for _, simulate := range simulations {
+		wg.Add(1)
+		go func(simulate func()) {
+			defer wg.Done()
+			simulate()
+		}(simulate)
+	}
+
+	require.Eventually(t, func() bool {
+		active, _ := client.inFlight()
+		return active == concurrency
+	}, 5*time.Second, 10*time.Millisecond)
+	// the remaining simulation waits for a slot
+	require.Never(t, func() bool {
+		active, calls := client.inFlight()
+		return active > concurrency || calls > concurrency
+	}, 200*time.Millisecond, 10*time.Millisecond)
+
+	close(client.release)
+	wg.Wait()
+
+	_, calls := client.inFlight()
+	require.Equal(t, len(simulations), calls)
+	require.Equal(t, concurrency, client.maxActive)
+}
+
+func TestUpkeepExecuter_SimulationConcurrency_Unlimited(t *testing.T) {
+	t.Parallel()
+
+	client := &blockingClient{NullClient: &eth.NullClient{CID: big.NewInt(1)}, release: make(chan struct{})}
+	ex := newTestExecuter(t, testExecuterDeps{ethClient: client})
+	require.Nil(t, ex.simulationQueue)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = ex.simulateUpkeep(context.Background(), UpkeepRegistration{UpkeepID: 1}, assets.GWei(60))
+		}()
+	}
+	require.Eventually(t, func() bool {
+		active, _ := client.inFlight()
+		return active == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	close(client.release)
+	wg.Wait()
+}
@@ -0,0 +1,20 @@
+package keeper
+
+import (
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+// observeSkippedHeads logs and counts the heads between the previously retrieved head and head. The mailbox
+// only holds the latest head, so heads arriving faster than they are processed replace each other and the
+// executer never sees the turns they held. It must only be called from the run goroutine.
+func (ex *UpkeepExecuter) observeSkippedHeads(head eth.Head) {
+	last := ex.lastRetrievedHead
+	ex.lastRetrievedHead = head.Number
+	if last == 0 || head.Number <= last+1 {
+		return
+	}
+	skipped := head.Number - last - 1
+	ex.logger.Infow("heads were replaced in the mailbox before they could be processed",
+		"blockheight", head.Number, "skippedFrom", last+1, "skippedTo", head.Number-1, "skipped", skipped)
+	ex.metrics.AddSkippedHeads(ex.job.KeeperSpec.ContractAddress.Hex(), int(skipped))
+}
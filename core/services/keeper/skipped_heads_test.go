@@ -0,0 +1,66 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+func TestObserveSkippedHeads(t *testing.T) {
+	t.Parallel()
+
+	recorder := &recordingMetrics{}
+	ex := newTestExecuter(t, testExecuterDeps{metrics: recorder})
+	retrieve := func() int64 {
+		item, exists := ex.mailbox.Retrieve()
+		require.True(t, exists)
+		head := item.(arrivedHead).Head
+		ex.observeSkippedHeads(head)
+		return head.Number
+	}
+
+	ex.deliverHead(eth.Head{Number: 10})
+	require.Equal(t, int64(10), retrieve())
+	require.Zero(t, recorder.skippedHeads)
+
+	// three heads arriving before the executer gets to them, only the latest is processed
+	ex.deliverHead(eth.Head{Number: 11})
+	ex.deliverHead(eth.Head{Number: 12})
+	ex.deliverHead(eth.Head{Number: 13})
+	require.Equal(t, int64(13), retrieve())
+	require.Equal(t, 2, recorder.skippedHeads)
+
+	ex.deliverHead(eth.Head{Number: 14})
+	require.Equal(t, int64(14), retrieve())
+	require.Equal(t, 2, recorder.skippedHeads)
+
+	t.Run("reorgs aren't counted", func(t *testing.T) {
+		ex.deliverHead(eth.Head{Number: 12})
+		require.Equal(t, int64(12), retrieve())
+		require.Equal(t, 2, recorder.skippedHeads)
+	})
+}
+
+func TestOnEmptyRetrieve(t *testing.T) {
+	t.Parallel()
+
+	recorder := &recordingMetrics{}
+	ex := newTestExecuter(t, testExecuterDeps{metrics: recorder})
+
+	for i := 0; i < emptyMailboxWarnThreshold; i++ {
+		ex.onEmptyRetrieve()
+	}
+	require.Equal(t, emptyMailboxWarnThreshold, recorder.emptyMailboxRetrieves)
+	require.Equal(t, emptyMailboxWarnThreshold, ex.emptyRetrieves)
+
+	t.Run("restarts counting once the window has passed", func(t *testing.T) {
+		ex.emptyRetrievesSince = time.Now().Add(-emptyMailboxWarnWindow - time.Second)
+		ex.onEmptyRetrieve()
+		require.Equal(t, emptyMailboxWarnThreshold+1, recorder.emptyMailboxRetrieves)
+		require.Equal(t, 1, ex.emptyRetrieves)
+		require.WithinDuration(t, time.Now(), ex.emptyRetrievesSince, time.Minute)
+	})
+}
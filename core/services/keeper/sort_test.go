@@ -0,0 +1,53 @@
+package keeper
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortByOverdue(t *testing.T) {
+	t.Parallel()
+
+	upkeeps := []UpkeepRegistration{
+		{UpkeepID: 1, LastRunBlockHeight: 30},
+		{UpkeepID: 2, LastRunBlockHeight: 10},
+		{UpkeepID: 3, LastRunBlockHeight: 0},
+		{UpkeepID: 4, LastRunBlockHeight: 10},
+		{UpkeepID: 5, LastRunBlockHeight: 20},
+	}
+	sortByOverdue(upkeeps)
+
+	var upkeepIDs []int64
+	for _, upkeep := range upkeeps {
+		upkeepIDs = append(upkeepIDs, upkeep.UpkeepID)
+	}
+	// ties keep the order of the eligibility query
+	require.Equal(t, []int64{3, 2, 4, 5, 1}, upkeepIDs)
+}
+
+func TestUpkeepExecuter_SortByValue(t *testing.T) {
+	t.Parallel()
+
+	ex := newTestExecuter(t, testExecuterDeps{})
+	upkeeps := []UpkeepRegistration{
+		{UpkeepID: 1},
+		{UpkeepID: 2},
+		{UpkeepID: 3},
+		{UpkeepID: 4},
+		{UpkeepID: 5},
+	}
+	ex.setUpkeepValue(upkeeps[0], big.NewInt(10))
+	ex.setUpkeepValue(upkeeps[1], big.NewInt(30))
+	ex.setUpkeepValue(upkeeps[3], big.NewInt(10))
+	ex.setUpkeepValue(upkeeps[4], big.NewInt(20))
+	ex.sortByValue(upkeeps)
+
+	var upkeepIDs []int64
+	for _, upkeep := range upkeeps {
+		upkeepIDs = append(upkeepIDs, upkeep.UpkeepID)
+	}
+	// unchecked upkeeps go first, ties keep the order of the eligibility query
+	require.Equal(t, []int64{3, 2, 5, 1, 4}, upkeepIDs)
+}
@@ -0,0 +1,38 @@
+package keeper
+
+// turnWindow is the block range, inclusive of both ends, within which this node holds the turn for an upkeep
+type turnWindow struct {
+	startBlock int64
+	endBlock   int64
+}
+
+// nextTurnWindow returns the turn window of upkeep containing blockNumber if this node holds the turn on it,
+// or the next one otherwise. It replicates the turn-taking of EligibleUpkeepsForRegistry: the keeper at
+// (positioningConstant + turn) % numKeepers holds the turn, where turn is blockNumber / blockCountPerTurn.
+// It returns false if the registry hasn't been synced with a turn configuration.
+func nextTurnWindow(upkeep UpkeepRegistration, blockNumber int64) (turnWindow, bool) {
+	blockCountPerTurn := int64(upkeep.Registry.BlockCountPerTurn)
+	numKeepers := int64(upkeep.Registry.NumKeepers)
+	if blockCountPerTurn <= 0 || numKeepers <= 0 {
+		return turnWindow{}, false
+	}
+	turn := blockNumber / blockCountPerTurn
+	holder := (int64(upkeep.PositioningConstant) + turn) % numKeepers
+	turnsUntilHeld := ((int64(upkeep.Registry.KeeperIndex)-holder)%numKeepers + numKeepers) % numKeepers
+	start := (turn + turnsUntilHeld) * blockCountPerTurn
+	return turnWindow{startBlock: start, endBlock: start + blockCountPerTurn - 1}, true
+}
+
+// addTurnWindow adds the next turn window of upkeep on blockNumber to the data of an event
+func addTurnWindow(data map[string]interface{}, upkeep UpkeepRegistration, blockNumber int64) map[string]interface{} {
+	window, ok := nextTurnWindow(upkeep, blockNumber)
+	if !ok {
+		return data
+	}
+	if data == nil {
+		data = make(map[string]interface{}, 2)
+	}
+	data["turnStartBlock"] = window.startBlock
+	data["turnEndBlock"] = window.endBlock
+	return data
+}
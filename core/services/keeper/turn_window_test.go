@@ -0,0 +1,50 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextTurnWindow(t *testing.T) {
+	t.Parallel()
+
+	upkeep := func(keeperIndex, numKeepers, positioningConstant int32) UpkeepRegistration {
+		return UpkeepRegistration{
+			PositioningConstant: positioningConstant,
+			Registry: Registry{
+				BlockCountPerTurn: 20,
+				KeeperIndex:       keeperIndex,
+				NumKeepers:        numKeepers,
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		upkeep      UpkeepRegistration
+		blockNumber int64
+		want        turnWindow
+	}{
+		{"holds the turn", upkeep(0, 3, 0), 5, turnWindow{0, 19}},
+		{"holds the turn on its last block", upkeep(0, 3, 0), 19, turnWindow{0, 19}},
+		{"next turn", upkeep(1, 3, 0), 5, turnWindow{20, 39}},
+		{"turn after next", upkeep(2, 3, 0), 5, turnWindow{40, 59}},
+		{"just missed the turn", upkeep(0, 3, 0), 20, turnWindow{60, 79}},
+		{"positioning constant shifts the turn", upkeep(0, 3, 2), 5, turnWindow{20, 39}},
+		{"single keeper always holds the turn", upkeep(0, 1, 7), 45, turnWindow{40, 59}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			window, ok := nextTurnWindow(tt.upkeep, tt.blockNumber)
+			require.True(t, ok)
+			require.Equal(t, tt.want, window)
+		})
+	}
+
+	t.Run("registry without a turn configuration", func(t *testing.T) {
+		_, ok := nextTurnWindow(UpkeepRegistration{}, 5)
+		require.False(t, ok)
+	})
+}
@@ -0,0 +1,142 @@
+package keeper
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+// performCost is the LINK payment of a perform, in juels, and the block it was performed for
+type performCost struct {
+	blockNumber int64
+	payment     *big.Int
+}
+
+// upkeepBalance returns the LINK balance of the upkeep on its registry, in juels
+func (ex *UpkeepExecuter) upkeepBalance(ctx context.Context, upkeep UpkeepRegistration) (*big.Int, error) {
+	version, err := ex.registryVersion(upkeep)
+	if err != nil {
+		return nil, err
+	}
+	getUpkeepData, err := version.packGetUpkeep(upkeep.UpkeepID)
+	if err != nil {
+		return nil, err
+	}
+	to := upkeep.Registry.ContractAddress.Address()
+	out, err := ex.ethClient.CallContract(ctx, ethereum.CallMsg{To: &to, Data: getUpkeepData}, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "getUpkeep call failed")
+	}
+	values, err := version.unpackGetUpkeep(out)
+	if err != nil {
+		return nil, err
+	}
+	balance, ok := values[3].(*big.Int)
+	if !ok {
+		return nil, errors.Errorf("expected balance to be *big.Int, got %T", values[3])
+	}
+	return balance, nil
+}
+
+// recordPerformCost adds the last checked payment of the upkeep to its cost history,
+// keeping the most recent KeeperLowBalanceProjectionWindow performs
+func (ex *UpkeepExecuter) recordPerformCost(upkeep UpkeepRegistration, blockNumber int64) {
+	ex.upkeepValuesMu.Lock()
+	payment, known := ex.upkeepValues.get(upkeep.key())
+	ex.upkeepValuesMu.Unlock()
+	if !known {
+		return
+	}
+
+	ex.balanceMu.Lock()
+	defer ex.balanceMu.Unlock()
+	costs := append(ex.upkeepPerformCosts(upkeep), performCost{blockNumber: blockNumber, payment: payment.(*big.Int)})
+	if window := int(ex.config.KeeperLowBalanceProjectionWindow()); len(costs) > window {
+		costs = costs[len(costs)-window:]
+	}
+	ex.performCosts.set(upkeep.key(), costs)
+}
+
+// upkeepPerformCosts returns the cost history of the upkeep, the caller must hold balanceMu
+func (ex *UpkeepExecuter) upkeepPerformCosts(upkeep UpkeepRegistration) []performCost {
+	costs, ok := ex.performCosts.get(upkeep.key())
+	if !ok {
+		return nil
+	}
+	return costs.([]performCost)
+}
+
+// blocksUntilDepletion projects after how many blocks the balance is spent at the rate of the
+// performs in costs. It returns false if there isn't enough history to project from.
+func blocksUntilDepletion(balance *big.Int, costs []performCost) (int64, bool) {
+	if len(costs) < 2 {
+		return 0, false
+	}
+	span := costs[len(costs)-1].blockNumber - costs[0].blockNumber
+	if span <= 0 {
+		return 0, false
+	}
+	// the first perform marks the start of the span, its cost was spent before it
+	spent := new(big.Int)
+	for _, cost := range costs[1:] {
+		spent.Add(spent, cost.payment)
+	}
+	if spent.Sign() <= 0 {
+		return 0, false
+	}
+	blocks := new(big.Int).Mul(balance, big.NewInt(span))
+	return blocks.Div(blocks, spent).Int64(), true
+}
+
+// checkLowBalance emits a low balance event when the balance of the upkeep falls below
+// KeeperLowBalanceThreshold. The event isn't repeated until the balance has recovered.
+func (ex *UpkeepExecuter) checkLowBalance(ctx context.Context, upkeep UpkeepRegistration, head eth.Head) {
+	threshold := ex.config.KeeperLowBalanceThreshold()
+	if threshold == nil || threshold.Sign() <= 0 {
+		return
+	}
+	balance, err := ex.upkeepBalance(ctx, upkeep)
+	if err != nil {
+		ex.logger.Warnw("unable to check upkeep balance", "upkeepID", upkeep.UpkeepID, "error", err)
+		return
+	}
+
+	ex.balanceMu.Lock()
+	if balance.Cmp(threshold) >= 0 {
+		ex.lowBalanceUpkeeps.delete(upkeep.key())
+		ex.balanceMu.Unlock()
+		return
+	}
+	if _, notified := ex.lowBalanceUpkeeps.get(upkeep.key()); notified {
+		ex.balanceMu.Unlock()
+		return
+	}
+	ex.lowBalanceUpkeeps.set(upkeep.key(), struct{}{})
+	blocks, projected := blocksUntilDepletion(balance, ex.upkeepPerformCosts(upkeep))
+	ex.balanceMu.Unlock()
+
+	data := map[string]interface{}{
+		"balance":   balance.String(),
+		"threshold": threshold.String(),
+	}
+	if projected {
+		data["estimatedBlocksUntilDepletion"] = blocks
+	}
+	ex.events.Emit(Event{
+		Type:            EventLowBalance,
+		RegistryAddress: upkeep.Registry.ContractAddress.Address(),
+		UpkeepID:        upkeep.UpkeepID,
+		BlockNumber:     head.Number,
+		Data:            data,
+	})
+}
+
+func (ex *UpkeepExecuter) lowBalanceCount() int {
+	ex.balanceMu.Lock()
+	defer ex.balanceMu.Unlock()
+	return ex.lowBalanceUpkeeps.len()
+}
@@ -0,0 +1,40 @@
+package keeper
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlocksUntilDepletion(t *testing.T) {
+	t.Parallel()
+
+	costs := []performCost{
+		{blockNumber: 100, payment: big.NewInt(30)},
+		{blockNumber: 110, payment: big.NewInt(10)},
+		{blockNumber: 120, payment: big.NewInt(10)},
+	}
+
+	tests := []struct {
+		name      string
+		balance   int64
+		costs     []performCost
+		blocks    int64
+		projected bool
+	}{
+		{"no history", 100, nil, 0, false},
+		{"single perform", 100, costs[:1], 0, false},
+		{"1 juel per block", 100, costs, 100, true},
+		{"empty balance", 0, costs, 0, true},
+		{"performs on the same block", 100, []performCost{costs[0], {blockNumber: 100, payment: big.NewInt(1)}}, 0, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			blocks, projected := blocksUntilDepletion(big.NewInt(tt.balance), tt.costs)
+			require.Equal(t, tt.projected, projected)
+			require.Equal(t, tt.blocks, blocks)
+		})
+	}
+}
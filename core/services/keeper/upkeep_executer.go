@@ -3,24 +3,52 @@ package keeper
 import (
 	"context"
 	"math/big"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/atomic"
+
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/services/eth"
 	"github.com/smartcontractkit/chainlink/core/services/gas"
 	httypes "github.com/smartcontractkit/chainlink/core/services/headtracker/types"
 	"github.com/smartcontractkit/chainlink/core/services/job"
 	"github.com/smartcontractkit/chainlink/core/services/pipeline"
-	"github.com/smartcontractkit/chainlink/core/services/postgres"
 	"github.com/smartcontractkit/chainlink/core/utils"
 	bigmath "github.com/smartcontractkit/chainlink/core/utils/big_math"
 )
 
 const (
-	executionQueueSize = 10
+	// defaultExecutionQueueSize is used if KeeperMaximumConcurrentExecutions is invalid
+	defaultExecutionQueueSize = 10
+	// forcedShutdownTimeout bounds how long Close waits for executions after cancelling them
+	forcedShutdownTimeout = 5 * time.Second
+	// emptyMailboxWarnThreshold empty mailbox retrieves within emptyMailboxWarnWindow are logged as a warning
+	emptyMailboxWarnThreshold = 10
+	emptyMailboxWarnWindow    = time.Minute
+)
+
+const (
+	// GasReconcileStrategyMax prices upkeeps at the higher of the estimator price and the head base fee
+	GasReconcileStrategyMax = "max"
+	// GasReconcileStrategyEstimator prices upkeeps at the estimator price, ignoring the head base fee
+	GasReconcileStrategyEstimator = "estimator"
+	// GasReconcileStrategyBlend prices upkeeps at a weighted average of the estimator price and the head base fee
+	GasReconcileStrategyBlend = "blend"
+)
+
+const (
+	// CheckBlockTagLatest simulates checkUpkeep against the latest block
+	CheckBlockTagLatest = "latest"
+	// CheckBlockTagPending simulates checkUpkeep against the pending block
+	CheckBlockTagPending = "pending"
 )
 
 // UpkeepExecuter fulfills Service and HeadTrackable interfaces
@@ -32,21 +60,133 @@ var (
 // UpkeepExecuter implements the logic to communicate with KeeperRegistry
 type UpkeepExecuter struct {
 	chStop          chan struct{}
+	chForceStop     chan struct{}
+	chFlush         chan chan struct{}
 	ethClient       eth.Client
 	config          Config
 	executionQueue  chan struct{}
+	simulationQueue chan struct{}
 	headBroadcaster httypes.HeadBroadcasterRegistry
 	gasEstimator    gas.Estimator
+	gasStrategy     UpkeepGasStrategy
 	job             job.Job
 	mailbox         *utils.Mailbox
+	metrics         Metrics
+	events          EventSink
+	onPerform       PerformCallback
 	orm             ORM
 	pr              pipeline.Runner
+	queryLimiter    *QueryLimiter
 	logger          logger.Logger
-	wgDone          sync.WaitGroup
+	wgDone          waitGroup
 	utils.StartStopOnce
+
+	// queued counts eligible upkeeps waiting for a slot in the executionQueue,
+	// inFlight counts running executions and abandoned counts executions cut short by Close
+	queued    atomic.Int64
+	inFlight  atomic.Int64
+	abandoned atomic.Int64
+	// indexDegraded is set while heads are skipped because of KeeperMaxIndexLag
+	indexDegraded atomic.Bool
+	// insufficientBalance is set while heads are skipped because of KeeperMinimumBalance
+	insufficientBalance atomic.Bool
+	// lastProcessedAt is when the executer last processed a head, in unix nanoseconds, for KeeperMaxProcessedHeadAge
+	lastProcessedAt atomic.Int64
+	// lastGracePeriod is the grace period of the last eligibility query, applied to log-triggered upkeeps
+	lastGracePeriod atomic.Int64
+	clock           utils.Nower
+
+	// inFlightUpkeeps maps the executing upkeeps to the head number they were triggered on
+	inFlightUpkeeps   map[upkeepKey]int64
+	inFlightUpkeepsMu sync.Mutex
+	// executionCounts buffers the execution counts of the upkeeps until the next flushExecutionCounts,
+	// keyed by the ID of their registration
+	executionCounts   map[int32]ExecutionCounts
+	executionCountsMu sync.Mutex
+
+	// The per-upkeep state below is bounded by KeeperMaxUpkeepStateEntries, the comments name the value type of each map.
+
+	// upkeepValues caches the maxLinkPayment (*big.Int) of each upkeep's last checkUpkeep, for KeeperValueWeightedOrdering
+	upkeepValues   *upkeepStateMap
+	upkeepValuesMu sync.Mutex
+
+	// intervals caches the on-chain interval (time.Duration) of time-based upkeeps and lastPerformed holds
+	// when this node last performed each upkeep (time.Time), for KeeperIntervalCooldown
+	intervals     *upkeepStateMap
+	lastPerformed *upkeepStateMap
+	cooldownMu    sync.Mutex
+
+	// simRevertStreaks counts the consecutive unexpected checkUpkeep reverts (uint32) of each upkeep and
+	// disabledUpkeeps holds the upkeeps disabled because of them (struct{}), for KeeperSimRevertAutoDisable
+	simRevertStreaks *upkeepStateMap
+	disabledUpkeeps  *upkeepStateMap
+	simRevertMu      sync.Mutex
+
+	// performCosts holds the payments of the recent performs ([]performCost) of each upkeep and lowBalanceUpkeeps
+	// the upkeeps a low balance event was emitted for (struct{}), for KeeperLowBalanceThreshold
+	performCosts      *upkeepStateMap
+	lowBalanceUpkeeps *upkeepStateMap
+	balanceMu         sync.Mutex
+
+	// performedWindows holds the KeeperPerformCadenceBlocks window (int64) each upkeep was last performed in
+	performedWindows *upkeepStateMap
+	cadenceMu        sync.Mutex
+
+	// overdueHeads counts the consecutive heads (uint32) each upkeep was eligible on without being performed, for KeeperOverdueWarnHeads
+	overdueHeads *upkeepStateMap
+	overdueMu    sync.Mutex
+
+	// logTriggers batches the trigger logs of log-triggered upkeeps, which are handed to the log trigger
+	// goroutine on chLogTriggers. logTriggers is nil if the job has no log-triggered upkeeps.
+	logTriggers   *logTriggerBatcher
+	chLogTriggers chan logTriggerBatch
+
+	// replayBlock is the block the upkeeps of the head replayed by GenerateReport are checked at, nil outside of a replay
+	replayBlock *big.Int
+
+	// headNonceGap caches the nonce gap check of the last head an upkeep was about to be performed on
+	headNonceGap *headNonceGap
+	nonceGapMu   sync.Mutex
+
+	// batchHead is the head whose upkeeps are executing, cancelBatch cancels their executions when a reorg replaces it
+	batchHead   *eth.Head
+	cancelBatch context.CancelFunc
+	// triggerBatches cancel the log-triggered executions of a block when a reorg replaces it
+	triggerBatches map[*eth.Head]context.CancelFunc
+	batchMu        sync.Mutex
+
+	// lastHead is the most recently processed head, only accessed from the run goroutine
+	lastHead *eth.Head
+	// lastHeadAt is when lastHead was processed, only accessed from the run goroutine
+	lastHeadAt time.Time
+	// lastRetrievedHead is the number of the head last retrieved from the mailbox, only accessed from the run goroutine
+	lastRetrievedHead int64
+	// idleHeads counts the consecutive processed heads without eligible upkeeps, only accessed from the run goroutine
+	idleHeads uint32
+	// pausedRegistries holds the paused state of the registries on the last processed head, only accessed from the run goroutine
+	pausedRegistries map[common.Address]bool
+	// eligibleUpkeeps is the set of upkeeps eligible on lastHead, only accessed from the run goroutine
+	eligibleUpkeeps map[upkeepKey]UpkeepRegistration
+	// emptyRetrieves counts empty mailbox retrieves since emptyRetrievesSince, only accessed from the run goroutine
+	emptyRetrieves      int
+	emptyRetrievesSince time.Time
+	// skewedHeads counts consecutive heads whose timestamp is skewed, only accessed from the run goroutine
+	skewedHeads int
+	// behind is set while the executer skips more heads than KeeperGracePeriodLagThreshold, only accessed from the run goroutine
+	behind bool
+	// avgBlockTime is the moving average of the block times of the processed heads, only accessed from the run goroutine
+	avgBlockTime time.Duration
+	// balanceCheck is the last KeeperMinimumBalance check, only accessed from the run goroutine
+	balanceCheck *fromAddressBalanceCheck
 }
 
-// NewUpkeepExecuter is the constructor of UpkeepExecuter
+// NewUpkeepExecuter is the constructor of UpkeepExecuter. Its metrics are registered with
+// metricsRegisterer, or shared with the other executers in the default registry if it's nil.
+// Executers sharing a registerer need it wrapped with distinct labels, e.g. by
+// prometheus.WrapRegistererWith, since collectors can only be registered once.
+// gasStrategy prices the perform transactions from the gas estimates, if it's nil the gas price
+// buffer of KeeperGasPriceBufferPercent is added to them.
+// onPerform is called for every performed upkeep, it may be nil.
 func NewUpkeepExecuter(
 	job job.Job,
 	orm ORM,
@@ -54,32 +194,93 @@ func NewUpkeepExecuter(
 	ethClient eth.Client,
 	headBroadcaster httypes.HeadBroadcaster,
 	gasEstimator gas.Estimator,
+	gasStrategy UpkeepGasStrategy,
 	logger logger.Logger,
 	config Config,
+	queryLimiter *QueryLimiter,
+	metricsRegisterer prometheus.Registerer,
+	onPerform PerformCallback,
 ) *UpkeepExecuter {
-	return &UpkeepExecuter{
+	ex := &UpkeepExecuter{
 		chStop:          make(chan struct{}),
+		chForceStop:     make(chan struct{}),
+		chFlush:         make(chan chan struct{}),
 		ethClient:       ethClient,
-		executionQueue:  make(chan struct{}, executionQueueSize),
+		executionQueue:  make(chan struct{}, executionQueueSize(config, logger)),
 		headBroadcaster: headBroadcaster,
 		gasEstimator:    gasEstimator,
+		gasStrategy:     gasStrategy,
 		job:             job,
-		mailbox:         utils.NewMailbox(1),
+		mailbox:         utils.NewMailbox(mailboxCapacity(config)),
+		metrics:         defaultMetrics,
+		events:          logEventSink{logger},
+		onPerform:       onPerform,
 		config:          config,
 		orm:             orm,
 		pr:              pr,
+		queryLimiter:    queryLimiter,
 		logger:          logger,
+		clock:           utils.Clock{},
+		inFlightUpkeeps: make(map[upkeepKey]int64),
+		chLogTriggers:   make(chan logTriggerBatch),
+
+		pausedRegistries: make(map[common.Address]bool),
+	}
+	ex.upkeepValues = ex.boundedUpkeepState("upkeepValues")
+	ex.intervals = ex.boundedUpkeepState("intervals")
+	ex.lastPerformed = ex.boundedUpkeepState("lastPerformed")
+	ex.simRevertStreaks = ex.boundedUpkeepState("simRevertStreaks")
+	ex.disabledUpkeeps = ex.boundedUpkeepState("disabledUpkeeps")
+	ex.performCosts = ex.boundedUpkeepState("performCosts")
+	ex.lowBalanceUpkeeps = ex.boundedUpkeepState("lowBalanceUpkeeps")
+	ex.performedWindows = ex.boundedUpkeepState("performedWindows")
+	ex.overdueHeads = ex.boundedUpkeepState("overdueHeads")
+	ex.lastGracePeriod.Store(config.KeeperMaximumGracePeriod())
+	if metricsRegisterer != nil {
+		ex.metrics = newPromMetrics(metricsRegisterer)
+	}
+	if concurrency := config.KeeperSimulationConcurrency(); concurrency > 0 {
+		ex.simulationQueue = make(chan struct{}, concurrency)
 	}
+	if config.KeeperBatchMetricUpdates() {
+		ex.metrics = newMetricsBatch(ex.metrics)
+	}
+	return ex
+}
+
+// executionQueueSize is the number of upkeeps the executer may execute concurrently
+func executionQueueSize(config Config, lggr logger.Logger) uint32 {
+	size := config.KeeperMaximumConcurrentExecutions()
+	if size < 1 {
+		lggr.Warnf("KeeperMaximumConcurrentExecutions must be at least 1, falling back to %d", defaultExecutionQueueSize)
+		return defaultExecutionQueueSize
+	}
+	return size
 }
 
 // Start starts the upkeep executer logic
 func (ex *UpkeepExecuter) Start() error {
 	return ex.StartOnce("UpkeepExecuter", func() error {
+		ex.logConfigChanges()
+		// a started executer has until KeeperMaxProcessedHeadAge to process its first head
+		ex.setLastProcessed()
+
+		latestHead, unsubscribeHeads := ex.headBroadcaster.Subscribe(ex)
+		pollInterval := ex.config.KeeperHeadPollFallbackInterval()
+		if unsubscribeHeads == nil && pollInterval <= 0 {
+			return errors.New("unable to subscribe to the head broadcaster")
+		}
+
 		ex.wgDone.Add(2)
 		go ex.run()
-		latestHead, unsubscribeHeads := ex.headBroadcaster.Subscribe(ex)
+		ex.subscribeLogTriggers()
+		if unsubscribeHeads == nil {
+			ex.logger.Warnw("unable to subscribe to the head broadcaster, falling back to polling for heads", "pollInterval", pollInterval)
+			go ex.pollHeads(pollInterval)
+			return nil
+		}
 		if latestHead != nil {
-			ex.mailbox.Deliver(*latestHead)
+			ex.deliverHead(*latestHead)
 		}
 		go func() {
 			defer unsubscribeHeads()
@@ -93,15 +294,80 @@ func (ex *UpkeepExecuter) Start() error {
 // Close stops and closes upkeep executer
 func (ex *UpkeepExecuter) Close() error {
 	return ex.StopOnce("UpkeepExecuter", func() error {
+		queued, inFlight := ex.queued.Load(), ex.inFlight.Load()
 		close(ex.chStop)
-		ex.wgDone.Wait()
+		if ex.logTriggers != nil {
+			ex.logTriggers.Stop()
+		}
+		if !ex.waitDone(ex.config.KeeperShutdownDeadline()) {
+			if upkeepIDs := ex.inFlightUpkeepIDs(); len(upkeepIDs) > 0 {
+				ex.logger.Warnw("cancelling upkeep executions that did not finish before the shutdown deadline",
+					"upkeepIDs", upkeepIDs)
+			}
+		}
+		close(ex.chForceStop)
+		if !ex.waitDone(forcedShutdownTimeout) {
+			ex.logger.Errorw("upkeep executions did not stop after being cancelled, giving up on them",
+				"upkeepIDs", ex.inFlightUpkeepIDs())
+		}
+		ex.flushMetrics()
+		ex.flushExecutionCounts()
+		ex.logger.Infow("upkeep executer shut down",
+			"queuedExecutions", queued,
+			"inFlightExecutions", inFlight,
+			"abandonedExecutions", ex.abandoned.Load(),
+		)
 		return nil
 	})
 }
 
+// waitDone waits up to timeout for the executer goroutines to exit, returning false if they haven't
+func (ex *UpkeepExecuter) waitDone(timeout time.Duration) bool {
+	return ex.wgDone.WaitTimeout(timeout)
+}
+
+func (ex *UpkeepExecuter) inFlightUpkeepIDs() []int64 {
+	ex.inFlightUpkeepsMu.Lock()
+	defer ex.inFlightUpkeepsMu.Unlock()
+	ids := make([]int64, 0, len(ex.inFlightUpkeeps))
+	for key := range ex.inFlightUpkeeps {
+		ids = append(ids, key.upkeepID)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
 // OnNewLongestChain handles the given head of a new longest chain
 func (ex *UpkeepExecuter) OnNewLongestChain(_ context.Context, head eth.Head) {
-	ex.mailbox.Deliver(head)
+	ex.deliverHead(head)
+}
+
+// pollHeads delivers the latest head from the eth client to the mailbox every interval,
+// used when no head broadcaster subscription is available
+func (ex *UpkeepExecuter) pollHeads(interval time.Duration) {
+	defer ex.wgDone.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var lastHash common.Hash
+	for {
+		select {
+		case <-ex.chStop:
+			return
+		case <-ticker.C:
+			ctx, cancel := utils.ContextFromChanWithDeadline(ex.chStop, interval)
+			head, err := ex.ethClient.HeadByNumber(ctx, nil)
+			cancel()
+			if err != nil {
+				ex.logger.Warnw("unable to poll latest head", "error", err)
+				continue
+			}
+			if head == nil || head.Hash == lastHash {
+				continue
+			}
+			lastHash = head.Hash
+			ex.deliverHead(*head)
+		}
+	}
 }
 
 func (ex *UpkeepExecuter) run() {
@@ -111,117 +377,1051 @@ func (ex *UpkeepExecuter) run() {
 		case <-ex.chStop:
 			return
 		case <-ex.mailbox.Notify():
-			ex.processActiveUpkeeps()
+			if mailboxCapacity(ex.config) > 1 {
+				ex.drainMailbox()
+			} else {
+				ex.processActiveUpkeeps()
+			}
+		case chDone := <-ex.chFlush:
+			ex.flushCaches()
+			close(chDone)
 		}
 	}
 }
 
+// drainMailbox processes the heads buffered in the mailbox in the order they arrived, until it's empty
+// or the executer is stopped. It must only be called from the run goroutine.
+func (ex *UpkeepExecuter) drainMailbox() {
+	for ex.mailbox.Len() > 0 {
+		select {
+		case <-ex.chStop:
+			return
+		default:
+		}
+		ex.processActiveUpkeeps()
+	}
+}
+
+// mailboxCapacity returns KeeperMailboxCapacity, at least 1
+func mailboxCapacity(config Config) uint64 {
+	if capacity := config.KeeperMailboxCapacity(); capacity > 1 {
+		return uint64(capacity)
+	}
+	return 1
+}
+
+// FlushCaches clears the transient state the executer keeps in memory, as if it had been restarted.
+// It waits for the head currently being processed, if any, to finish first.
+func (ex *UpkeepExecuter) FlushCaches() error {
+	if ex.State() != utils.StartStopOnce_Started {
+		return errors.New("upkeep executer is not started")
+	}
+	chDone := make(chan struct{})
+	select {
+	case ex.chFlush <- chDone:
+	case <-ex.chStop:
+		return errors.New("upkeep executer is stopping")
+	}
+	select {
+	case <-chDone:
+		return nil
+	case <-ex.chStop:
+		return errors.New("upkeep executer is stopping")
+	}
+}
+
+// flushCaches must only be called from the run goroutine
+func (ex *UpkeepExecuter) flushCaches() {
+	var lastHeadNumber interface{}
+	if ex.lastHead != nil {
+		lastHeadNumber = ex.lastHead.Number
+	}
+	ex.logger.Infow("flushing upkeep executer caches",
+		"lastHead", lastHeadNumber,
+		"eligibleUpkeeps", len(ex.eligibleUpkeeps),
+		"emptyRetrieves", ex.emptyRetrieves,
+		"upkeepValues", ex.upkeepValueCount(),
+		"cooldowns", ex.cooldownCount(),
+		"disabledUpkeeps", ex.disabledUpkeepCount(),
+		"lowBalanceUpkeeps", ex.lowBalanceCount(),
+	)
+	ex.lastHead = nil
+	ex.lastHeadAt = time.Time{}
+	ex.lastRetrievedHead = 0
+	ex.eligibleUpkeeps = nil
+	ex.idleHeads = 0
+	ex.emptyRetrieves = 0
+	ex.avgBlockTime = 0
+	ex.upkeepValuesMu.Lock()
+	ex.upkeepValues.reset()
+	ex.upkeepValuesMu.Unlock()
+	ex.cooldownMu.Lock()
+	ex.intervals.reset()
+	ex.lastPerformed.reset()
+	ex.cooldownMu.Unlock()
+	ex.simRevertMu.Lock()
+	ex.simRevertStreaks.reset()
+	ex.disabledUpkeeps.reset()
+	ex.simRevertMu.Unlock()
+	ex.balanceMu.Lock()
+	ex.performCosts.reset()
+	ex.lowBalanceUpkeeps.reset()
+	ex.balanceMu.Unlock()
+	ex.cadenceMu.Lock()
+	ex.performedWindows.reset()
+	ex.cadenceMu.Unlock()
+	ex.overdueMu.Lock()
+	ex.overdueHeads.reset()
+	ex.overdueMu.Unlock()
+	ex.emptyRetrievesSince = time.Time{}
+	ex.skewedHeads = 0
+}
+
 func (ex *UpkeepExecuter) processActiveUpkeeps() {
 	// Keepers could miss their turn in the turn taking algo if they are too overloaded
 	// with work because processActiveUpkeeps() blocks
 	item, exists := ex.mailbox.Retrieve()
 	if !exists {
-		ex.logger.Info("no head to retrieve. It might have been skipped")
+		ex.onEmptyRetrieve()
 		return
 	}
 
-	head, ok := item.(eth.Head)
+	arrived, ok := item.(arrivedHead)
 	if !ok {
-		ex.logger.Errorf("expected `eth.Head`, got %T", head)
+		ex.logger.Errorf("expected `arrivedHead`, got %T", item)
+		return
+	}
+	head := arrived.Head
+	ex.observeSkippedHeads(head)
+	if ex.config.KeeperSkipDuplicateHeads() && ex.isDuplicateHead(head) {
+		ex.logger.Debugw("ignoring head identical to the last processed head", "blockheight", head.Number, "hash", head.Hash.Hex())
+		ex.metrics.IncDuplicateHeads(ex.job.KeeperSpec.ContractAddress.Hex())
+		return
+	}
+	if ex.isOutOfOrderHead(head) {
+		ex.logger.Debugw("ignoring head below the last processed head", "blockheight", head.Number, "lastBlockheight", ex.lastHead.Number)
+		return
+	}
+	if ex.debounced(head) {
+		ex.logger.Debugw("ignoring head arriving within KeeperMinHeadInterval of the last processed head", "blockheight", head.Number)
+		return
+	}
+	started := time.Now()
+
+	if err := ex.runnerReady(); err != nil {
+		ex.logger.Infow("deferring upkeeps until a later head", "blockheight", head.Number, "error", err)
+		return
+	}
+
+	if !ex.sufficientBalance(head) {
+		// the gauge reporting the skipped heads isn't buffered until the balance recovers
+		ex.flushMetrics()
 		return
 	}
 
 	ex.logger.Debugw("checking active upkeeps", "blockheight", head.Number)
+	ex.observeClockSkew(head)
 
-	ctx, cancel := postgres.DefaultQueryCtx()
-	defer cancel()
+	if !ex.indexCaughtUp(head) {
+		return
+	}
 
-	activeUpkeeps, err := ex.orm.EligibleUpkeepsForRegistry(
-		ctx,
-		ex.job.KeeperSpec.ContractAddress,
-		head.Number,
-		ex.config.KeeperMaximumGracePeriod(),
-	)
+	if !ex.queryLimiter.wait(ex.config.KeeperEligibilityQueryRPS(), ex.chStop) {
+		return
+	}
+
+	ex.observeBlockTime(head)
+	ex.observeHeadLag(head)
+	activeUpkeeps, eligibilityFallback, err := ex.loadEligibleUpkeeps(head)
 	if err != nil {
 		ex.logger.With("error", err).Error("unable to load active registrations")
 		return
 	}
+	ex.setLastProcessed()
+	activeUpkeeps, allPaused := ex.excludePausedRegistries(head, activeUpkeeps)
+	if allPaused {
+		// the head was processed, later heads are still deduplicated and ordered against it
+		ex.setLastHead(head)
+		return
+	}
+
+	ex.metrics.AddEligibleUpkeeps(ex.job.KeeperSpec.ContractAddress.Hex(), len(activeUpkeeps))
+	ex.observeIdle(len(activeUpkeeps))
+	ex.emitEligibilityChanges(head, activeUpkeeps)
+
+	if ex.config.KeeperExcludePendingPerforms() {
+		activeUpkeeps = ex.excludePendingPerforms(head, activeUpkeeps)
+	}
+	activeUpkeeps = ex.excludeBackedOff(head, activeUpkeeps)
+
+	if ex.config.KeeperOverdueOrdering() {
+		sortByOverdue(activeUpkeeps)
+	}
+	if ex.config.KeeperValueWeightedOrdering() {
+		ex.sortByValue(activeUpkeeps)
+	}
+	activeUpkeeps = ex.limitPerRegistry(head, activeUpkeeps)
+
+	ex.observeReorg(head, len(activeUpkeeps))
+	ex.setLastHead(head)
+
+	summary := &headSummary{}
+	ctxBatch := ex.startBatch(head)
+	gasPrices := ex.newHeadGasPrices()
+	ex.processRegistries(activeUpkeeps, func(upkeeps []UpkeepRegistration) {
+		ex.executeUpkeeps(ctxBatch, head, arrived.arrivedAt, eligibilityFallback, upkeeps, gasPrices, summary)
+	})
+	ex.endBatch()
+	ex.flushMetrics()
+	ex.flushExecutionCounts()
+	ex.logHeadSummary(head.Number, summary, started)
+}
+
+// executeUpkeeps batch checks upkeeps if KeeperBatchCheckUpkeep is enabled, then executes
+// the upkeeps needing a perform as execution slots free up and waits for them to finish
+func (ex *UpkeepExecuter) executeUpkeeps(ctxBatch context.Context, head eth.Head, arrivedAt time.Time, eligibilityFallback bool, upkeeps []UpkeepRegistration, gasPrices *headGasPrices, summary *headSummary) {
+	var checked map[upkeepKey]checkUpkeepResult
+	if ex.config.KeeperBatchCheckUpkeep() && len(upkeeps) > 0 {
+		upkeeps, checked = ex.batchCheckUpkeeps(head, upkeeps, gasPrices)
+	}
+	summary.eligible.Add(int64(len(upkeeps)))
 
 	wg := sync.WaitGroup{}
-	wg.Add(len(activeUpkeeps))
-	done := func() {
+	done := func(outcome executionOutcome) {
+		summary.record(outcome)
+		ex.inFlight.Dec()
+		ex.metrics.AddActiveExecutions(ex.job.KeeperSpec.ContractAddress.Hex(), -1)
 		<-ex.executionQueue
 		wg.Done()
+		ex.wgDone.Done()
+	}
+	ex.queued.Add(int64(len(upkeeps)))
+	chunkSize := int(ex.config.KeeperExecutionChunkSize())
+	for i, reg := range upkeeps {
+		if chunkSize > 0 && i > 0 && i%chunkSize == 0 {
+			// bounds the goroutines of a head with many eligible upkeeps
+			wg.Wait()
+		}
+		if ctxBatch.Err() != nil {
+			ex.logger.Debugw("head was replaced by a reorg, not enqueuing the remaining upkeeps",
+				"blockheight", head.Number, "remaining", len(upkeeps)-i)
+			ex.queued.Sub(int64(len(upkeeps) - i))
+			break
+		}
+		enqueuedAt := time.Now()
+		if !ex.acquireExecutionSlot() {
+			ex.logger.Debugw("shutting down, not enqueuing the remaining upkeeps",
+				"blockheight", head.Number, "remaining", len(upkeeps)-i)
+			ex.queued.Sub(int64(len(upkeeps) - i))
+			break
+		}
+		ex.metrics.ObserveQueueWait(ex.job.KeeperSpec.ContractAddress.Hex(), time.Since(enqueuedAt))
+		ex.queued.Dec()
+		ex.inFlight.Inc()
+		ex.metrics.AddActiveExecutions(ex.job.KeeperSpec.ContractAddress.Hex(), 1)
+		var result *checkUpkeepResult
+		if r, ok := checked[reg.key()]; ok {
+			result = &r
+		}
+		wg.Add(1)
+		// Close waits on wgDone for the executions to drain
+		ex.wgDone.Add(1)
+		go ex.execute(ctxBatch, reg, head, arrivedAt, eligibilityFallback, result, gasPrices, nil, done)
 	}
-	for _, reg := range activeUpkeeps {
-		ex.executionQueue <- struct{}{}
-		go ex.execute(reg, head.Number, done)
+	wg.Wait()
+}
+
+// debounced returns true if head arrived within KeeperMinHeadInterval of the last processed head
+// without advancing the chain by more than one block. It must only be called from the run goroutine.
+func (ex *UpkeepExecuter) debounced(head eth.Head) bool {
+	interval := ex.config.KeeperMinHeadInterval()
+	if interval <= 0 || ex.lastHead == nil {
+		return false
 	}
+	return time.Since(ex.lastHeadAt) < interval && head.Number <= ex.lastHead.Number+1
+}
 
-	wg.Wait()
+// runnerReady returns an error if upkeeps can't be executed because the pipeline runner
+// is missing or hasn't finished starting yet
+func (ex *UpkeepExecuter) runnerReady() error {
+	if ex.pr == nil {
+		return errors.New("no pipeline runner")
+	}
+	return errors.Wrap(ex.pr.Ready(), "pipeline runner is not ready")
 }
 
-// execute triggers the pipeline run
-func (ex *UpkeepExecuter) execute(upkeep UpkeepRegistration, headNumber int64, done func()) {
-	defer done()
+// onEmptyRetrieve logs an empty mailbox at debug level, escalating to a warning
+// if it happens emptyMailboxWarnThreshold times within emptyMailboxWarnWindow
+func (ex *UpkeepExecuter) onEmptyRetrieve() {
+	ex.metrics.IncEmptyMailboxRetrieves(ex.job.KeeperSpec.ContractAddress.Hex())
+	ex.logger.Debug("no head to retrieve. It might have been skipped")
 
+	now := time.Now()
+	if now.Sub(ex.emptyRetrievesSince) > emptyMailboxWarnWindow {
+		ex.emptyRetrieves = 0
+		ex.emptyRetrievesSince = now
+	}
+	ex.emptyRetrieves++
+	if ex.emptyRetrieves == emptyMailboxWarnThreshold {
+		ex.logger.Warnw("repeatedly found no head to retrieve, heads may be delivered faster than they are processed",
+			"emptyRetrieves", ex.emptyRetrieves, "since", ex.emptyRetrievesSince)
+	}
+}
+
+// acquireExecutionSlot waits for a free slot in the executionQueue,
+// returning false without taking one once Close has been called
+func (ex *UpkeepExecuter) acquireExecutionSlot() bool {
+	select {
+	case <-ex.chStop:
+		return false
+	default:
+	}
+	select {
+	case ex.executionQueue <- struct{}{}:
+		return true
+	case <-ex.chStop:
+		return false
+	}
+}
+
+// execute triggers the pipeline run,
+// checked is the result of the batched checkUpkeep of upkeep, nil if it wasn't batch checked.
+// ctxBatch is cancelled if a reorg replaces head while the upkeeps triggered on it execute.
+// gasPrices holds the gas prices shared by the upkeeps of head, nil if they aren't shared.
+// trigger is the log that triggered a log-triggered upkeep, nil if upkeep was triggered by head.
+func (ex *UpkeepExecuter) execute(ctxBatch context.Context, upkeep UpkeepRegistration, head eth.Head, arrivedAt time.Time, eligibilityFallback bool, checked *checkUpkeepResult, gasPrices *headGasPrices, trigger *types.Log, done func(executionOutcome)) {
+	start := time.Now()
+	outcome := executionSkipped
+	var decision upkeepDecision
+	defer func() {
+		ex.metrics.ObserveExecuteDuration(upkeep.Registry.ContractAddress.Hex(), time.Since(start))
+		ex.recordDecision(upkeep, head, outcome, decision)
+		ex.recordExecution(upkeep, outcome)
+		ex.observeOverdue(upkeep, head, outcome, decision)
+		done(outcome)
+	}()
+
+	headNumber := head.Number
 	svcLogger := ex.logger.With("blockNum", headNumber, "upkeepID", upkeep.UpkeepID)
+	if len(ex.job.KeeperSpec.RegistryAddresses()) > 1 {
+		// the executer of a job servicing several registries isn't named after one of them
+		svcLogger = svcLogger.With("registryAddress", upkeep.Registry.ContractAddress.Hex())
+	}
 	svcLogger.Debug("checking upkeep")
+	defer func() { logDecision(svcLogger, outcome, decision) }()
+
+	ex.inFlightUpkeepsMu.Lock()
+	ex.inFlightUpkeeps[upkeep.key()] = headNumber
+	ex.inFlightUpkeepsMu.Unlock()
+	defer func() {
+		ex.inFlightUpkeepsMu.Lock()
+		delete(ex.inFlightUpkeeps, upkeep.key())
+		ex.inFlightUpkeepsMu.Unlock()
+	}()
 
-	ctxService, cancel := utils.ContextFromChanWithDeadline(ex.chStop, time.Minute)
+	// executions are allowed to finish until the shutdown deadline passes
+	ctxService, cancel := ex.executionContext(ctxBatch)
 	defer cancel()
 
-	gasPrice, err := ex.estimateGasPrice(upkeep)
+	if ctxBatch.Err() != nil {
+		svcLogger.Debug("skipping upkeep, its head was replaced by a reorg")
+		decision.reason = decisionReorged
+		return
+	}
+
+	if ex.isUpkeepDisabled(upkeep) {
+		svcLogger.Debug("skipping upkeep disabled after repeated simulation reverts")
+		decision.reason = decisionDisabled
+		return
+	}
+
+	if !ex.withinPerformWindow(upkeep, head) {
+		svcLogger.Debug("skipping upkeep outside of its perform windows")
+		decision.reason = decisionOutsidePerformWindow
+		return
+	}
+
+	if ex.config.KeeperIntervalCooldown() {
+		inCooldown, err := ex.inIntervalCooldown(ctxService, upkeep)
+		if err != nil {
+			svcLogger.Warnw("unable to determine upkeep interval", "error", err)
+		} else if inCooldown {
+			svcLogger.Debug("skipping upkeep performed less than its interval ago")
+			decision.reason = decisionIntervalCooldown
+			return
+		}
+	}
+
+	// The registry evaluates the payment and balance checks of checkUpkeep at tx.gasprice, so the gas price
+	// of the perform is estimated before the check and the check is priced with it. Batch checked upkeeps
+	// were priced by their batch.
+	var gasPrice *big.Int
+	if checked != nil {
+		gasPrice = checked.GasPrice
+	}
+	if gasPrice == nil {
+		var err error
+		gasPrice, err = ex.estimateCheckGasPrice(ctxService, upkeep, head, gasPrices, svcLogger)
+		if err != nil {
+			decision.reason, outcome = gasPriceErrorDecision(err, svcLogger)
+			return
+		}
+	}
+
+	// checkUpkeep is called before the pipeline run, unless the upkeep was batch checked, so that its
+	// performData is known to the executer and the pipeline. The registry reverts checkUpkeep if the
+	// upkeep doesn't need to be performed.
+	maxSize := ex.config.KeeperMaxPerformDataSize()
+	dryRun := ex.config.KeeperDryRun()
+	if checked == nil {
+		result, err := ex.simulateUpkeep(ctxService, upkeep, gasPrice)
+		if errors.Is(err, errCheckUpkeepUnavailable) {
+			// the check didn't revert, it says nothing about the upkeep
+			svcLogger.Warnw("unable to check upkeep", "error", err)
+			decision.reason = decisionCheckFailed
+			outcome = executionFailed
+			return
+		}
+		ex.recordSimulation(upkeep, head, err)
+		if err != nil {
+			svcLogger.Debugw("upkeep not eligible to perform", "error", err)
+			decision.checkUpkeep = simulationReverted
+			decision.reason = decisionCheckReverted
+			return
+		}
+		checked = &result
+	}
+	decision.checkUpkeep = simulationSucceeded
+	ex.setUpkeepValue(upkeep, checked.MaxLinkPayment)
+	performData := checked.PerformData
+	if maxSize > 0 && len(performData) > int(maxSize) {
+		svcLogger.Warnw("skipping upkeep, performData exceeds KeeperMaxPerformDataSize",
+			"performDataSize", len(performData), "maxPerformDataSize", maxSize)
+		decision.reason = decisionPerformDataTooLarge
+		return
+	}
+
+	if !ex.performScheduled(upkeep, head) {
+		svcLogger.Debug("skipping upkeep already performed within KeeperPerformCadenceBlocks")
+		decision.reason = decisionPerformCadence
+		return
+	}
+
+	decision.gasLimit = ex.performUpkeepGasLimit(upkeep, svcLogger)
+	if blockGasLimit := ex.blockGasLimit(head); blockGasLimit > 0 && decision.gasLimit > blockGasLimit {
+		svcLogger.Warnw("skipping upkeep, perform gas limit exceeds the block gas limit",
+			"gasLimit", decision.gasLimit, "blockGasLimit", blockGasLimit)
+		ex.metrics.IncBlockGasLimitExceeded(upkeep.Registry.ContractAddress.Hex())
+		decision.reason = decisionBlockGasLimit
+		return
+	}
+
+	gasPrice, err := ex.applyRegistryMaxGasPrice(gasPrice, checked, svcLogger)
 	if err != nil {
-		svcLogger.Error(errors.Wrap(err, "estimating gas price"))
+		svcLogger.Warnw("skipping upkeep, gas price estimate exceeds the maximum gas price the registry reimburses", "error", err)
+		decision.reason = decisionGasPriceAboveMaximum
 		return
 	}
+	decision.gasPrice = gasPrice
+	if ex.config.KeeperUseEstimatedGasLimit() {
+		// unlike its gas price, the gas limit of the estimate depends on the performData
+		_, estimatedGasLimit, err := ex.estimateGasPriceWithRetries(ctxService, upkeep, head, performData, gasPrices, svcLogger)
+		if err != nil {
+			decision.reason, outcome = gasPriceErrorDecision(err, svcLogger)
+			return
+		}
+		decision.gasLimit = ex.estimatedGasLimit(decision.gasLimit, estimatedGasLimit, head, svcLogger)
+	}
 
-	vars := pipeline.NewVarsFrom(map[string]interface{}{
-		"jobSpec": map[string]interface{}{
-			"jobID":                 ex.job.ID,
-			"fromAddress":           upkeep.Registry.FromAddress.String(),
-			"contractAddress":       upkeep.Registry.ContractAddress.String(),
-			"upkeepID":              upkeep.UpkeepID,
-			"performUpkeepGasLimit": upkeep.ExecuteGas + ex.orm.config.KeeperRegistryPerformGasOverhead(),
-			"checkUpkeepGasLimit": ex.config.KeeperRegistryCheckGasOverhead() + uint64(upkeep.Registry.CheckGas) +
-				ex.config.KeeperRegistryPerformGasOverhead() + upkeep.ExecuteGas,
-			"gasPrice": gasPrice,
-		},
-	})
+	if ex.config.KeeperSimulatePerformUpkeep() {
+		if err := ex.simulatePerformUpkeep(ctxService, upkeep, performData, decision.gasLimit); err != nil {
+			svcLogger.Debugw("skipping upkeep, performUpkeep simulation reverted", "error", err)
+			decision.performSimulation = simulationReverted
+			decision.reason = decisionPerformReverted
+			return
+		}
+		decision.performSimulation = simulationSucceeded
+	}
+
+	jobSpec := map[string]interface{}{
+		"jobID":                 ex.job.ID,
+		"fromAddress":           upkeep.Registry.FromAddress.String(),
+		"contractAddress":       upkeep.Registry.ContractAddress.String(),
+		"upkeepID":              upkeep.UpkeepID,
+		"performUpkeepGasLimit": decision.gasLimit,
+		"checkUpkeepGasLimit":   ex.checkUpkeepGasLimit(upkeep),
+		"gasPrice":              gasPrice,
+		"performData":           performData,
+	}
+	if checked.GasLimit != nil {
+		// the gas limit the registry returned for the perform, excluding its overhead
+		jobSpec["upkeepGasLimit"] = checked.GasLimit.Uint64()
+	}
+	if estimator, ok := ex.gasEstimator.(gas.ConfidenceEstimator); ok {
+		if confidence, ok := estimator.GasPriceConfidence(); ok {
+			svcLogger.Debugw("estimated gas price", "gasPrice", gasPrice, "gasPriceConfidence", confidence)
+			ex.metrics.SetGasPriceConfidence(upkeep.Registry.ContractAddress.Hex(), confidence)
+			jobSpec["gasPriceConfidence"] = confidence
+		}
+	}
+	if ex.config.KeeperEstimateConfirmationBlocks() {
+		if estimator, ok := ex.gasEstimator.(gas.ConfirmationEstimator); ok {
+			if blocks, ok := estimator.EstimateConfirmationBlocks(gasPrice); ok {
+				svcLogger.Infow("estimated perform confirmation", "gasPrice", gasPrice, "estimatedConfirmationBlocks", blocks)
+				jobSpec["estimatedConfirmationBlocks"] = blocks
+			}
+		}
+	}
+
+	if dryRun {
+		svcLogger.Infow("dry run, not performing upkeep",
+			"gasPrice", gasPrice, "gasLimit", decision.gasLimit, "performDataSize", len(performData))
+		decision.reason = decisionDryRun
+		return
+	}
+
+	if !ex.withinNonceGapTolerance(headNumber) {
+		decision.reason = decisionNonceGap
+		return
+	}
+
+	runVars := map[string]interface{}{
+		"jobSpec": jobSpec,
+	}
+	if trigger != nil {
+		runVars["triggerData"] = trigger.Data
+	}
+	vars := pipeline.NewVarsFrom(runVars)
 
 	run := pipeline.NewRun(*ex.job.PipelineSpec, vars)
-	if _, err := ex.pr.Run(ctxService, &run, ex.logger, true, nil); err != nil {
+	err = ex.runPipeline(ctxService, &run, upkeep, svcLogger)
+	decision.pipelineRunID = run.ID
+	switch ctxErr := ctxService.Err(); {
+	case errors.Is(ctxErr, context.DeadlineExceeded):
+		svcLogger.Warnw("upkeep execution timed out", "error", err)
+		ex.metrics.IncExecutionTimeouts(upkeep.Registry.ContractAddress.Hex())
+		ex.setLastError(upkeep, headNumber, errors.New("upkeep execution timed out"))
+		outcome = executionFailed
+		decision.reason = decisionTimedOut
+		return
+	case ctxErr != nil && ctxBatch.Err() != nil:
+		// the upkeep is re-evaluated on the head that replaced this one
+		svcLogger.Infow("upkeep execution cancelled, its head was replaced by a reorg", "error", err)
+		decision.reason = decisionReorged
+		return
+	case ctxErr != nil:
+		// cancelled by Close, not an execution failure
+		ex.abandoned.Inc()
+		svcLogger.Debugw("upkeep execution cancelled by shutdown", "error", err)
+		decision.reason = decisionShutdown
+		return
+	case err != nil:
 		ex.logger.With("error", err).Errorw("failed executing run")
+		ex.metrics.IncPipelineRuns(upkeep.Registry.ContractAddress.Hex(), pipeline.RunStatusErrored)
+		ex.setLastError(upkeep, headNumber, err)
+		outcome = executionFailed
+		decision.reason = decisionRunFailed
 		return
 	}
 
 	// Only after task runs where a tx was broadcast
+	ex.metrics.IncPipelineRuns(upkeep.Registry.ContractAddress.Hex(), pipelineRunStatus(run))
 	if run.State == pipeline.RunStatusCompleted {
-		err := ex.orm.SetLastRunHeightForUpkeepOnJob(ctxService, ex.job.ID, upkeep.UpkeepID, headNumber)
+		outcome = executionPerformed
+		decision.reason = decisionPerformed
+		if eligibilityFallback {
+			svcLogger.Warn("performed upkeep found eligible at the fallback height of the previously processed head")
+		}
+		ex.observeHeadToBroadcast(run, arrivedAt)
+		ex.setLastPerformed(upkeep, time.Now())
+		ex.recordPerformCost(upkeep, headNumber)
+		ex.setPerformedWindow(upkeep, head)
+		ex.checkLowBalance(ctxService, upkeep, head)
+		err := ex.orm.SetLastRunHeightForUpkeepOnJob(ctxService, ex.job.ID, upkeep.Registry.ContractAddress, upkeep.UpkeepID, headNumber)
 		if err != nil {
 			ex.logger.With("error", err).Errorw("failed to set last run height for upkeep")
 		}
+		ex.notifyPerformed(upkeep, headNumber, gasPrice, run.ID)
+	} else {
+		// failEarly check_upkeep_tx errors the run when the upkeep no longer needs performing,
+		// pipelines performing the checked performData don't check the upkeep again
+		decision.reason = decisionRunErrored
+		ex.setLastError(upkeep, headNumber, runError(run))
 	}
 }
 
-func (ex *UpkeepExecuter) estimateGasPrice(upkeep UpkeepRegistration) (*big.Int, error) {
-	performTxData, err := RegistryABI.Pack(
-		"performUpkeep",
-		big.NewInt(upkeep.UpkeepID),
-		common.Hex2Bytes("1234"), // placeholder
-	)
+// setLastError records a failed execution of upkeep on headNumber
+func (ex *UpkeepExecuter) setLastError(upkeep UpkeepRegistration, headNumber int64, runErr error) {
+	// the execution context may already be done
+	ctx, cancel := utils.ContextFromChan(ex.chStop)
+	defer cancel()
+	if err := ex.orm.SetLastErrorForUpkeepOnJob(ctx, ex.job.ID, upkeep.Registry.ContractAddress, upkeep.UpkeepID, headNumber, runErr); err != nil {
+		ex.logger.With("error", err).Errorw("failed to set last error for upkeep")
+	}
+}
+
+// runError returns the first error of an errored run
+func runError(run pipeline.Run) error {
+	for _, err := range run.Errors {
+		if !err.IsZero() {
+			return errors.New(err.String)
+		}
+	}
+	return errors.Errorf("run ended %s", run.State)
+}
+
+// pipelineRunStatus folds every non-completed final state into errored for the pipeline runs metric
+func pipelineRunStatus(run pipeline.Run) pipeline.RunStatus {
+	if run.State == pipeline.RunStatusCompleted {
+		return pipeline.RunStatusCompleted
+	}
+	return pipeline.RunStatusErrored
+}
+
+// checkUpkeepResult holds the decoded return values of checkUpkeep that the executer uses
+type checkUpkeepResult struct {
+	PerformData    []byte
+	MaxLinkPayment *big.Int
+	GasLimit       *big.Int
+	// MaxValidGasPrice is the highest gas price the registry reimburses the perform with,
+	// nil if the registry doesn't return one
+	MaxValidGasPrice *big.Int
+	// GasPrice is the gas price checkUpkeep was called with
+	GasPrice *big.Int
+}
+
+// gasPriceErrorDecision logs why the gas price of an upkeep's perform couldn't be estimated and
+// returns the reason and outcome of its decision
+func gasPriceErrorDecision(err error, lggr logger.Logger) (string, executionOutcome) {
+	switch {
+	case errors.Is(err, errGasPriceAboveMaximum):
+		lggr.Warnw("skipping upkeep, gas price estimate exceeds KeeperMaximumGasPrice", "error", err)
+		return decisionGasPriceAboveMaximum, executionSkipped
+	case errors.Is(err, errGasPriceAboveUpkeepMaximum):
+		lggr.Warnw("skipping upkeep, gas price estimate exceeds its maxGasPriceWei override", "error", err)
+		return decisionGasPriceAboveMaximum, executionSkipped
+	default:
+		lggr.Error(errors.Wrap(err, "estimating gas price"))
+		return decisionGasEstimationFailed, executionFailed
+	}
+}
+
+// simulatePerformUpkeep eth_calls performUpkeep from the keeper's address against the latest block,
+// it errors if the perform tx would revert
+func (ex *UpkeepExecuter) simulatePerformUpkeep(ctx context.Context, upkeep UpkeepRegistration, performData []byte, gasLimit uint64) error {
+	version, err := ex.registryVersion(upkeep)
 	if err != nil {
-		return nil, errors.Wrap(err, "unable to construct performUpkeep data")
+		return err
 	}
-	gasPrice, _, err := ex.gasEstimator.EstimateGas(performTxData, upkeep.ExecuteGas)
+	performTxData, err := version.packPerformUpkeep(upkeep.UpkeepID, performData)
 	if err != nil {
-		return nil, errors.Wrap(err, "unable to estimate gas")
+		return err
+	}
+	release, err := ex.acquireSimulationSlot(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	to := upkeep.Registry.ContractAddress.Address()
+	_, err = ex.ethClient.CallContract(ctx, ethereum.CallMsg{
+		From: upkeep.Registry.FromAddress.Address(),
+		To:   &to,
+		Gas:  gasLimit,
+		Data: performTxData,
+	}, nil)
+	return errors.Wrap(err, "performUpkeep call failed")
+}
+
+// simulateUpkeep runs checkUpkeep at gasPrice once a slot in the simulationQueue is free
+func (ex *UpkeepExecuter) simulateUpkeep(ctx context.Context, upkeep UpkeepRegistration, gasPrice *big.Int) (checkUpkeepResult, error) {
+	release, err := ex.acquireSimulationSlot(ctx)
+	if err != nil {
+		return checkUpkeepResult{}, err
+	}
+	defer release()
+	return ex.checkUpkeep(ctx, upkeep, gasPrice)
+}
+
+// acquireSimulationSlot blocks until a slot in the simulationQueue is free or ctx is done.
+// Every simulation eth_call, single or batched, holds a slot while it is in flight.
+func (ex *UpkeepExecuter) acquireSimulationSlot(ctx context.Context) (release func(), err error) {
+	if ex.simulationQueue == nil {
+		return func() {}, nil
+	}
+	select {
+	case ex.simulationQueue <- struct{}{}:
+		return func() { <-ex.simulationQueue }, nil
+	case <-ctx.Done():
+		return nil, errors.Wrap(ctx.Err(), "waiting for a simulation slot")
+	}
+}
+
+// checkUpkeep calls checkUpkeep at gasPrice on the registry and returns the decoded result.
+// A reverted call means the upkeep does not need to be performed, calls failing at the RPC level
+// return errCheckUpkeepUnavailable.
+func (ex *UpkeepExecuter) checkUpkeep(ctx context.Context, upkeep UpkeepRegistration, gasPrice *big.Int) (checkUpkeepResult, error) {
+	version, err := ex.registryVersion(upkeep)
+	if err != nil {
+		return checkUpkeepResult{}, err
+	}
+	msg, err := ex.checkUpkeepCallMsg(version, upkeep, gasPrice)
+	if err != nil {
+		return checkUpkeepResult{}, err
+	}
+	out, err := ex.ethClient.CallContract(ctx, msg, ex.checkBlockNumber())
+	if err != nil {
+		return checkUpkeepResult{}, checkUpkeepCallError(err)
+	}
+	result, err := version.unpackCheckUpkeep(out)
+	result.GasPrice = gasPrice
+	return result, err
+}
+
+// checkUpkeepCallMsg returns the eth_call of checkUpkeep for upkeep on a registry of version, priced at gasPrice
+func (ex *UpkeepExecuter) checkUpkeepCallMsg(version registryVersion, upkeep UpkeepRegistration, gasPrice *big.Int) (ethereum.CallMsg, error) {
+	checkTxData, err := version.packCheckUpkeep(upkeep.UpkeepID, upkeep.Registry.FromAddress.Address())
+	if err != nil {
+		return ethereum.CallMsg{}, err
+	}
+	to := upkeep.Registry.ContractAddress.Address()
+	return ethereum.CallMsg{
+		To:       &to,
+		Gas:      ex.checkUpkeepGasLimit(upkeep),
+		GasPrice: gasPrice,
+		Data:     checkTxData,
+	}, nil
+}
+
+// checkBlockNumber returns the block number argument of checkUpkeep calls for KeeperCheckBlockTag,
+// nil being the latest block, or the replayed block while replaying a head for GenerateReport
+func (ex *UpkeepExecuter) checkBlockNumber() *big.Int {
+	if ex.replayBlock != nil {
+		return ex.replayBlock
+	}
+	switch tag := ex.config.KeeperCheckBlockTag(); tag {
+	case CheckBlockTagPending:
+		return big.NewInt(int64(rpc.PendingBlockNumber))
+	default:
+		if tag != CheckBlockTagLatest {
+			ex.logger.Warnf("unrecognised check block tag '%s', falling back to '%s'", tag, CheckBlockTagLatest)
+		}
+		return nil
+	}
+}
+
+// performUpkeepGasLimit returns the gas limit of the performUpkeep transaction, clamped to KeeperMaxPerformGasLimit
+func (ex *UpkeepExecuter) performUpkeepGasLimit(upkeep UpkeepRegistration, lggr logger.Logger) uint64 {
+	gasLimit := upkeep.ExecuteGas + ex.registryPerformGasOverhead()
+	if maxGasLimit := ex.config.KeeperMaxPerformGasLimit(); maxGasLimit > 0 && gasLimit > maxGasLimit {
+		lggr.Warnw("perform gas limit exceeds KeeperMaxPerformGasLimit, clamping it",
+			"gasLimit", gasLimit, "maxPerformGasLimit", maxGasLimit)
+		return maxGasLimit
+	}
+	return gasLimit
+}
+
+// estimatedGasLimit returns the gas limit returned by the gas estimator if it is higher than gasLimit,
+// clamped to KeeperMaxPerformGasLimit and the block gas limit
+func (ex *UpkeepExecuter) estimatedGasLimit(gasLimit, estimated uint64, head eth.Head, lggr logger.Logger) uint64 {
+	if maxGasLimit := ex.config.KeeperMaxPerformGasLimit(); maxGasLimit > 0 && estimated > maxGasLimit {
+		lggr.Warnw("gas limit of the gas estimator exceeds KeeperMaxPerformGasLimit, clamping it",
+			"estimatedGasLimit", estimated, "maxPerformGasLimit", maxGasLimit)
+		estimated = maxGasLimit
+	}
+	if blockGasLimit := ex.blockGasLimit(head); blockGasLimit > 0 && estimated > blockGasLimit {
+		estimated = blockGasLimit
+	}
+	if estimated <= gasLimit {
+		return gasLimit
+	}
+	lggr.Infow("using the perform gas limit of the gas estimator", "gasLimit", estimated, "configuredGasLimit", gasLimit)
+	return estimated
+}
+
+// executionContext is cancelled after KeeperExecutionTimeout, when the executer is forced to stop, or with parent
+func (ex *UpkeepExecuter) executionContext(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(parent, ex.config.KeeperExecutionTimeout())
+	go func() {
+		select {
+		case <-ex.chForceStop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
+
+// blockGasLimit returns KeeperBlockGasLimit, or the gas limit of head if unset.
+// Zero means the block gas limit is unknown and performs aren't checked against it.
+func (ex *UpkeepExecuter) blockGasLimit(head eth.Head) uint64 {
+	if gasLimit := ex.config.KeeperBlockGasLimit(); gasLimit > 0 {
+		return gasLimit
+	}
+	return head.GasLimit
+}
+
+func (ex *UpkeepExecuter) checkUpkeepGasLimit(upkeep UpkeepRegistration) uint64 {
+	return ex.registryCheckGasOverhead() + uint64(upkeep.Registry.CheckGas) +
+		ex.registryPerformGasOverhead() + upkeep.ExecuteGas
+}
+
+// registryCheckGasOverhead returns the check gas overhead of the job's registry,
+// falling back to KeeperRegistryCheckGasOverhead
+func (ex *UpkeepExecuter) registryCheckGasOverhead() uint64 {
+	if overhead := ex.job.KeeperSpec.RegistryCheckGasOverhead; overhead != nil {
+		return *overhead
+	}
+	return ex.config.KeeperRegistryCheckGasOverhead()
+}
+
+// registryPerformGasOverhead returns the perform gas overhead of the job's registry,
+// falling back to KeeperRegistryPerformGasOverhead
+func (ex *UpkeepExecuter) registryPerformGasOverhead() uint64 {
+	if overhead := ex.job.KeeperSpec.RegistryPerformGasOverhead; overhead != nil {
+		return *overhead
+	}
+	return ex.config.KeeperRegistryPerformGasOverhead()
+}
+
+// emitEligibilityChanges diffs activeUpkeeps against the upkeeps eligible on the previous head
+// and emits an event for every upkeep that entered or left the eligible set
+func (ex *UpkeepExecuter) emitEligibilityChanges(head eth.Head, activeUpkeeps []UpkeepRegistration) {
+	eligible := make(map[upkeepKey]UpkeepRegistration, len(activeUpkeeps))
+	for _, upkeep := range activeUpkeeps {
+		eligible[upkeep.key()] = upkeep
+		if _, exists := ex.eligibleUpkeeps[upkeep.key()]; !exists {
+			ex.events.Emit(Event{Type: EventEligibleEntered, RegistryAddress: upkeep.Registry.ContractAddress.Address(), UpkeepID: upkeep.UpkeepID, BlockNumber: head.Number,
+				Data: addTurnWindow(nil, upkeep, head.Number)})
+		}
+	}
+	var left []UpkeepRegistration
+	for key, upkeep := range ex.eligibleUpkeeps {
+		if _, exists := eligible[key]; !exists {
+			left = append(left, upkeep)
+		}
+	}
+	sort.Slice(left, func(i, j int) bool {
+		if left[i].UpkeepID != left[j].UpkeepID {
+			return left[i].UpkeepID < left[j].UpkeepID
+		}
+		return left[i].Registry.ContractAddress.Hex() < left[j].Registry.ContractAddress.Hex()
+	})
+	for _, upkeep := range left {
+		ex.resetOverdue(upkeep)
+		// an upkeep usually leaves when its turn ends, the window is the next turn of this node
+		ex.events.Emit(Event{Type: EventEligibleLeft, RegistryAddress: upkeep.Registry.ContractAddress.Address(), UpkeepID: upkeep.UpkeepID, BlockNumber: head.Number,
+			Data: addTurnWindow(nil, upkeep, head.Number)})
+	}
+	ex.eligibleUpkeeps = eligible
+}
+
+func (ex *UpkeepExecuter) setUpkeepValue(upkeep UpkeepRegistration, value *big.Int) {
+	ex.upkeepValuesMu.Lock()
+	defer ex.upkeepValuesMu.Unlock()
+	ex.upkeepValues.set(upkeep.key(), value)
+}
+
+func (ex *UpkeepExecuter) upkeepValueCount() int {
+	ex.upkeepValuesMu.Lock()
+	defer ex.upkeepValuesMu.Unlock()
+	return ex.upkeepValues.len()
+}
+
+// sortByOverdue orders upkeeps by their last run height, upkeeps that never ran or ran longest ago first
+func sortByOverdue(upkeeps []UpkeepRegistration) {
+	sort.SliceStable(upkeeps, func(i, j int) bool {
+		return upkeeps[i].LastRunBlockHeight < upkeeps[j].LastRunBlockHeight
+	})
+}
+
+// sortByValue orders upkeeps by the payment of their last check, highest first. Upkeeps that
+// haven't been checked yet go first so that their value becomes known.
+func (ex *UpkeepExecuter) sortByValue(upkeeps []UpkeepRegistration) {
+	ex.upkeepValuesMu.Lock()
+	defer ex.upkeepValuesMu.Unlock()
+	sort.SliceStable(upkeeps, func(i, j int) bool {
+		vi, iKnown := ex.upkeepValues.peek(upkeeps[i].key())
+		vj, jKnown := ex.upkeepValues.peek(upkeeps[j].key())
+		if !iKnown || !jKnown {
+			return !iKnown && jKnown
+		}
+		return vi.(*big.Int).Cmp(vj.(*big.Int)) > 0
+	})
+}
+
+// setLastHead records head as the last processed head.
+// It must only be called from the run goroutine.
+func (ex *UpkeepExecuter) setLastHead(head eth.Head) {
+	ex.lastHead = &head
+	ex.lastHeadAt = time.Now()
+}
+
+// isDuplicateHead returns true if head has the same number and hash as the last processed head.
+// It must only be called from the run goroutine.
+func (ex *UpkeepExecuter) isDuplicateHead(head eth.Head) bool {
+	return ex.lastHead != nil && head.Number == ex.lastHead.Number && head.Hash == ex.lastHead.Hash
+}
+
+// isOutOfOrderHead returns true if head is lower than the last processed head, as when a head is
+// delivered late. A head at the same height with a different hash is a reorg and isn't out of order.
+// It must only be called from the run goroutine.
+func (ex *UpkeepExecuter) isOutOfOrderHead(head eth.Head) bool {
+	return ex.lastHead != nil && head.Number < ex.lastHead.Number
+}
+
+// observeReorg counts the upkeeps re-evaluated on head if it replaced the previously processed head
+func (ex *UpkeepExecuter) observeReorg(head eth.Head, upkeeps int) {
+	if !ex.isReorg(head) {
+		return
+	}
+	ex.logger.Infow("reorg detected, re-evaluating upkeeps",
+		"blockheight", head.Number, "previousBlockheight", ex.lastHead.Number, "upkeeps", upkeeps)
+	ex.metrics.AddReorgReevaluations(ex.job.KeeperSpec.ContractAddress.Hex(), upkeeps)
+}
+
+// isReorg returns true if head does not extend the previously processed head
+func (ex *UpkeepExecuter) isReorg(head eth.Head) bool {
+	return ex.lastHead != nil && replaces(head, *ex.lastHead)
+}
+
+// replaces returns true if head is on a different chain than previous. Heads more than one block
+// ahead of previous can't be told apart from heads skipped on the same chain and are assumed to extend it.
+func replaces(head, previous eth.Head) bool {
+	if head.Hash == previous.Hash {
+		return false
+	}
+	if head.Number == previous.Number+1 {
+		return head.ParentHash != previous.Hash
+	}
+	return head.Number <= previous.Number
+}
+
+// checkPerformData stands in for the performData in the gas price estimate the checkUpkeep call is priced
+// with, the performData is only known after the check. The perform is sent at the same gas price, the gas
+// limit estimated for KeeperUseEstimatedGasLimit is estimated again with the checked performData.
+var checkPerformData = []byte{}
+
+// estimateCheckGasPrice returns the gas price of the perform of upkeep, estimated before upkeep is checked
+func (ex *UpkeepExecuter) estimateCheckGasPrice(ctx context.Context, upkeep UpkeepRegistration, head eth.Head, prices *headGasPrices, lggr logger.Logger) (*big.Int, error) {
+	gasPrice, _, err := ex.estimateGasPriceWithRetries(ctx, upkeep, head, checkPerformData, prices, lggr)
+	return gasPrice, err
+}
+
+// estimateGasPrice returns the gas price of the perform transaction of upkeep, as priced by the UpkeepGasStrategy
+// of the executer from the gas estimator's estimate, and the gas limit returned by the gas estimator.
+// prices holds the gas prices shared by the upkeeps of head, nil if they aren't shared.
+func (ex *UpkeepExecuter) estimateGasPrice(ctx context.Context, upkeep UpkeepRegistration, head eth.Head, performData []byte, prices *headGasPrices) (*big.Int, uint64, error) {
+	if performData == nil {
+		return nil, 0, errors.New("performData of the checked upkeep is required to estimate its gas price")
+	}
+	version, err := ex.registryVersion(upkeep)
+	if err != nil {
+		return nil, 0, err
+	}
+	performTxData, err := version.packPerformUpkeep(upkeep.UpkeepID, performData)
+	if err != nil {
+		return nil, 0, err
+	}
+	gasPrice, gasLimit, err := ex.estimatorGasPrice(upkeep, performTxData, prices)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "unable to estimate gas")
+	}
+	gasPrice = ex.sanitizeGasPrice(upkeep, gasPrice)
+	gasPrice = ex.reconcileGasPrice(gasPrice, head.BaseFeePerGas)
+	maxGasPrice := ex.config.KeeperMaximumGasPrice()
+	capped := maxGasPrice != nil && maxGasPrice.Sign() > 0
+	if capped && gasPrice.Cmp(maxGasPrice) > 0 {
+		return nil, 0, errors.Wrapf(errGasPriceAboveMaximum, "estimated %s wei, maximum %s wei", gasPrice, maxGasPrice)
+	}
+	upkeepMaxGasPrice, skipAboveUpkeepMax := ex.upkeepMaxGasPrice(upkeep)
+	if skipAboveUpkeepMax && gasPrice.Cmp(upkeepMaxGasPrice) > 0 {
+		return nil, 0, errors.Wrapf(errGasPriceAboveUpkeepMaximum, "estimated %s wei, maximum %s wei", gasPrice, upkeepMaxGasPrice)
+	}
+	gasPrice, err = ex.upkeepGasStrategy().PriceFor(ctx, upkeep, gasPrice)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "unable to price the perform transaction")
+	}
+	if capped && gasPrice.Cmp(maxGasPrice) > 0 {
+		gasPrice = new(big.Int).Set(maxGasPrice)
+	}
+	if upkeepMaxGasPrice != nil && gasPrice.Cmp(upkeepMaxGasPrice) > 0 {
+		gasPrice = upkeepMaxGasPrice
+	}
+	ex.checkBaseFeeOverpayment(upkeep, gasPrice, head.BaseFeePerGas)
+	return gasPrice, gasLimit, nil
+}
+
+// withinPerformWindow returns false if the job spec restricts the upkeep to perform windows
+// and the head timestamp falls outside all of them, allowing for KeeperClockSkewTolerance
+func (ex *UpkeepExecuter) withinPerformWindow(upkeep UpkeepRegistration, head eth.Head) bool {
+	override, exists := ex.job.KeeperSpec.UpkeepOverrides.ForUpkeep(upkeep.UpkeepID)
+	if !exists || len(override.PerformWindows) == 0 {
+		return true
+	}
+	windows, err := parsePerformWindows(override.PerformWindows)
+	if err != nil {
+		// already validated when the job was created
+		ex.logger.Errorw("invalid perform windows", "upkeepID", upkeep.UpkeepID, "error", err)
+		return false
+	}
+	for _, w := range windows {
+		if ex.withinTolerance(headTime(head), w.contains) {
+			return true
+		}
+	}
+	return false
+}
+
+// gasPriceSpeed returns the speed tier configured for the upkeep in the job spec,
+// falling back to KeeperGasPriceSpeed
+func (ex *UpkeepExecuter) gasPriceSpeed(upkeep UpkeepRegistration) gas.Speed {
+	if override, exists := ex.job.KeeperSpec.UpkeepOverrides.ForUpkeep(upkeep.UpkeepID); exists && override.GasPriceSpeed != "" {
+		return gas.Speed(override.GasPriceSpeed)
+	}
+	return gas.Speed(ex.config.KeeperGasPriceSpeed())
+}
+
+// gasPriceBufferPercent returns the gas price buffer configured for the upkeep in the job spec,
+// falling back to KeeperGasPriceBufferPercent
+func (ex *UpkeepExecuter) gasPriceBufferPercent(upkeep UpkeepRegistration) uint32 {
+	if override, exists := ex.job.KeeperSpec.UpkeepOverrides.ForUpkeep(upkeep.UpkeepID); exists && override.GasPriceBufferPercent != nil {
+		return *override.GasPriceBufferPercent
+	}
+	return ex.config.KeeperGasPriceBufferPercent()
+}
+
+// upkeepMaxGasPrice returns the maxGasPriceWei configured for the upkeep in the job spec, if any,
+// and whether the upkeep is skipped while the estimate exceeds it
+func (ex *UpkeepExecuter) upkeepMaxGasPrice(upkeep UpkeepRegistration) (*big.Int, bool) {
+	override, exists := ex.job.KeeperSpec.UpkeepOverrides.ForUpkeep(upkeep.UpkeepID)
+	if !exists || override.MaxGasPriceWei == nil {
+		return nil, false
+	}
+	return new(big.Int).SetUint64(*override.MaxGasPriceWei), override.SkipAboveMaxGasPrice
+}
+
+// reconcileGasPrice combines the estimator price with the head base fee according to
+// KeeperGasReconcileStrategy. Heads without a base fee (pre EIP-1559) leave the estimate untouched.
+func (ex *UpkeepExecuter) reconcileGasPrice(estimate *big.Int, baseFee *utils.Big) *big.Int {
+	if baseFee == nil {
+		return estimate
+	}
+	switch strategy := ex.config.KeeperGasReconcileStrategy(); strategy {
+	case GasReconcileStrategyEstimator:
+		return estimate
+	case GasReconcileStrategyBlend:
+		weight := ex.config.KeeperGasReconcileEstimatorWeight()
+		if weight > 100 {
+			weight = 100
+		}
+		return bigmath.Div(
+			bigmath.Add(bigmath.Mul(estimate, weight), bigmath.Mul(baseFee.ToInt(), 100-weight)),
+			100,
+		)
+	default:
+		if strategy != GasReconcileStrategyMax {
+			ex.logger.Warnf("unrecognised gas reconcile strategy '%s', falling back to '%s'", strategy, GasReconcileStrategyMax)
+		}
+		return bigmath.Max(estimate, baseFee.ToInt())
 	}
-	// add GasPriceBuffer to gasPrice
-	gasPrice = bigmath.Div(
-		bigmath.Mul(gasPrice, 100+ex.config.KeeperGasPriceBufferPercent()),
-		100,
-	)
-	return gasPrice, nil
 }
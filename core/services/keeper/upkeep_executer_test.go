@@ -2,13 +2,21 @@ package keeper_test
 
 import (
 	"context"
+	"fmt"
 	"math/big"
+	"net"
+	"strconv"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/onsi/gomega"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -26,8 +34,14 @@ import (
 	bptxmmocks "github.com/smartcontractkit/chainlink/core/services/bulletprooftxmanager/mocks"
 	"github.com/smartcontractkit/chainlink/core/services/eth"
 	gasmocks "github.com/smartcontractkit/chainlink/core/services/gas/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/headtracker"
+	httypes "github.com/smartcontractkit/chainlink/core/services/headtracker/types"
 	"github.com/smartcontractkit/chainlink/core/services/job"
 	"github.com/smartcontractkit/chainlink/core/services/keeper"
+	"github.com/smartcontractkit/chainlink/core/services/keystore/keys/ethkey"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	pipelinemocks "github.com/smartcontractkit/chainlink/core/services/pipeline/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/postgres"
 	"github.com/smartcontractkit/chainlink/core/utils"
 	bigmath "github.com/smartcontractkit/chainlink/core/utils/big_math"
 )
@@ -36,7 +50,8 @@ func newHead() eth.Head {
 	return eth.NewHead(big.NewInt(20), utils.NewHash(), utils.NewHash(), 1000, utils.NewBigI(0))
 }
 
-func setup(t *testing.T) (
+// setup starts an executer for a registry with one upkeep, overrides are applied to the config before the executer is created
+func setup(t *testing.T, overrides ...func(*configtest.TestGeneralConfig)) (
 	*gorm.DB,
 	*configtest.TestGeneralConfig,
 	*mocks.Client,
@@ -49,10 +64,14 @@ func setup(t *testing.T) (
 ) {
 	config := cltest.NewTestGeneralConfig(t)
 	config.Overrides.KeeperMaximumGracePeriod = null.IntFrom(0)
+	for _, override := range overrides {
+		override(config)
+	}
 	db := pgtest.NewGormDB(t)
 	config.SetDB(db)
 	keyStore := cltest.NewKeyStore(t, db)
 	ethClient := cltest.NewEthClientMockWithDefaultChain(t)
+	ethClient.On("PendingNonceAt", mock.Anything, mock.Anything).Maybe().Return(uint64(0), nil)
 	registry, job := cltest.MustInsertKeeperRegistry(t, db, keyStore.Eth())
 	cfg := cltest.NewTestGeneralConfig(t)
 	txm := new(bptxmmocks.TxManager)
@@ -61,9 +80,11 @@ func setup(t *testing.T) (
 	estimator.On("EstimateGas", mock.Anything, mock.Anything).Return(assets.GWei(60), uint64(0), nil)
 	cc := evmtest.NewChainSet(t, evmtest.TestChainOpts{TxManager: txm, DB: db, Client: ethClient, KeyStore: keyStore.Eth(), GeneralConfig: cfg})
 	jpv2 := cltest.NewJobPipelineV2(t, cfg, cc, db, keyStore)
+	require.NoError(t, jpv2.Pr.Start())
+	t.Cleanup(func() { jpv2.Pr.Close() })
 	ch := evmtest.MustGetDefaultChain(t, cc)
 	orm := keeper.NewORM(db, txm, config, bulletprooftxmanager.SendEveryStrategy{})
-	executer := keeper.NewUpkeepExecuter(job, orm, jpv2.Pr, ethClient, ch.HeadBroadcaster(), ch.TxManager().GetGasEstimator(), config.CreateProductionLogger(), config)
+	executer := keeper.NewUpkeepExecuter(job, orm, jpv2.Pr, ethClient, ch.HeadBroadcaster(), ch.TxManager().GetGasEstimator(), nil, config.CreateProductionLogger(), config, keeper.NewQueryLimiter(), nil, nil)
 	upkeep := cltest.MustInsertUpkeepForRegistry(t, db, config, registry)
 	err := executer.Start()
 	t.Cleanup(func() { executer.Close() })
@@ -71,6 +92,12 @@ func setup(t *testing.T) (
 	return db, config, ethClient, executer, registry, upkeep, job, jpv2, txm
 }
 
+// cancelOnShutdown cancels in-flight executions as soon as the executer is closed
+func cancelOnShutdown(c *configtest.TestGeneralConfig) {
+	deadline := time.Duration(0)
+	c.Overrides.KeeperShutdownDeadline = &deadline
+}
+
 var checkUpkeepResponse = struct {
 	PerformData    []byte
 	MaxLinkPayment *big.Int
@@ -81,7 +108,7 @@ var checkUpkeepResponse = struct {
 	PerformData:    common.Hex2Bytes("1234"),
 	MaxLinkPayment: big.NewInt(0), // doesn't matter
 	GasLimit:       big.NewInt(2_000_000),
-	GasWei:         big.NewInt(0), // doesn't matter
+	GasWei:         big.NewInt(0), // doesn't cap the gas price
 	LinkEth:        big.NewInt(0), // doesn't matter
 }
 
@@ -92,6 +119,15 @@ func Test_UpkeepExecuter_ErrorsIfStartedTwice(t *testing.T) {
 	require.Error(t, err)
 }
 
+func Test_UpkeepExecuter_FlushCaches(t *testing.T) {
+	t.Parallel()
+	_, _, _, executer, _, _, _, _, _ := setup(t)
+
+	require.NoError(t, executer.FlushCaches())
+	require.NoError(t, executer.Close())
+	require.Error(t, executer.FlushCaches())
+}
+
 func Test_UpkeepExecuter_PerformsUpkeep_Happy(t *testing.T) {
 	t.Parallel()
 
@@ -112,6 +148,7 @@ func Test_UpkeepExecuter_PerformsUpkeep_Happy(t *testing.T) {
 			Run(func(mock.Arguments) { ethTxCreated.ItHappened() })
 
 		registryMock := cltest.NewContractMockReceiver(t, ethMock, keeper.RegistryABI, registry.ContractAddress.Address())
+		// the executer checks the upkeep once, the pipeline performs the checked performData
 		registryMock.MockMatchedResponse(
 			"checkUpkeep",
 			func(callArgs ethereum.CallMsg) bool {
@@ -119,7 +156,7 @@ func Test_UpkeepExecuter_PerformsUpkeep_Happy(t *testing.T) {
 					callArgs.Gas == 650_000
 			},
 			checkUpkeepResponse,
-		)
+		).Once()
 
 		head := newHead()
 		executer.OnNewLongestChain(context.Background(), head)
@@ -128,6 +165,7 @@ func Test_UpkeepExecuter_PerformsUpkeep_Happy(t *testing.T) {
 		require.Len(t, runs, 1)
 		assert.False(t, runs[0].HasErrors())
 		assertLastRunHeight(t, db, upkeep, 20)
+		ethMock.AssertNumberOfCalls(t, "CallContract", 1)
 
 		ethMock.AssertExpectations(t)
 		txm.AssertExpectations(t)
@@ -190,6 +228,158 @@ func Test_UpkeepExecuter_PerformsUpkeep_Happy(t *testing.T) {
 	})
 }
 
+func Test_UpkeepExecuter_SkipsOversizedPerformData(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewGomegaWithT(t)
+
+	db, config, ethMock, executer, registry, _, _, _, _ := setup(t, func(c *configtest.TestGeneralConfig) {
+		c.Overrides.KeeperMaxPerformDataSize = null.IntFrom(5000)
+	})
+
+	oversized := checkUpkeepResponse
+	oversized.PerformData = make([]byte, config.KeeperMaxPerformDataSize()+1)
+
+	callCount := atomic.NewInt32(0)
+	registryMock := cltest.NewContractMockReceiver(t, ethMock, keeper.RegistryABI, registry.ContractAddress.Address())
+	registryMock.MockResponse("checkUpkeep", oversized).Run(func(args mock.Arguments) {
+		callCount.Inc()
+	})
+
+	head := newHead()
+	executer.OnNewLongestChain(context.Background(), head)
+
+	g.Eventually(callCount.Load).Should(gomega.Equal(int32(1)))
+	g.Consistently(callCount.Load).Should(gomega.Equal(int32(1)))
+	cltest.AssertCountStays(t, db, bulletprooftxmanager.EthTx{}, 0)
+	ethMock.AssertExpectations(t)
+}
+
+func Test_UpkeepExecuter_SimulatesPerformUpkeep(t *testing.T) {
+	t.Parallel()
+	simulatePerformUpkeep := func(c *configtest.TestGeneralConfig) {
+		c.Overrides.KeeperSimulatePerformUpkeep = null.BoolFrom(true)
+	}
+
+	t.Run("runs upkeep if the simulation succeeds", func(t *testing.T) {
+		_, _, ethMock, executer, registry, _, job, jpv2, txm := setup(t, simulatePerformUpkeep)
+
+		ethTxCreated := cltest.NewAwaiter()
+		txm.On("CreateEthTransaction", mock.Anything, mock.Anything).
+			Once().
+			Return(bulletprooftxmanager.EthTx{ID: 1}, nil).
+			Run(func(mock.Arguments) { ethTxCreated.ItHappened() })
+
+		registryMock := cltest.NewContractMockReceiver(t, ethMock, keeper.RegistryABI, registry.ContractAddress.Address())
+		registryMock.MockResponse("checkUpkeep", checkUpkeepResponse)
+		registryMock.MockMatchedResponse(
+			"performUpkeep",
+			func(callArgs ethereum.CallMsg) bool {
+				return callArgs.From == registry.FromAddress.Address()
+			},
+			true,
+		).Once()
+
+		head := newHead()
+		executer.OnNewLongestChain(context.Background(), head)
+		ethTxCreated.AwaitOrFail(t)
+		runs := cltest.WaitForPipelineComplete(t, 0, job.ID, 1, 5, jpv2.Jrm, time.Second, 100*time.Millisecond)
+		require.Len(t, runs, 1)
+		assert.False(t, runs[0].HasErrors())
+
+		ethMock.AssertExpectations(t)
+		txm.AssertExpectations(t)
+	})
+
+	t.Run("skips upkeep if the simulation reverts", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		db, _, ethMock, executer, registry, _, job, _, txm := setup(t, simulatePerformUpkeep)
+
+		simulated := atomic.NewInt32(0)
+		registryMock := cltest.NewContractMockReceiver(t, ethMock, keeper.RegistryABI, registry.ContractAddress.Address())
+		registryMock.MockResponse("checkUpkeep", checkUpkeepResponse)
+		registryMock.MockRevertResponse("performUpkeep").Run(func(args mock.Arguments) {
+			simulated.Inc()
+		})
+
+		head := newHead()
+		executer.OnNewLongestChain(context.Background(), head)
+
+		g.Eventually(simulated.Load).Should(gomega.Equal(int32(1)))
+		cltest.AssertPipelineRunsStays(t, job.PipelineSpecID, db, 0)
+		ethMock.AssertExpectations(t)
+		txm.AssertExpectations(t)
+	})
+}
+
+func Test_UpkeepExecuter_BatchChecksUpkeeps(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewGomegaWithT(t)
+
+	db, config, ethMock, executer, registry, _, _, _, _ := setup(t, func(c *configtest.TestGeneralConfig) {
+		c.Overrides.KeeperBatchCheckUpkeep = null.BoolFrom(true)
+		c.Overrides.KeeperBatchCheckUpkeepSize = null.IntFrom(2)
+	})
+	for i := 0; i < 3; i++ {
+		cltest.MustInsertUpkeepForRegistry(t, db, config, registry)
+	}
+	upkeepID := func(data []byte) int64 {
+		return new(big.Int).SetBytes(data[4:36]).Int64()
+	}
+
+	// the first upkeep of every batch passes the check, the second one reverts
+	gasPrice := bigmath.Div(bigmath.Mul(assets.GWei(60), 100+config.KeeperGasPriceBufferPercent()), 100)
+	passing := make(chan int64, 4)
+	batches := atomic.NewInt32(0)
+	passed, err := keeper.RegistryABI.Methods["checkUpkeep"].Outputs.Pack(
+		checkUpkeepResponse.PerformData,
+		checkUpkeepResponse.MaxLinkPayment,
+		checkUpkeepResponse.GasLimit,
+		checkUpkeepResponse.GasWei,
+		checkUpkeepResponse.LinkEth,
+	)
+	require.NoError(t, err)
+	ethMock.On("BatchCallContext", mock.Anything, mock.MatchedBy(func(b []rpc.BatchElem) bool {
+		return len(b) > 0 && b[0].Method == "eth_call"
+	})).Return(nil).Run(func(args mock.Arguments) {
+		batches.Inc()
+		elems := args.Get(1).([]rpc.BatchElem)
+		assert.LessOrEqual(t, len(elems), 2)
+		for i := range elems {
+			if i > 0 {
+				elems[i].Error = errors.New("execution reverted")
+				continue
+			}
+			call := elems[i].Args[0].(map[string]interface{})
+			// every check is priced at the gas price of the upkeep's perform
+			assert.Equal(t, gasPrice.String(), call["gasPrice"].(*hexutil.Big).String())
+			passing <- upkeepID(call["data"].(hexutil.Bytes))
+			*elems[i].Result.(*hexutil.Bytes) = passed
+		}
+	})
+
+	// only the upkeeps that passed the batched check run their pipeline
+	executed := make(chan int64, 4)
+	registryMock := cltest.NewContractMockReceiver(t, ethMock, keeper.RegistryABI, registry.ContractAddress.Address())
+	registryMock.MockRevertResponse("checkUpkeep").Run(func(args mock.Arguments) {
+		executed <- upkeepID(args.Get(1).(ethereum.CallMsg).Data)
+	})
+
+	executer.OnNewLongestChain(context.Background(), newHead())
+
+	g.Eventually(batches.Load).Should(gomega.Equal(int32(2)))
+	want := []int64{<-passing, <-passing}
+	var got []int64
+	g.Eventually(func() []int64 {
+		select {
+		case id := <-executed:
+			got = append(got, id)
+		default:
+		}
+		return got
+	}, 5*time.Second).Should(gomega.ConsistOf(want))
+	g.Consistently(func() int { return len(executed) }).Should(gomega.Equal(0))
+}
+
 func Test_UpkeepExecuter_PerformsUpkeep_Error(t *testing.T) {
 	t.Parallel()
 	g := gomega.NewGomegaWithT(t)
@@ -209,3 +399,962 @@ func Test_UpkeepExecuter_PerformsUpkeep_Error(t *testing.T) {
 	cltest.AssertCountStays(t, db, bulletprooftxmanager.EthTx{}, 0)
 	ethMock.AssertExpectations(t)
 }
+
+func Test_UpkeepExecuter_RetriesTransientGasEstimationErrors(t *testing.T) {
+	t.Parallel()
+
+	config := cltest.NewTestGeneralConfig(t)
+	config.Overrides.KeeperMaximumGracePeriod = null.IntFrom(0)
+	backoff := time.Millisecond
+	config.Overrides.KeeperGasEstimationRetryBackoff = &backoff
+	db := pgtest.NewGormDB(t)
+	config.SetDB(db)
+	keyStore := cltest.NewKeyStore(t, db)
+	ethClient := cltest.NewEthClientMockWithDefaultChain(t)
+	ethClient.On("PendingNonceAt", mock.Anything, mock.Anything).Maybe().Return(uint64(0), nil)
+	registry, j := cltest.MustInsertKeeperRegistry(t, db, keyStore.Eth())
+	upkeep := cltest.MustInsertUpkeepForRegistry(t, db, config, registry)
+	cltest.NewContractMockReceiver(t, ethClient, keeper.RegistryABI, registry.ContractAddress.Address()).MockResponse("checkUpkeep", checkUpkeepResponse)
+
+	txm := new(bptxmmocks.TxManager)
+	estimator := new(gasmocks.Estimator)
+	txm.On("GetGasEstimator").Return(estimator)
+	// the estimator fails twice to reach the eth node, then recovers
+	networkErr := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	estimator.On("EstimateGas", mock.Anything, mock.Anything).Return((*big.Int)(nil), uint64(0), networkErr).Twice()
+	estimator.On("EstimateGas", mock.Anything, mock.Anything).Return(assets.GWei(60), uint64(0), nil)
+	ethTxCreated := cltest.NewAwaiter()
+	txm.On("CreateEthTransaction", mock.Anything, mock.Anything).
+		Once().
+		Return(bulletprooftxmanager.EthTx{}, nil).
+		Run(func(mock.Arguments) { ethTxCreated.ItHappened() })
+	cfg := cltest.NewTestGeneralConfig(t)
+	cc := evmtest.NewChainSet(t, evmtest.TestChainOpts{TxManager: txm, DB: db, Client: ethClient, KeyStore: keyStore.Eth(), GeneralConfig: cfg})
+	jpv2 := cltest.NewJobPipelineV2(t, cfg, cc, db, keyStore)
+	require.NoError(t, jpv2.Pr.Start())
+	t.Cleanup(func() { jpv2.Pr.Close() })
+	ch := evmtest.MustGetDefaultChain(t, cc)
+	orm := keeper.NewORM(db, txm, config, bulletprooftxmanager.SendEveryStrategy{})
+	executer := keeper.NewUpkeepExecuter(j, orm, jpv2.Pr, ethClient, ch.HeadBroadcaster(), estimator, nil, config.CreateProductionLogger(), config, keeper.NewQueryLimiter(), nil, nil)
+	require.NoError(t, executer.Start())
+	t.Cleanup(func() { executer.Close() })
+
+	executer.OnNewLongestChain(context.Background(), newHead())
+	ethTxCreated.AwaitOrFail(t)
+	runs := cltest.WaitForPipelineComplete(t, 0, j.ID, 1, 5, jpv2.Jrm, time.Second, 100*time.Millisecond)
+	require.Len(t, runs, 1)
+	assert.False(t, runs[0].HasErrors())
+	assertLastRunHeight(t, db, upkeep, 20)
+	estimator.AssertNumberOfCalls(t, "EstimateGas", 3)
+	txm.AssertExpectations(t)
+}
+
+func Test_UpkeepExecuter_DefersUpkeepsOnNonceGap(t *testing.T) {
+	t.Parallel()
+
+	t.Run("of any sending key", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		db, _, ethMock, executer, registry, _, job, _, txm := setup(t)
+		// the ethtx task may send the perform from a sending key other than the keeper spec's fromAddress
+		require.NoError(t, db.Exec(`INSERT INTO eth_key_states (address, next_nonce, is_funding, evm_chain_id, created_at, updated_at)
+			SELECT ?, 2, false, evm_chain_id, NOW(), NOW() FROM eth_key_states WHERE address = ?`,
+			cltest.NewAddress(), job.KeeperSpec.FromAddress.Address()).Error)
+
+		checked := atomic.NewBool(false)
+		registryMock := cltest.NewContractMockReceiver(t, ethMock, keeper.RegistryABI, registry.ContractAddress.Address())
+		registryMock.MockResponse("checkUpkeep", checkUpkeepResponse).Run(func(mock.Arguments) { checked.Store(true) })
+
+		executer.OnNewLongestChain(context.TODO(), newHead())
+
+		g.Eventually(checked.Load).Should(gomega.BeTrue())
+		cltest.AssertPipelineRunsStays(t, job.PipelineSpecID, db, 0)
+		txm.AssertNotCalled(t, "CreateEthTransaction", mock.Anything, mock.Anything)
+	})
+
+	t.Run("only measured for upkeeps about to be performed", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		db, _, ethMock, executer, registry, _, job, _, _ := setup(t)
+		require.NoError(t, db.Exec(`UPDATE eth_key_states SET next_nonce = 2 WHERE address = ?`, job.KeeperSpec.FromAddress.Address()).Error)
+
+		checked := atomic.NewBool(false)
+		registryMock := cltest.NewContractMockReceiver(t, ethMock, keeper.RegistryABI, registry.ContractAddress.Address())
+		registryMock.MockRevertResponse("checkUpkeep").Run(func(mock.Arguments) { checked.Store(true) })
+
+		executer.OnNewLongestChain(context.TODO(), newHead())
+
+		g.Eventually(checked.Load).Should(gomega.BeTrue())
+		require.NoError(t, executer.Close())
+		ethMock.AssertNotCalled(t, "PendingNonceAt", mock.Anything, mock.Anything)
+	})
+}
+
+func Test_UpkeepExecuter_SkipsHeadsOnInsufficientBalance(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewGomegaWithT(t)
+
+	db, _, ethMock, executer, _, _, job, _, _ := setup(t, func(c *configtest.TestGeneralConfig) {
+		c.Overrides.KeeperMinimumBalance = assets.Ether(1)
+	})
+	ethMock.On("BalanceAt", mock.Anything, job.KeeperSpec.FromAddress.Address(), big.NewInt(20)).Once().Return(big.NewInt(0), nil)
+
+	head := newHead()
+	executer.OnNewLongestChain(context.TODO(), head)
+
+	g.Eventually(executer.Healthy).Should(gomega.HaveOccurred())
+	cltest.AssertPipelineRunsStays(t, job.PipelineSpecID, db, 0)
+	ethMock.AssertExpectations(t)
+}
+
+func Test_UpkeepExecuter_SkipsPausedRegistry(t *testing.T) {
+	t.Parallel()
+
+	db, _, ethMock, executer, registry, _, job, _, _ := setup(t)
+	require.NoError(t, db.Exec(`UPDATE keeper_registries SET paused = true WHERE id = ?`, registry.ID).Error)
+
+	head := newHead()
+	executer.OnNewLongestChain(context.TODO(), head)
+
+	cltest.AssertPipelineRunsStays(t, job.PipelineSpecID, db, 0)
+	ethMock.AssertExpectations(t)
+}
+
+func Test_UpkeepExecuter_PausedRegistryHeadIsProcessed(t *testing.T) {
+	t.Parallel()
+
+	db, _, ethMock, executer, registry, _, _, _, _ := setup(t)
+	require.NoError(t, db.Exec(`UPDATE keeper_registries SET paused = true WHERE id = ?`, registry.ID).Error)
+
+	// signals once the paused registry has been loaded with the eligible upkeeps
+	loaded := make(chan struct{}, 1)
+	require.NoError(t, db.Callback().Query().After("gorm:query").Register("keeper_test:registry_loaded", func(tx *gorm.DB) {
+		if tx.Statement.Table != "keeper_registries" {
+			return
+		}
+		select {
+		case loaded <- struct{}{}:
+		default:
+		}
+	}))
+
+	checked := atomic.NewBool(false)
+	registryMock := cltest.NewContractMockReceiver(t, ethMock, keeper.RegistryABI, registry.ContractAddress.Address())
+	registryMock.MockRevertResponse("checkUpkeep").Maybe().Run(func(mock.Arguments) { checked.Store(true) })
+
+	head := newHead()
+	executer.OnNewLongestChain(context.Background(), head)
+	select {
+	case <-loaded:
+	case <-time.After(5 * time.Second):
+		t.Fatal("eligible upkeeps weren't loaded")
+	}
+	require.NoError(t, db.Exec(`UPDATE keeper_registries SET paused = false WHERE id = ?`, registry.ID).Error)
+
+	// the head skipped for the paused registry is the last processed head, delivering it again is a duplicate
+	executer.OnNewLongestChain(context.Background(), head)
+	g := gomega.NewGomegaWithT(t)
+	g.Consistently(checked.Load).Should(gomega.BeFalse())
+}
+
+func Test_UpkeepExecuter_TimesOutSlowEligibilityQueries(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewGomegaWithT(t)
+
+	timeout := 100 * time.Millisecond
+	db, _, ethMock, executer, registry, _, job, _, _ := setup(t, func(c *configtest.TestGeneralConfig) {
+		c.Overrides.KeeperEligibilityQueryTimeout = &timeout
+	})
+
+	// the eligibility query hangs until its context is done while slow is set
+	slow := atomic.NewBool(true)
+	cancelled := make(chan error, 1)
+	require.NoError(t, db.Callback().Query().Before("gorm:query").Register("keeper_test:slow_eligibility_query", func(tx *gorm.DB) {
+		if tx.Statement.Table != "upkeep_registrations" || !slow.Load() {
+			return
+		}
+		<-tx.Statement.Context.Done()
+		select {
+		case cancelled <- tx.Statement.Context.Err():
+		default:
+		}
+	}))
+
+	start := time.Now()
+	executer.OnNewLongestChain(context.Background(), newHead())
+	select {
+	case err := <-cancelled:
+		require.Equal(t, context.DeadlineExceeded, err)
+		require.Less(t, int64(time.Since(start)), int64(postgres.DefaultQueryTimeout))
+	case <-time.After(5 * time.Second):
+		t.Fatal("eligibility query wasn't cancelled")
+	}
+	cltest.AssertPipelineRunsStays(t, job.PipelineSpecID, db, 0)
+
+	// the next head is processed once the query is fast again
+	slow.Store(false)
+	wasCalled := atomic.NewBool(false)
+	registryMock := cltest.NewContractMockReceiver(t, ethMock, keeper.RegistryABI, registry.ContractAddress.Address())
+	registryMock.MockRevertResponse("checkUpkeep").Run(func(args mock.Arguments) {
+		wasCalled.Store(true)
+	})
+	head := newHead()
+	head.Number = 21
+	executer.OnNewLongestChain(context.Background(), head)
+	g.Eventually(wasCalled).Should(gomega.Equal(atomic.NewBool(true)))
+	ethMock.AssertExpectations(t)
+}
+
+func Test_UpkeepExecuter_EvaluatesEligibilityBehindHead(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewGomegaWithT(t)
+
+	db, _, ethMock, executer, registry, upkeep, job, _, _ := setup(t, func(c *configtest.TestGeneralConfig) {
+		c.Overrides.KeeperEligibilityConfirmations = null.IntFrom(5)
+	})
+	// performed in the turn before head 20, which is still the current turn 5 blocks behind it
+	require.NoError(t, db.Exec(`UPDATE upkeep_registrations SET last_run_block_height = 18 WHERE id = ?`, upkeep.ID).Error)
+
+	executer.OnNewLongestChain(context.Background(), newHead())
+	cltest.AssertPipelineRunsStays(t, job.PipelineSpecID, db, 0)
+
+	// 5 blocks behind head 25 is the turn starting at block 20
+	wasCalled := atomic.NewBool(false)
+	registryMock := cltest.NewContractMockReceiver(t, ethMock, keeper.RegistryABI, registry.ContractAddress.Address())
+	registryMock.MockRevertResponse("checkUpkeep").Run(func(args mock.Arguments) {
+		wasCalled.Store(true)
+	})
+	head := newHead()
+	head.Number = 25
+	executer.OnNewLongestChain(context.Background(), head)
+	g.Eventually(wasCalled).Should(gomega.Equal(atomic.NewBool(true)))
+	ethMock.AssertExpectations(t)
+}
+
+func Test_UpkeepExecuter_RetriesFailedEligibilityQueries(t *testing.T) {
+	t.Parallel()
+
+	// failEligibilityQueries fails the eligibility queries for which fail returns true
+	failEligibilityQueries := func(t *testing.T, db *gorm.DB, fail func(vars []interface{}) bool) {
+		require.NoError(t, db.Callback().Query().After("gorm:query").Register("keeper_test:fail_eligibility_query", func(tx *gorm.DB) {
+			if tx.Statement.Table == "upkeep_registrations" && fail(tx.Statement.Vars) {
+				tx.AddError(errors.New("eligibility query failed"))
+			}
+		}))
+	}
+	// mockCheckUpkeep reverts every checkUpkeep call of the registry, counting them
+	mockCheckUpkeep := func(t *testing.T, ethMock *mocks.Client, registry keeper.Registry) *atomic.Int32 {
+		checks := atomic.NewInt32(0)
+		registryMock := cltest.NewContractMockReceiver(t, ethMock, keeper.RegistryABI, registry.ContractAddress.Address())
+		registryMock.MockRevertResponse("checkUpkeep").Run(func(args mock.Arguments) {
+			checks.Inc()
+		})
+		return checks
+	}
+
+	t.Run("retries failed queries", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		db, _, ethMock, executer, registry, _, _, _, _ := setup(t, func(c *configtest.TestGeneralConfig) {
+			c.Overrides.KeeperEligibilityQueryRetries = null.IntFrom(2)
+		})
+		failures := atomic.NewInt32(0)
+		failEligibilityQueries(t, db, func([]interface{}) bool { return failures.Inc() <= 2 })
+		checks := mockCheckUpkeep(t, ethMock, registry)
+
+		executer.OnNewLongestChain(context.Background(), newHead())
+		g.Eventually(checks.Load, 5*time.Second).Should(gomega.Equal(int32(1)))
+		require.Equal(t, int32(3), failures.Load())
+	})
+
+	t.Run("skips the head once the retries are exhausted", func(t *testing.T) {
+		db, _, ethMock, executer, registry, _, job, _, _ := setup(t, func(c *configtest.TestGeneralConfig) {
+			c.Overrides.KeeperEligibilityQueryRetries = null.IntFrom(1)
+		})
+		failEligibilityQueries(t, db, func([]interface{}) bool { return true })
+		checks := mockCheckUpkeep(t, ethMock, registry)
+
+		executer.OnNewLongestChain(context.Background(), newHead())
+		cltest.AssertPipelineRunsStays(t, job.PipelineSpecID, db, 0)
+		require.Zero(t, checks.Load())
+	})
+
+	t.Run("falls back to the upkeeps eligible on the previous head", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		db, _, ethMock, executer, registry, _, _, _, _ := setup(t, func(c *configtest.TestGeneralConfig) {
+			c.Overrides.KeeperEligibilityStaleFallback = null.BoolFrom(true)
+		})
+		// the queries at height 21 fail, the upkeep is eligible at height 20
+		failEligibilityQueries(t, db, func(vars []interface{}) bool {
+			for _, v := range vars {
+				if v == int64(21) {
+					return true
+				}
+			}
+			return false
+		})
+		checks := mockCheckUpkeep(t, ethMock, registry)
+
+		executer.OnNewLongestChain(context.Background(), newHead())
+		g.Eventually(checks.Load, 5*time.Second).Should(gomega.Equal(int32(1)))
+
+		head := newHead()
+		head.Number = 21
+		executer.OnNewLongestChain(context.Background(), head)
+		g.Eventually(checks.Load, 5*time.Second).Should(gomega.Equal(int32(2)))
+	})
+}
+
+// unavailableBroadcaster is a head broadcaster whose subscriptions fail
+type unavailableBroadcaster struct {
+	*headtracker.NullBroadcaster
+}
+
+func (unavailableBroadcaster) Subscribe(httypes.HeadTrackable) (*eth.Head, func()) {
+	return nil, nil
+}
+
+func Test_UpkeepExecuter_HeadSubscriptionFailure(t *testing.T) {
+	t.Parallel()
+
+	// newExecuter returns an unstarted executer of the registry of setup that can't subscribe to heads
+	newExecuter := func(t *testing.T, pollInterval time.Duration) (*keeper.UpkeepExecuter, *mocks.Client, keeper.Registry) {
+		db, config, ethMock, _, registry, _, job, jpv2, txm := setup(t, func(c *configtest.TestGeneralConfig) {
+			c.Overrides.KeeperHeadPollFallbackInterval = &pollInterval
+		})
+		orm := keeper.NewORM(db, txm, config, bulletprooftxmanager.SendEveryStrategy{})
+		executer := keeper.NewUpkeepExecuter(job, orm, jpv2.Pr, ethMock, unavailableBroadcaster{}, txm.GetGasEstimator(), nil, config.CreateProductionLogger(), config, keeper.NewQueryLimiter(), nil, nil)
+		t.Cleanup(func() { executer.Close() })
+		return executer, ethMock, registry
+	}
+
+	t.Run("fails to start without a poll fallback", func(t *testing.T) {
+		executer, _, _ := newExecuter(t, 0)
+		require.Error(t, executer.Start())
+	})
+
+	t.Run("polls for heads with a poll fallback", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		executer, ethMock, registry := newExecuter(t, 10*time.Millisecond)
+
+		head := newHead()
+		ethMock.On("HeadByNumber", mock.Anything, (*big.Int)(nil)).Return(&head, nil)
+		checks := atomic.NewInt32(0)
+		registryMock := cltest.NewContractMockReceiver(t, ethMock, keeper.RegistryABI, registry.ContractAddress.Address())
+		registryMock.MockRevertResponse("checkUpkeep").Run(func(args mock.Arguments) {
+			checks.Inc()
+		})
+
+		require.NoError(t, executer.Start())
+		g.Eventually(checks.Load).Should(gomega.Equal(int32(1)))
+		// polling the same head again doesn't deliver it twice
+		g.Consistently(checks.Load, 100*time.Millisecond).Should(gomega.Equal(int32(1)))
+	})
+}
+
+func Test_UpkeepExecuter_PerformsUpkeepsOfSeveralRegistries(t *testing.T) {
+	t.Parallel()
+
+	// the registries are processed together, then concurrently
+	for _, concurrency := range []int64{0, 2} {
+		concurrency := concurrency
+		t.Run(fmt.Sprintf("KeeperRegistryConcurrency=%d", concurrency), func(t *testing.T) {
+			t.Parallel()
+
+			config := cltest.NewTestGeneralConfig(t)
+			config.Overrides.KeeperMaximumGracePeriod = null.IntFrom(0)
+			config.Overrides.KeeperRegistryConcurrency = null.IntFrom(concurrency)
+			db := pgtest.NewGormDB(t)
+			config.SetDB(db)
+			keyStore := cltest.NewKeyStore(t, db)
+			ethClient := cltest.NewEthClientMockWithDefaultChain(t)
+			ethClient.On("PendingNonceAt", mock.Anything, mock.Anything).Maybe().Return(uint64(0), nil)
+			registry, j := cltest.MustInsertKeeperRegistry(t, db, keyStore.Eth())
+			// the second registry has an upkeep with the same ID, eligible on the same heads
+			otherRegistry := registry
+			otherRegistry.ID = 0
+			otherRegistry.ContractAddress = cltest.NewEIP55Address()
+			require.NoError(t, db.Create(&otherRegistry).Error)
+			j.KeeperSpec.ContractAddresses = job.KeeperRegistryAddresses{otherRegistry.ContractAddress}
+			upkeep := cltest.MustInsertUpkeepForRegistry(t, db, config, registry)
+			otherUpkeep := cltest.MustInsertUpkeepForRegistry(t, db, config, otherRegistry)
+			require.Equal(t, upkeep.UpkeepID, otherUpkeep.UpkeepID)
+
+			txm := new(bptxmmocks.TxManager)
+			estimator := new(gasmocks.Estimator)
+			txm.On("GetGasEstimator").Return(estimator)
+			estimator.On("EstimateGas", mock.Anything, mock.Anything).Return(assets.GWei(60), uint64(0), nil)
+			cfg := cltest.NewTestGeneralConfig(t)
+			cc := evmtest.NewChainSet(t, evmtest.TestChainOpts{TxManager: txm, DB: db, Client: ethClient, KeyStore: keyStore.Eth(), GeneralConfig: cfg})
+			jpv2 := cltest.NewJobPipelineV2(t, cfg, cc, db, keyStore)
+			require.NoError(t, jpv2.Pr.Start())
+			t.Cleanup(func() { jpv2.Pr.Close() })
+			ch := evmtest.MustGetDefaultChain(t, cc)
+			orm := keeper.NewORM(db, txm, config, bulletprooftxmanager.SendEveryStrategy{})
+			executer := keeper.NewUpkeepExecuter(j, orm, jpv2.Pr, ethClient, ch.HeadBroadcaster(), ch.TxManager().GetGasEstimator(), nil, config.CreateProductionLogger(), config, keeper.NewQueryLimiter(), nil, nil)
+			require.NoError(t, executer.Start())
+			t.Cleanup(func() { executer.Close() })
+
+			// each perform goes to the registry of its upkeep
+			var performs []cltest.Awaiter
+			for _, r := range []keeper.Registry{registry, otherRegistry} {
+				to := r.ContractAddress.Address()
+				performed := cltest.NewAwaiter()
+				performs = append(performs, performed)
+				cltest.NewContractMockReceiver(t, ethClient, keeper.RegistryABI, to).MockResponse("checkUpkeep", checkUpkeepResponse)
+				txm.On("CreateEthTransaction",
+					mock.Anything, mock.MatchedBy(func(newTx bulletprooftxmanager.NewTx) bool { return newTx.ToAddress == to }),
+				).
+					Once().
+					Return(bulletprooftxmanager.EthTx{}, nil).
+					Run(func(mock.Arguments) { performed.ItHappened() })
+			}
+
+			executer.OnNewLongestChain(context.Background(), newHead())
+			for _, performed := range performs {
+				performed.AwaitOrFail(t)
+			}
+			runs := cltest.WaitForPipelineComplete(t, 0, j.ID, 2, 5, jpv2.Jrm, time.Second, 100*time.Millisecond)
+			require.Len(t, runs, 2)
+			assertLastRunHeight(t, db, upkeep, 20)
+			assertLastRunHeight(t, db, otherUpkeep, 20)
+
+			ethClient.AssertExpectations(t)
+			txm.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_UpkeepExecuter_CallsPerformCallback(t *testing.T) {
+	t.Parallel()
+
+	config := cltest.NewTestGeneralConfig(t)
+	config.Overrides.KeeperMaximumGracePeriod = null.IntFrom(0)
+	db := pgtest.NewGormDB(t)
+	config.SetDB(db)
+	keyStore := cltest.NewKeyStore(t, db)
+	ethClient := cltest.NewEthClientMockWithDefaultChain(t)
+	ethClient.On("PendingNonceAt", mock.Anything, mock.Anything).Maybe().Return(uint64(0), nil)
+	registry, j := cltest.MustInsertKeeperRegistry(t, db, keyStore.Eth())
+	upkeep := cltest.MustInsertUpkeepForRegistry(t, db, config, registry)
+	cltest.NewContractMockReceiver(t, ethClient, keeper.RegistryABI, registry.ContractAddress.Address()).MockResponse("checkUpkeep", checkUpkeepResponse)
+
+	txm := new(bptxmmocks.TxManager)
+	estimator := new(gasmocks.Estimator)
+	txm.On("GetGasEstimator").Return(estimator)
+	estimator.On("EstimateGas", mock.Anything, mock.Anything).Return(assets.GWei(60), uint64(0), nil)
+	txm.On("CreateEthTransaction", mock.Anything, mock.Anything).Twice().Return(bulletprooftxmanager.EthTx{}, nil)
+	cfg := cltest.NewTestGeneralConfig(t)
+	cc := evmtest.NewChainSet(t, evmtest.TestChainOpts{TxManager: txm, DB: db, Client: ethClient, KeyStore: keyStore.Eth(), GeneralConfig: cfg})
+	jpv2 := cltest.NewJobPipelineV2(t, cfg, cc, db, keyStore)
+	require.NoError(t, jpv2.Pr.Start())
+	t.Cleanup(func() { jpv2.Pr.Close() })
+	ch := evmtest.MustGetDefaultChain(t, cc)
+	orm := keeper.NewORM(db, txm, config, bulletprooftxmanager.SendEveryStrategy{})
+	performed := make(chan keeper.PerformedUpkeep, 10)
+	onPerform := func(p keeper.PerformedUpkeep) { performed <- p }
+	executer := keeper.NewUpkeepExecuter(j, orm, jpv2.Pr, ethClient, ch.HeadBroadcaster(), ch.TxManager().GetGasEstimator(), nil, config.CreateProductionLogger(), config, keeper.NewQueryLimiter(), nil, onPerform)
+	require.NoError(t, executer.Start())
+	t.Cleanup(func() { executer.Close() })
+
+	gasPrice := bigmath.Div(bigmath.Mul(assets.GWei(60), 100+config.KeeperGasPriceBufferPercent()), 100)
+	awaitPerformed := func(t *testing.T, blockNumber int64) keeper.PerformedUpkeep {
+		select {
+		case p := <-performed:
+			require.Equal(t, registry.ContractAddress.Address(), p.RegistryAddress)
+			require.Equal(t, upkeep.UpkeepID, p.UpkeepID)
+			require.Equal(t, blockNumber, p.BlockNumber)
+			require.Equal(t, gasPrice.String(), p.GasPrice.String())
+			return p
+		case <-time.After(5 * time.Second):
+			t.Fatalf("perform callback wasn't called for block %d", blockNumber)
+			return keeper.PerformedUpkeep{}
+		}
+	}
+
+	executer.OnNewLongestChain(context.Background(), newHead())
+	first := awaitPerformed(t, 20)
+	// head 40 starts the next turn of the upkeep
+	executer.OnNewLongestChain(context.Background(), *cltest.Head(40))
+	second := awaitPerformed(t, 40)
+
+	runs := cltest.WaitForPipelineComplete(t, 0, j.ID, 2, 5, jpv2.Jrm, time.Second, 100*time.Millisecond)
+	require.Len(t, runs, 2)
+	require.ElementsMatch(t, []int64{runs[0].ID, runs[1].ID}, []int64{first.PipelineRunID, second.PipelineRunID})
+	require.NoError(t, executer.Close())
+	require.Len(t, performed, 0)
+	txm.AssertExpectations(t)
+}
+
+func Test_UpkeepExecuter_DryRun(t *testing.T) {
+	t.Parallel()
+
+	db, _, ethMock, executer, registry, upkeep, job, _, txm := setup(t, func(c *configtest.TestGeneralConfig) {
+		c.Overrides.KeeperDryRun = null.BoolFrom(true)
+	})
+
+	checked := cltest.NewAwaiter()
+	registryMock := cltest.NewContractMockReceiver(t, ethMock, keeper.RegistryABI, registry.ContractAddress.Address())
+	registryMock.MockResponse("checkUpkeep", checkUpkeepResponse).Run(func(mock.Arguments) { checked.ItHappened() })
+
+	executer.OnNewLongestChain(context.Background(), newHead())
+	checked.AwaitOrFail(t)
+
+	// the pipeline isn't run, so no perform transaction is created and the upkeep isn't marked as run
+	cltest.AssertPipelineRunsStays(t, job.PipelineSpecID, db, 0)
+	assertLastRunHeight(t, db, upkeep, 0)
+	txm.AssertNotCalled(t, "CreateEthTransaction", mock.Anything, mock.Anything)
+}
+
+func Test_UpkeepExecuter_RecoversPipelinePanics(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewGomegaWithT(t)
+
+	config := cltest.NewTestGeneralConfig(t)
+	config.Overrides.KeeperMaximumGracePeriod = null.IntFrom(0)
+	db := pgtest.NewGormDB(t)
+	config.SetDB(db)
+	keyStore := cltest.NewKeyStore(t, db)
+	ethClient := cltest.NewEthClientMockWithDefaultChain(t)
+	ethClient.On("PendingNonceAt", mock.Anything, mock.Anything).Maybe().Return(uint64(0), nil)
+	registry, j := cltest.MustInsertKeeperRegistry(t, db, keyStore.Eth())
+	upkeep := cltest.MustInsertUpkeepForRegistry(t, db, config, registry)
+	cltest.NewContractMockReceiver(t, ethClient, keeper.RegistryABI, registry.ContractAddress.Address()).MockResponse("checkUpkeep", checkUpkeepResponse)
+
+	txm := new(bptxmmocks.TxManager)
+	estimator := new(gasmocks.Estimator)
+	txm.On("GetGasEstimator").Return(estimator)
+	estimator.On("EstimateGas", mock.Anything, mock.Anything).Return(assets.GWei(60), uint64(0), nil)
+	cc := evmtest.NewChainSet(t, evmtest.TestChainOpts{TxManager: txm, DB: db, Client: ethClient, KeyStore: keyStore.Eth(), GeneralConfig: cltest.NewTestGeneralConfig(t)})
+	ch := evmtest.MustGetDefaultChain(t, cc)
+
+	runs := atomic.NewInt32(0)
+	runner := new(pipelinemocks.Runner)
+	runner.On("Ready").Return(nil)
+	runner.On("Run", mock.Anything, mock.Anything, mock.Anything, true, mock.Anything).
+		Run(func(mock.Arguments) {
+			runs.Inc()
+			panic("task exploded")
+		}).
+		Return(false, nil)
+	orm := keeper.NewORM(db, txm, config, bulletprooftxmanager.SendEveryStrategy{})
+	executer := keeper.NewUpkeepExecuter(j, orm, runner, ethClient, ch.HeadBroadcaster(), estimator, nil, config.CreateProductionLogger(), config, keeper.NewQueryLimiter(), nil, nil)
+	require.NoError(t, executer.Start())
+	t.Cleanup(func() { executer.Close() })
+
+	executer.OnNewLongestChain(context.Background(), newHead())
+
+	// the panic is recorded as a failed run of the upkeep
+	g.Eventually(func() int32 {
+		require.NoError(t, db.Find(&upkeep).Error)
+		return upkeep.FailureCount
+	}).Should(gomega.Equal(int32(1)))
+	require.Equal(t, int32(1), runs.Load())
+	require.Equal(t, int64(20), upkeep.LastErrorBlockHeight.Int64)
+	require.Contains(t, upkeep.LastError.String, "task exploded")
+	// the executer kept running and releases its executions on shutdown
+	require.NoError(t, executer.Healthy())
+	require.NoError(t, executer.Close())
+	txm.AssertNotCalled(t, "CreateEthTransaction", mock.Anything, mock.Anything)
+}
+
+// logTriggeredUpkeep is a started executer of a job with a log-triggered upkeep, built by setupLogTriggeredUpkeep
+type logTriggeredUpkeep struct {
+	db        *gorm.DB
+	ethClient *mocks.Client
+	sub       *mocks.Subscription
+	executer  *keeper.UpkeepExecuter
+	upkeep    keeper.UpkeepRegistration
+	emitter   ethkey.EIP55Address
+	topic     common.Hash
+	// logs is the log stream of the trigger subscription
+	logs    chan<- types.Log
+	checked cltest.Awaiter
+	runs    chan *pipeline.Run
+}
+
+// setupLogTriggeredUpkeep starts an executer with a log-triggered upkeep that last ran on lastRunBlockHeight
+func setupLogTriggeredUpkeep(t *testing.T, lastRunBlockHeight int64, overrides ...func(*configtest.TestGeneralConfig)) logTriggeredUpkeep {
+	config := cltest.NewTestGeneralConfig(t)
+	config.Overrides.KeeperMaximumGracePeriod = null.IntFrom(0)
+	for _, override := range overrides {
+		override(config)
+	}
+	db := pgtest.NewGormDB(t)
+	config.SetDB(db)
+	keyStore := cltest.NewKeyStore(t, db)
+	ethClient := cltest.NewEthClientMockWithDefaultChain(t)
+	ethClient.On("PendingNonceAt", mock.Anything, mock.Anything).Maybe().Return(uint64(0), nil)
+	registry, j := cltest.MustInsertKeeperRegistry(t, db, keyStore.Eth())
+	upkeep := cltest.MustInsertUpkeepForRegistry(t, db, config, registry)
+	if lastRunBlockHeight > 0 {
+		require.NoError(t, db.Exec(`UPDATE upkeep_registrations SET last_run_block_height = ? WHERE id = ?`, lastRunBlockHeight, upkeep.ID).Error)
+	}
+	emitter := cltest.NewEIP55Address()
+	topic := utils.NewHash()
+	j.KeeperSpec.UpkeepOverrides = job.KeeperUpkeepOverrides{
+		strconv.FormatInt(upkeep.UpkeepID, 10): {LogTrigger: &job.KeeperLogTrigger{Address: emitter, Topics: []string{topic.Hex()}}},
+	}
+
+	// the fake log stream of the subscription
+	chLogs := make(chan chan<- types.Log, 1)
+	sub := new(mocks.Subscription)
+	sub.On("Err").Return((<-chan error)(nil))
+	sub.On("Unsubscribe").Return()
+	ethClient.On("SubscribeFilterLogs", mock.Anything, mock.MatchedBy(func(q ethereum.FilterQuery) bool {
+		return len(q.Addresses) == 1 && q.Addresses[0] == emitter.Address() && q.Topics[0][0] == topic
+	}), mock.Anything).
+		Once().
+		Run(func(args mock.Arguments) { chLogs <- args.Get(2).(chan<- types.Log) }).
+		Return(sub, nil)
+	checked := cltest.NewAwaiter()
+	cltest.NewContractMockReceiver(t, ethClient, keeper.RegistryABI, registry.ContractAddress.Address()).
+		MockResponse("checkUpkeep", checkUpkeepResponse).
+		Once().
+		Run(func(mock.Arguments) { checked.ItHappened() })
+
+	txm := new(bptxmmocks.TxManager)
+	estimator := new(gasmocks.Estimator)
+	txm.On("GetGasEstimator").Return(estimator)
+	estimator.On("EstimateGas", mock.Anything, mock.Anything).Return(assets.GWei(60), uint64(0), nil)
+	cc := evmtest.NewChainSet(t, evmtest.TestChainOpts{TxManager: txm, DB: db, Client: ethClient, KeyStore: keyStore.Eth(), GeneralConfig: cltest.NewTestGeneralConfig(t)})
+	ch := evmtest.MustGetDefaultChain(t, cc)
+
+	runs := make(chan *pipeline.Run, 1)
+	runner := new(pipelinemocks.Runner)
+	runner.On("Run", mock.Anything, mock.Anything, mock.Anything, true, mock.Anything).
+		Run(func(args mock.Arguments) {
+			run := args.Get(1).(*pipeline.Run)
+			run.State = pipeline.RunStatusCompleted
+			runs <- run
+		}).
+		Return(false, nil)
+	orm := keeper.NewORM(db, txm, config, bulletprooftxmanager.SendEveryStrategy{})
+	executer := keeper.NewUpkeepExecuter(j, orm, runner, ethClient, ch.HeadBroadcaster(), estimator, nil, config.CreateProductionLogger(), config, keeper.NewQueryLimiter(), nil, nil)
+	require.NoError(t, executer.Start())
+	t.Cleanup(func() { executer.Close() })
+
+	var logs chan<- types.Log
+	select {
+	case logs = <-chLogs:
+	case <-time.After(5 * time.Second):
+		t.Fatal("executer didn't subscribe to the trigger logs")
+	}
+	return logTriggeredUpkeep{
+		db:        db,
+		ethClient: ethClient,
+		sub:       sub,
+		executer:  executer,
+		upkeep:    upkeep,
+		emitter:   emitter,
+		topic:     topic,
+		logs:      logs,
+		checked:   checked,
+		runs:      runs,
+	}
+}
+
+func Test_UpkeepExecuter_ExecutesLogTriggeredUpkeeps(t *testing.T) {
+	t.Parallel()
+
+	u := setupLogTriggeredUpkeep(t, 0)
+	payload := []byte("trigger payload")
+	u.logs <- types.Log{Address: u.emitter.Address(), Topics: []common.Hash{u.topic}, Data: payload, BlockNumber: 20, BlockHash: utils.NewHash()}
+
+	u.checked.AwaitOrFail(t)
+	select {
+	case run := <-u.runs:
+		vars := run.Inputs.Val.(map[string]interface{})
+		require.Equal(t, payload, vars["triggerData"])
+		jobSpec := vars["jobSpec"].(map[string]interface{})
+		require.Equal(t, u.upkeep.UpkeepID, jobSpec["upkeepID"])
+		// the pipeline can perform the checked performData
+		require.Equal(t, checkUpkeepResponse.PerformData, jobSpec["performData"])
+		require.Equal(t, checkUpkeepResponse.GasLimit.Uint64(), jobSpec["upkeepGasLimit"])
+	case <-time.After(5 * time.Second):
+		t.Fatal("trigger log didn't start a pipeline run")
+	}
+	assertLastRunHeight(t, u.db, u.upkeep, 20)
+
+	require.NoError(t, u.executer.Close())
+	u.sub.AssertCalled(t, "Unsubscribe")
+	u.ethClient.AssertExpectations(t)
+}
+
+func Test_UpkeepExecuter_IgnoresLogTriggersWithinGracePeriod(t *testing.T) {
+	t.Parallel()
+
+	// the upkeep ran 5 blocks before the trigger log, within the grace period of 10 blocks
+	u := setupLogTriggeredUpkeep(t, 15, func(c *configtest.TestGeneralConfig) {
+		c.Overrides.KeeperMaximumGracePeriod = null.IntFrom(10)
+	})
+	u.logs <- types.Log{Address: u.emitter.Address(), Topics: []common.Hash{u.topic}, BlockNumber: 20, BlockHash: utils.NewHash()}
+
+	select {
+	case <-u.checked:
+		t.Fatal("upkeep within its grace period was checked")
+	case <-u.runs:
+		t.Fatal("upkeep within its grace period was executed")
+	case <-time.After(time.Second):
+	}
+	assertLastRunHeight(t, u.db, u.upkeep, 15)
+	require.NoError(t, u.executer.Close())
+}
+
+func Test_UpkeepExecuter_SkipsDuplicateHeads(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewGomegaWithT(t)
+
+	_, _, ethMock, executer, registry, _, _, _, _ := setup(t)
+
+	callCount := atomic.NewInt32(0)
+	registryMock := cltest.NewContractMockReceiver(t, ethMock, keeper.RegistryABI, registry.ContractAddress.Address())
+	// revert so that the upkeep stays eligible on the head
+	registryMock.MockRevertResponse("checkUpkeep").Run(func(mock.Arguments) {
+		callCount.Inc()
+	})
+
+	head := newHead()
+	executer.OnNewLongestChain(context.Background(), head)
+	g.Eventually(callCount.Load).Should(gomega.Equal(int32(1)))
+
+	executer.OnNewLongestChain(context.Background(), head)
+	g.Consistently(callCount.Load).Should(gomega.Equal(int32(1)))
+
+	// a head with the same number but a different hash is processed
+	reorged := head
+	reorged.Hash = utils.NewHash()
+	executer.OnNewLongestChain(context.Background(), reorged)
+	g.Eventually(callCount.Load).Should(gomega.Equal(int32(2)))
+}
+
+func Test_UpkeepExecuter_IgnoresOutOfOrderHeads(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewGomegaWithT(t)
+
+	_, _, ethMock, executer, registry, _, _, _, _ := setup(t)
+
+	callCount := atomic.NewInt32(0)
+	registryMock := cltest.NewContractMockReceiver(t, ethMock, keeper.RegistryABI, registry.ContractAddress.Address())
+	// revert so that the upkeep stays eligible on the heads
+	registryMock.MockRevertResponse("checkUpkeep").Run(func(mock.Arguments) {
+		callCount.Inc()
+	})
+
+	head := *cltest.Head(21)
+	executer.OnNewLongestChain(context.Background(), head)
+	g.Eventually(callCount.Load).Should(gomega.Equal(int32(1)))
+
+	// a late head below the last processed one is ignored
+	executer.OnNewLongestChain(context.Background(), *cltest.Head(20))
+	g.Consistently(callCount.Load).Should(gomega.Equal(int32(1)))
+
+	// a reorg at the same height is processed
+	reorged := head
+	reorged.Hash = utils.NewHash()
+	executer.OnNewLongestChain(context.Background(), reorged)
+	g.Eventually(callCount.Load).Should(gomega.Equal(int32(2)))
+
+	executer.OnNewLongestChain(context.Background(), *cltest.Head(22))
+	g.Eventually(callCount.Load).Should(gomega.Equal(int32(3)))
+}
+
+func Test_UpkeepExecuter_MailboxCapacity(t *testing.T) {
+	t.Parallel()
+
+	// deliverBurst delivers heads 20 thru 22 before the executer starts, returning
+	// the count of heads the upkeep is checked on
+	deliverBurst := func(t *testing.T, capacity int64) *atomic.Int32 {
+		config := cltest.NewTestGeneralConfig(t)
+		config.Overrides.KeeperMaximumGracePeriod = null.IntFrom(0)
+		config.Overrides.KeeperMailboxCapacity = null.IntFrom(capacity)
+		db := pgtest.NewGormDB(t)
+		config.SetDB(db)
+		keyStore := cltest.NewKeyStore(t, db)
+		ethClient := cltest.NewEthClientMockWithDefaultChain(t)
+		ethClient.On("PendingNonceAt", mock.Anything, mock.Anything).Maybe().Return(uint64(0), nil)
+		registry, j := cltest.MustInsertKeeperRegistry(t, db, keyStore.Eth())
+		cltest.MustInsertUpkeepForRegistry(t, db, config, registry)
+		callCount := atomic.NewInt32(0)
+		// revert so that the upkeep stays eligible on every head
+		cltest.NewContractMockReceiver(t, ethClient, keeper.RegistryABI, registry.ContractAddress.Address()).
+			MockRevertResponse("checkUpkeep").
+			Run(func(mock.Arguments) { callCount.Inc() })
+
+		txm := new(bptxmmocks.TxManager)
+		estimator := new(gasmocks.Estimator)
+		estimator.On("EstimateGas", mock.Anything, mock.Anything).Maybe().Return(assets.GWei(60), uint64(0), nil)
+		txm.On("GetGasEstimator").Return(estimator)
+		cfg := cltest.NewTestGeneralConfig(t)
+		cc := evmtest.NewChainSet(t, evmtest.TestChainOpts{TxManager: txm, DB: db, Client: ethClient, KeyStore: keyStore.Eth(), GeneralConfig: cfg})
+		jpv2 := cltest.NewJobPipelineV2(t, cfg, cc, db, keyStore)
+		require.NoError(t, jpv2.Pr.Start())
+		t.Cleanup(func() { jpv2.Pr.Close() })
+		ch := evmtest.MustGetDefaultChain(t, cc)
+		orm := keeper.NewORM(db, txm, config, bulletprooftxmanager.SendEveryStrategy{})
+		executer := keeper.NewUpkeepExecuter(j, orm, jpv2.Pr, ethClient, ch.HeadBroadcaster(), estimator, nil, config.CreateProductionLogger(), config, keeper.NewQueryLimiter(), nil, nil)
+
+		for i := 20; i <= 22; i++ {
+			executer.OnNewLongestChain(context.Background(), *cltest.Head(i))
+		}
+		require.NoError(t, executer.Start())
+		t.Cleanup(func() { executer.Close() })
+		return callCount
+	}
+
+	t.Run("capacity 1 drops all but the latest head", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		callCount := deliverBurst(t, 1)
+		g.Eventually(callCount.Load).Should(gomega.Equal(int32(1)))
+		g.Consistently(callCount.Load).Should(gomega.Equal(int32(1)))
+	})
+
+	t.Run("capacity 3 processes the buffered heads in order", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		callCount := deliverBurst(t, 3)
+		// heads processed out of order would be ignored
+		g.Eventually(callCount.Load).Should(gomega.Equal(int32(3)))
+		g.Consistently(callCount.Load).Should(gomega.Equal(int32(3)))
+	})
+}
+
+func Test_UpkeepExecuter_StopsEnqueuingOnShutdown(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewGomegaWithT(t)
+
+	db, config, ethMock, executer, registry, _, _, _, txm := setup(t, cancelOnShutdown)
+	// one more upkeep than fits in the execution queue of 10
+	for i := 0; i < 10; i++ {
+		cltest.MustInsertUpkeepForRegistry(t, db, config, registry)
+	}
+
+	callCount := atomic.NewInt32(0)
+	registryMock := cltest.NewContractMockReceiver(t, ethMock, keeper.RegistryABI, registry.ContractAddress.Address())
+	// revert so that the cancelled executions don't go on to create perform transactions
+	registryMock.MockRevertResponse("checkUpkeep").Run(func(args mock.Arguments) {
+		callCount.Inc()
+		// hold the execution slot until Close cancels the execution
+		<-args.Get(0).(context.Context).Done()
+	})
+
+	executer.OnNewLongestChain(context.Background(), newHead())
+	g.Eventually(callCount.Load).Should(gomega.Equal(int32(10)))
+
+	require.NoError(t, executer.Close())
+
+	estimator := txm.GetGasEstimator().(*gasmocks.Estimator)
+	estimateCalls := 0
+	for _, call := range estimator.Calls {
+		if call.Method == "EstimateGas" {
+			estimateCalls++
+		}
+	}
+	assert.Equal(t, 10, estimateCalls)
+	assert.Equal(t, int32(10), callCount.Load())
+}
+
+func Test_UpkeepExecuter_CloseDrainsInFlightExecutions(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewGomegaWithT(t)
+
+	_, _, ethMock, executer, registry, _, _, _, _ := setup(t, cancelOnShutdown)
+
+	started := atomic.NewBool(false)
+	returned := atomic.NewBool(false)
+	registryMock := cltest.NewContractMockReceiver(t, ethMock, keeper.RegistryABI, registry.ContractAddress.Address())
+	registryMock.MockRevertResponse("checkUpkeep").Run(func(args mock.Arguments) {
+		started.Store(true)
+		// a slow execution, only ending once Close cancels it
+		<-args.Get(0).(context.Context).Done()
+		time.Sleep(100 * time.Millisecond)
+		returned.Store(true)
+	})
+
+	executer.OnNewLongestChain(context.Background(), newHead())
+	g.Eventually(started.Load).Should(gomega.BeTrue())
+
+	chClosed := make(chan error)
+	go func() { chClosed <- executer.Close() }()
+	select {
+	case err := <-chClosed:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close didn't return after cancelling the in-flight execution")
+	}
+	// Close only returns once the execution goroutine finished
+	assert.True(t, returned.Load())
+}
+
+func Test_UpkeepExecuter_DispatchesOverdueUpkeepsFirst(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewGomegaWithT(t)
+
+	// a single execution slot executes the upkeeps one by one, in dispatch order
+	db, config, ethMock, executer, registry, upkeep, _, _, _ := setup(t, func(config *configtest.TestGeneralConfig) {
+		config.Overrides.KeeperMaximumConcurrentExecutions = null.IntFrom(1)
+	})
+	upkeeps := []keeper.UpkeepRegistration{upkeep}
+	for i := 0; i < 3; i++ {
+		upkeeps = append(upkeeps, cltest.MustInsertUpkeepForRegistry(t, db, config, registry))
+	}
+	for i, lastRunBlockHeight := range []int64{15, 5, 0, 10} {
+		require.NoError(t, db.Model(&upkeeps[i]).Update("last_run_block_height", lastRunBlockHeight).Error)
+	}
+
+	var mu sync.Mutex
+	var dispatched []int64
+	registryMock := cltest.NewContractMockReceiver(t, ethMock, keeper.RegistryABI, registry.ContractAddress.Address())
+	registryMock.MockRevertResponse("checkUpkeep").Run(func(args mock.Arguments) {
+		// checkUpkeep(uint256 id, address from) starts with the selector followed by the upkeep ID
+		data := args.Get(1).(ethereum.CallMsg).Data
+		mu.Lock()
+		defer mu.Unlock()
+		dispatched = append(dispatched, new(big.Int).SetBytes(data[4:36]).Int64())
+	})
+
+	executer.OnNewLongestChain(context.Background(), newHead())
+
+	g.Eventually(func() []int64 {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]int64(nil), dispatched...)
+	}, 10*time.Second).Should(gomega.Equal([]int64{
+		upkeeps[2].UpkeepID, // never run
+		upkeeps[1].UpkeepID, // last run at 5
+		upkeeps[3].UpkeepID, // last run at 10
+		upkeeps[0].UpkeepID, // last run at 15
+	}))
+}
+
+func Test_UpkeepExecuter_BoundsConcurrentExecutions(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewGomegaWithT(t)
+
+	const maxConcurrentExecutions = 5
+	db, config, ethMock, executer, registry, _, _, _, _ := setup(t, func(config *configtest.TestGeneralConfig) {
+		config.Overrides.KeeperMaximumConcurrentExecutions = null.IntFrom(maxConcurrentExecutions)
+	})
+	for i := 0; i < 49; i++ {
+		cltest.MustInsertUpkeepForRegistry(t, db, config, registry)
+	}
+
+	callCount := atomic.NewInt32(0)
+	inFlight := atomic.NewInt32(0)
+	maxInFlight := atomic.NewInt32(0)
+	registryMock := cltest.NewContractMockReceiver(t, ethMock, keeper.RegistryABI, registry.ContractAddress.Address())
+	registryMock.MockRevertResponse("checkUpkeep").Run(func(args mock.Arguments) {
+		callCount.Inc()
+		n := inFlight.Inc()
+		for {
+			max := maxInFlight.Load()
+			if n <= max || maxInFlight.CAS(max, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		inFlight.Dec()
+	})
+
+	executer.OnNewLongestChain(context.Background(), newHead())
+
+	g.Eventually(callCount.Load, 10*time.Second).Should(gomega.Equal(int32(50)))
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(maxConcurrentExecutions))
+	assert.Greater(t, maxInFlight.Load(), int32(1))
+}
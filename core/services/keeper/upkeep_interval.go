@@ -0,0 +1,106 @@
+package keeper
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+// intervalABI is the interval() getter of time-based upkeep contracts, returning their interval in seconds
+var intervalABI = eth.MustGetABI(`[{"inputs":[],"name":"interval","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"}]`)
+
+// inIntervalCooldown returns true if the upkeep is time-based and was performed by this node
+// less than its on-chain interval ago
+func (ex *UpkeepExecuter) inIntervalCooldown(ctx context.Context, upkeep UpkeepRegistration) (bool, error) {
+	ex.cooldownMu.Lock()
+	lastPerformed, performed := ex.lastPerformed.get(upkeep.key())
+	ex.cooldownMu.Unlock()
+	if !performed {
+		return false, nil
+	}
+	interval, err := ex.upkeepInterval(ctx, upkeep)
+	if err != nil {
+		return false, err
+	}
+	return interval > 0 && time.Since(lastPerformed.(time.Time)) < interval, nil
+}
+
+func (ex *UpkeepExecuter) setLastPerformed(upkeep UpkeepRegistration, at time.Time) {
+	ex.cooldownMu.Lock()
+	defer ex.cooldownMu.Unlock()
+	ex.lastPerformed.set(upkeep.key(), at)
+}
+
+func (ex *UpkeepExecuter) cooldownCount() int {
+	ex.cooldownMu.Lock()
+	defer ex.cooldownMu.Unlock()
+	return ex.lastPerformed.len()
+}
+
+// upkeepInterval returns the interval of the upkeep's target contract, caching it per upkeep.
+// Targets without an interval() function are not time-based and have an interval of 0.
+func (ex *UpkeepExecuter) upkeepInterval(ctx context.Context, upkeep UpkeepRegistration) (time.Duration, error) {
+	ex.cooldownMu.Lock()
+	cachedInterval, cached := ex.intervals.get(upkeep.key())
+	ex.cooldownMu.Unlock()
+	if cached {
+		return cachedInterval.(time.Duration), nil
+	}
+
+	target, err := ex.upkeepTarget(ctx, upkeep)
+	if err != nil {
+		return 0, err
+	}
+	intervalData, err := intervalABI.Pack("interval")
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to construct interval data")
+	}
+	out, err := ex.ethClient.CallContract(ctx, ethereum.CallMsg{To: &target, Data: intervalData}, nil)
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+	var interval time.Duration
+	if err == nil {
+		if values, unpackErr := intervalABI.Unpack("interval", out); unpackErr == nil {
+			if seconds, ok := values[0].(*big.Int); ok && seconds.IsInt64() {
+				interval = time.Duration(seconds.Int64()) * time.Second
+			}
+		}
+	}
+
+	ex.cooldownMu.Lock()
+	defer ex.cooldownMu.Unlock()
+	ex.intervals.set(upkeep.key(), interval)
+	return interval, nil
+}
+
+func (ex *UpkeepExecuter) upkeepTarget(ctx context.Context, upkeep UpkeepRegistration) (common.Address, error) {
+	version, err := ex.registryVersion(upkeep)
+	if err != nil {
+		return common.Address{}, err
+	}
+	getUpkeepData, err := version.packGetUpkeep(upkeep.UpkeepID)
+	if err != nil {
+		return common.Address{}, err
+	}
+	to := upkeep.Registry.ContractAddress.Address()
+	out, err := ex.ethClient.CallContract(ctx, ethereum.CallMsg{To: &to, Data: getUpkeepData}, nil)
+	if err != nil {
+		return common.Address{}, errors.Wrap(err, "getUpkeep call failed")
+	}
+	values, err := version.unpackGetUpkeep(out)
+	if err != nil {
+		return common.Address{}, err
+	}
+	target, ok := values[0].(common.Address)
+	if !ok {
+		return common.Address{}, errors.Errorf("expected target to be common.Address, got %T", values[0])
+	}
+	return target, nil
+}
@@ -0,0 +1,82 @@
+package keeper
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+// intervalClient answers the getUpkeep call of the registry with target, and the interval call of
+// target with interval seconds, reverting it if interval is nil
+type intervalClient struct {
+	*eth.NullClient
+	target   common.Address
+	interval *big.Int
+	calls    atomic.Int32
+}
+
+func (c *intervalClient) CallContract(_ context.Context, msg ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	c.calls.Inc()
+	if *msg.To != c.target {
+		return registryVersions[DefaultRegistryVersion].abi.Methods["getUpkeep"].Outputs.Pack(
+			c.target, uint32(2_000_000), []byte{}, big.NewInt(1), common.Address{}, common.Address{}, uint64(1_000_000_000),
+		)
+	}
+	if c.interval == nil {
+		return nil, errors.New("execution reverted")
+	}
+	return intervalABI.Methods["interval"].Outputs.Pack(c.interval)
+}
+
+func TestUpkeepExecuter_InIntervalCooldown(t *testing.T) {
+	t.Parallel()
+
+	upkeep := UpkeepRegistration{UpkeepID: 1}
+	newExecuter := func(interval *big.Int) (*UpkeepExecuter, *intervalClient) {
+		client := &intervalClient{NullClient: &eth.NullClient{CID: big.NewInt(1)}, target: common.HexToAddress("0x1234"), interval: interval}
+		return newTestExecuter(t, testExecuterDeps{ethClient: client}), client
+	}
+
+	t.Run("upkeeps this node hasn't performed", func(t *testing.T) {
+		ex, client := newExecuter(big.NewInt(60))
+		inCooldown, err := ex.inIntervalCooldown(context.Background(), upkeep)
+		require.NoError(t, err)
+		require.False(t, inCooldown)
+		require.Zero(t, client.calls.Load())
+	})
+
+	t.Run("time-based upkeeps", func(t *testing.T) {
+		ex, client := newExecuter(big.NewInt(60))
+
+		ex.setLastPerformed(upkeep, time.Now().Add(-10*time.Second))
+		inCooldown, err := ex.inIntervalCooldown(context.Background(), upkeep)
+		require.NoError(t, err)
+		require.True(t, inCooldown)
+		require.Equal(t, int32(2), client.calls.Load())
+
+		// the interval is cached
+		ex.setLastPerformed(upkeep, time.Now().Add(-2*time.Minute))
+		inCooldown, err = ex.inIntervalCooldown(context.Background(), upkeep)
+		require.NoError(t, err)
+		require.False(t, inCooldown)
+		require.Equal(t, int32(2), client.calls.Load())
+	})
+
+	t.Run("targets without an interval", func(t *testing.T) {
+		ex, _ := newExecuter(nil)
+
+		ex.setLastPerformed(upkeep, time.Now())
+		inCooldown, err := ex.inIntervalCooldown(context.Background(), upkeep)
+		require.NoError(t, err)
+		require.False(t, inCooldown)
+	})
+}
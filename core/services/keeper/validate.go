@@ -2,12 +2,15 @@ package keeper
 
 import (
 	"reflect"
+	"strconv"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/pelletier/go-toml"
 	"github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
 
 	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/gas"
 	"github.com/smartcontractkit/chainlink/core/services/job"
 	"github.com/smartcontractkit/chainlink/core/services/pipeline"
 )
@@ -37,17 +40,43 @@ perform_upkeep_tx        [type=ethtx
                           gasLimit="$(jobSpec.performUpkeepGasLimit)"
                           txMeta="{\"jobID\":$(jobSpec.jobID)}"]
 encode_check_upkeep_tx -> check_upkeep_tx -> decode_check_upkeep_tx -> encode_perform_upkeep_tx -> perform_upkeep_tx`
+
+	// expectedDirectObservationSourceRaw is the observation source of a keeper job performing the performData
+	// returned by the checkUpkeep call of the executer, instead of checking the upkeep again.
+	expectedDirectObservationSourceRaw = `
+encode_perform_upkeep_tx [type=ethabiencode
+                          abi="performUpkeep(uint256 id, bytes calldata performData)"
+                          data="{\"id\": $(jobSpec.upkeepID),\"performData\":$(jobSpec.performData)}"]
+perform_upkeep_tx        [type=ethtx
+                          minConfirmations=0
+                          to="$(jobSpec.contractAddress)"
+                          data="$(encode_perform_upkeep_tx)"
+                          gasLimit="$(jobSpec.performUpkeepGasLimit)"
+                          txMeta="{\"jobID\":$(jobSpec.jobID)}"]
+encode_perform_upkeep_tx -> perform_upkeep_tx`
 )
 
+// directPerformData is the variable the observation source of a keeper job performs the checked performData with
+const directPerformData = "$(jobSpec.performData)"
+
 // expectedPipeline it is basically parsed expectedObservationSourceRaw value
 var expectedPipeline pipeline.Pipeline
 
+// expectedDirectPipeline is the parsed expectedDirectObservationSourceRaw
+var expectedDirectPipeline pipeline.Pipeline
+
 func init() {
 	pp, err := pipeline.Parse(expectedObservationSourceRaw)
 	if err != nil {
 		logger.Default.With("error", err).Fatal("failed to parse default observation source")
 	}
 	expectedPipeline = *pp
+
+	pp, err = pipeline.Parse(expectedDirectObservationSourceRaw)
+	if err != nil {
+		logger.Default.With("error", err).Fatal("failed to parse direct observation source")
+	}
+	expectedDirectPipeline = *pp
 }
 
 func ValidatedKeeperSpec(tomlString string) (job.Job, error) {
@@ -74,9 +103,72 @@ func ValidatedKeeperSpec(tomlString string) (job.Job, error) {
 		return j, errors.Errorf("unsupported type %s", j.Type)
 	}
 
-	if !reflect.DeepEqual(j.Pipeline.Tasks, expectedPipeline.Tasks) {
+	if reflect.DeepEqual(j.Pipeline.Tasks, expectedPipeline.Tasks) {
+		// the executer checks the upkeep before the pipeline run, checking it again in the pipeline
+		// would double the checkUpkeep calls
+		j.Pipeline = expectedDirectPipeline
+	} else if !reflect.DeepEqual(j.Pipeline.Tasks, expectedDirectPipeline.Tasks) {
 		return j, errors.New("invalid observation source provided")
 	}
 
+	if err := validateRegistryAddresses(spec); err != nil {
+		return j, err
+	}
+
+	if err := validateUpkeepOverrides(spec.UpkeepOverrides); err != nil {
+		return j, err
+	}
+
+	if spec.RegistryCheckGasOverhead != nil && *spec.RegistryCheckGasOverhead == 0 {
+		return j, errors.New("registryCheckGasOverhead must be positive")
+	}
+	if spec.RegistryPerformGasOverhead != nil && *spec.RegistryPerformGasOverhead == 0 {
+		return j, errors.New("registryPerformGasOverhead must be positive")
+	}
+	if _, err := registryVersionFor(spec.RegistryVersion); err != nil {
+		return j, err
+	}
+
 	return j, nil
 }
+
+// validateRegistryAddresses checks that the additional registries of the spec are distinct from each other and ContractAddress
+func validateRegistryAddresses(spec job.KeeperSpec) error {
+	seen := map[common.Address]struct{}{spec.ContractAddress.Address(): {}}
+	for _, address := range spec.ContractAddresses {
+		if address.IsZero() {
+			return errors.New("contractAddresses must not contain the zero address")
+		}
+		if _, exists := seen[address.Address()]; exists {
+			return errors.Errorf("registry %s is listed more than once in contractAddress and contractAddresses", address.Hex())
+		}
+		seen[address.Address()] = struct{}{}
+	}
+	return nil
+}
+
+func validateUpkeepOverrides(overrides job.KeeperUpkeepOverrides) error {
+	for upkeepID, override := range overrides {
+		if _, err := strconv.ParseInt(upkeepID, 10, 64); err != nil {
+			return errors.Errorf("invalid upkeep ID %q in upkeepOverrides", upkeepID)
+		}
+		if override.GasPriceSpeed != "" && !gas.Speed(override.GasPriceSpeed).IsValid() {
+			return errors.Errorf("invalid gasPriceSpeed %q for upkeep %s", override.GasPriceSpeed, upkeepID)
+		}
+		if _, err := parsePerformWindows(override.PerformWindows); err != nil {
+			return errors.Wrapf(err, "invalid performWindows for upkeep %s", upkeepID)
+		}
+		if override.MaxGasPriceWei != nil && *override.MaxGasPriceWei == 0 {
+			return errors.Errorf("maxGasPriceWei for upkeep %s must be positive", upkeepID)
+		}
+		if override.SkipAboveMaxGasPrice && override.MaxGasPriceWei == nil {
+			return errors.Errorf("skipAboveMaxGasPrice for upkeep %s requires maxGasPriceWei", upkeepID)
+		}
+		if override.LogTrigger != nil {
+			if _, err := logTriggerFilter(*override.LogTrigger); err != nil {
+				return errors.Wrapf(err, "invalid logTrigger for upkeep %s", upkeepID)
+			}
+		}
+	}
+	return nil
+}
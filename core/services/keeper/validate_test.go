@@ -81,6 +81,42 @@ perform_upkeep_tx        [type=ethtx
                           txMeta="{\\"jobID\\":$(jobSpec.jobID)}"]
 encode_check_upkeep_tx -> check_upkeep_tx -> decode_check_upkeep_tx -> encode_perform_upkeep_tx -> perform_upkeep_tx
 """
+`,
+			},
+			want: want{
+				id:           0,
+				contractAddr: "0x9E40733cC9df84636505f4e6Db28DCa0dC5D1bba",
+				fromAddr:     "0xa8037A20989AFcBC51798de9762b351D63ff462e",
+				createdAt:    time.Time{},
+				updatedAt:    time.Time{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid job spec performing the checked performData",
+			args: args{
+				tomlString: `
+type            = "keeper"
+schemaVersion   = 2
+name            = "example keeper spec"
+contractAddress = "0x9E40733cC9df84636505f4e6Db28DCa0dC5D1bba"
+fromAddress     = "0xa8037A20989AFcBC51798de9762b351D63ff462e"
+evmChainID      = 4
+externalJobID   =  "123e4567-e89b-12d3-a456-426655440002"
+
+
+observationSource = """
+encode_perform_upkeep_tx [type=ethabiencode
+                          abi="performUpkeep(uint256 id, bytes calldata performData)"
+                          data="{\\"id\\": $(jobSpec.upkeepID),\\"performData\\":$(jobSpec.performData)}"]
+perform_upkeep_tx        [type=ethtx
+                          minConfirmations=0
+                          to="$(jobSpec.contractAddress)"
+                          data="$(encode_perform_upkeep_tx)"
+                          gasLimit="$(jobSpec.performUpkeepGasLimit)"
+                          txMeta="{\\"jobID\\":$(jobSpec.jobID)}"]
+encode_perform_upkeep_tx -> perform_upkeep_tx
+"""
 `,
 			},
 			want: want{
@@ -127,6 +163,152 @@ perform_upkeep_tx        [type=ethtx
                           txMeta="{\\"jobID\\":$(jobSpec.jobID)}"]
 encode_check_upkeep_tx -> check_upkeep_tx -> decode_check_upkeep_tx -> encode_perform_upkeep_tx -> perform_upkeep_tx
 """
+`,
+			},
+			want:    want{},
+			wantErr: true,
+		},
+		{
+			name: "invalid upkeep override gas price speed",
+			args: args{
+				tomlString: testspecs.GenerateKeeperSpec(testspecs.KeeperSpecParams{
+					ContractAddress: "0x9E40733cC9df84636505f4e6Db28DCa0dC5D1bba",
+					FromAddress:     "0xa8037A20989AFcBC51798de9762b351D63ff462e",
+				}).Toml() + `
+[upkeepOverrides.1]
+gasPriceSpeed = "ludicrous"
+`,
+			},
+			want:    want{},
+			wantErr: true,
+		},
+		{
+			name: "invalid upkeep override perform window",
+			args: args{
+				tomlString: testspecs.GenerateKeeperSpec(testspecs.KeeperSpecParams{
+					ContractAddress: "0x9E40733cC9df84636505f4e6Db28DCa0dC5D1bba",
+					FromAddress:     "0xa8037A20989AFcBC51798de9762b351D63ff462e",
+				}).Toml() + `
+[upkeepOverrides.1]
+performWindows = ["09:00-17:00", "9pm-11pm"]
+`,
+			},
+			want:    want{},
+			wantErr: true,
+		},
+		{
+			name: "zero upkeep override max gas price",
+			args: args{
+				tomlString: testspecs.GenerateKeeperSpec(testspecs.KeeperSpecParams{
+					ContractAddress: "0x9E40733cC9df84636505f4e6Db28DCa0dC5D1bba",
+					FromAddress:     "0xa8037A20989AFcBC51798de9762b351D63ff462e",
+				}).Toml() + `
+[upkeepOverrides.1]
+maxGasPriceWei = 0
+`,
+			},
+			want:    want{},
+			wantErr: true,
+		},
+		{
+			name: "valid job spec with a log-triggered upkeep",
+			args: args{
+				tomlString: testspecs.GenerateKeeperSpec(testspecs.KeeperSpecParams{
+					ContractAddress: "0x9E40733cC9df84636505f4e6Db28DCa0dC5D1bba",
+					FromAddress:     "0xa8037A20989AFcBC51798de9762b351D63ff462e",
+				}).Toml() + `
+[upkeepOverrides.1.logTrigger]
+address = "0x3cCad4715152693fE3BC4460591e3D3Fbd071b42"
+topics = ["0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"]
+`,
+			},
+			want: want{
+				contractAddr: "0x9E40733cC9df84636505f4e6Db28DCa0dC5D1bba",
+				fromAddr:     "0xa8037A20989AFcBC51798de9762b351D63ff462e",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid upkeep override log trigger topic",
+			args: args{
+				tomlString: testspecs.GenerateKeeperSpec(testspecs.KeeperSpecParams{
+					ContractAddress: "0x9E40733cC9df84636505f4e6Db28DCa0dC5D1bba",
+					FromAddress:     "0xa8037A20989AFcBC51798de9762b351D63ff462e",
+				}).Toml() + `
+[upkeepOverrides.1.logTrigger]
+address = "0x3cCad4715152693fE3BC4460591e3D3Fbd071b42"
+topics = ["Transfer(address,address,uint256)"]
+`,
+			},
+			want:    want{},
+			wantErr: true,
+		},
+		{
+			name: "zero registry gas overhead",
+			args: args{
+				tomlString: testspecs.GenerateKeeperSpec(testspecs.KeeperSpecParams{
+					ContractAddress: "0x9E40733cC9df84636505f4e6Db28DCa0dC5D1bba",
+					FromAddress:     "0xa8037A20989AFcBC51798de9762b351D63ff462e",
+				}).Toml() + `
+registryPerformGasOverhead = 0
+`,
+			},
+			want:    want{},
+			wantErr: true,
+		},
+		{
+			name: "valid job spec for a newer registry version",
+			args: args{
+				tomlString: testspecs.GenerateKeeperSpec(testspecs.KeeperSpecParams{
+					ContractAddress: "0x9E40733cC9df84636505f4e6Db28DCa0dC5D1bba",
+					FromAddress:     "0xa8037A20989AFcBC51798de9762b351D63ff462e",
+				}).Toml() + `
+registryVersion = "1.3"
+`,
+			},
+			want: want{
+				contractAddr: "0x9E40733cC9df84636505f4e6Db28DCa0dC5D1bba",
+				fromAddr:     "0xa8037A20989AFcBC51798de9762b351D63ff462e",
+			},
+			wantErr: false,
+		},
+		{
+			name: "unsupported registry version",
+			args: args{
+				tomlString: testspecs.GenerateKeeperSpec(testspecs.KeeperSpecParams{
+					ContractAddress: "0x9E40733cC9df84636505f4e6Db28DCa0dC5D1bba",
+					FromAddress:     "0xa8037A20989AFcBC51798de9762b351D63ff462e",
+				}).Toml() + `
+registryVersion = "2.0"
+`,
+			},
+			want:    want{},
+			wantErr: true,
+		},
+		{
+			name: "valid job spec with several registries",
+			args: args{
+				tomlString: testspecs.GenerateKeeperSpec(testspecs.KeeperSpecParams{
+					ContractAddress: "0x9E40733cC9df84636505f4e6Db28DCa0dC5D1bba",
+					FromAddress:     "0xa8037A20989AFcBC51798de9762b351D63ff462e",
+				}).Toml() + `
+contractAddresses = ["0x3cCad4715152693fE3BC4460591e3D3Fbd071b42"]
+`,
+			},
+			want: want{
+				contractAddr: "0x9E40733cC9df84636505f4e6Db28DCa0dC5D1bba",
+				fromAddr:     "0xa8037A20989AFcBC51798de9762b351D63ff462e",
+			},
+			wantErr: false,
+		},
+		{
+			name: "registry listed twice",
+			args: args{
+				tomlString: testspecs.GenerateKeeperSpec(testspecs.KeeperSpecParams{
+					ContractAddress: "0x9E40733cC9df84636505f4e6Db28DCa0dC5D1bba",
+					FromAddress:     "0xa8037A20989AFcBC51798de9762b351D63ff462e",
+				}).Toml() + `
+contractAddresses = ["0x9E40733cC9df84636505f4e6Db28DCa0dC5D1bba"]
 `,
 			},
 			want:    want{},
@@ -150,3 +332,16 @@ encode_check_upkeep_tx -> check_upkeep_tx -> decode_check_upkeep_tx -> encode_pe
 		})
 	}
 }
+
+func TestValidatedKeeperSpec_DirectObservationSource(t *testing.T) {
+	t.Parallel()
+
+	// the default observation source checks the upkeep again, jobs are created with the direct one instead
+	jb, err := ValidatedKeeperSpec(testspecs.GenerateKeeperSpec(testspecs.KeeperSpecParams{
+		ContractAddress: "0x9E40733cC9df84636505f4e6Db28DCa0dC5D1bba",
+		FromAddress:     "0xa8037A20989AFcBC51798de9762b351D63ff462e",
+	}).Toml())
+	require.NoError(t, err)
+	require.Equal(t, expectedDirectObservationSourceRaw, jb.Pipeline.Source)
+	require.Equal(t, expectedDirectPipeline.Tasks, jb.Pipeline.Tasks)
+}
@@ -0,0 +1,75 @@
+package keeper
+
+import (
+	"sync"
+	"time"
+)
+
+// closedChan is returned by waitGroup.done while no goroutine is counted
+var closedChan = func() chan struct{} {
+	ch := make(chan struct{})
+	close(ch)
+	return ch
+}()
+
+// waitGroup counts goroutines like a sync.WaitGroup, but can be waited on with a timeout
+// without leaving a goroutine behind once the timeout expires
+type waitGroup struct {
+	mu sync.Mutex
+	n  int
+	// chDone is closed once n drops back to zero, nil while n is zero
+	chDone chan struct{}
+}
+
+// Add adds delta, which may be negative, to the counter
+func (g *waitGroup) Add(delta int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.n == 0 && delta > 0 {
+		g.chDone = make(chan struct{})
+	}
+	g.n += delta
+	if g.n < 0 {
+		panic("keeper: negative waitGroup counter")
+	}
+	if g.n == 0 && g.chDone != nil {
+		close(g.chDone)
+		g.chDone = nil
+	}
+}
+
+// Done decrements the counter by one
+func (g *waitGroup) Done() {
+	g.Add(-1)
+}
+
+// WaitTimeout blocks until the counter is zero or timeout expired, returning false in the latter case
+func (g *waitGroup) WaitTimeout(timeout time.Duration) bool {
+	chDone := g.done()
+	if timeout <= 0 {
+		select {
+		case <-chDone:
+			return true
+		default:
+			return false
+		}
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-chDone:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+// done returns a channel that is closed once the counter is zero
+func (g *waitGroup) done() <-chan struct{} {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.chDone == nil {
+		return closedChan
+	}
+	return g.chDone
+}
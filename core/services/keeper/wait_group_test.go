@@ -0,0 +1,126 @@
+package keeper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/configtest"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	pipelinemocks "github.com/smartcontractkit/chainlink/core/services/pipeline/mocks"
+)
+
+func TestWaitGroup(t *testing.T) {
+	t.Parallel()
+
+	var g waitGroup
+	require.True(t, g.WaitTimeout(0))
+
+	g.Add(2)
+	require.False(t, g.WaitTimeout(0))
+	require.False(t, g.WaitTimeout(10*time.Millisecond))
+
+	g.Done()
+	require.False(t, g.WaitTimeout(0))
+	go g.Done()
+	require.True(t, g.WaitTimeout(5*time.Second))
+
+	// the group can be reused once it's done
+	g.Add(1)
+	require.False(t, g.WaitTimeout(0))
+	g.Done()
+	require.True(t, g.WaitTimeout(0))
+}
+
+func TestUpkeepExecuter_Close_ShutdownDeadline(t *testing.T) {
+	t.Parallel()
+
+	// startExecution starts an execution that ends once release is closed or its context is done,
+	// the returned channel receives the error of its context when it ended
+	startExecution := func(t *testing.T, deadline time.Duration, release chan struct{}) (*UpkeepExecuter, chan error) {
+		ex := newTestExecuter(t, testExecuterDeps{}, func(c *configtest.TestGeneralConfig) {
+			c.Overrides.KeeperShutdownDeadline = &deadline
+		})
+		require.NoError(t, ex.StartOnce("UpkeepExecuter", func() error { return nil }))
+		ended := make(chan error, 1)
+		ex.wgDone.Add(1)
+		go func() {
+			defer ex.wgDone.Done()
+			ctx, cancel := ex.executionContext(context.Background())
+			defer cancel()
+			select {
+			case <-release:
+			case <-ctx.Done():
+			}
+			ended <- ctx.Err()
+		}()
+		return ex, ended
+	}
+
+	t.Run("drains executions finishing before the deadline", func(t *testing.T) {
+		release := make(chan struct{})
+		ex, ended := startExecution(t, time.Minute, release)
+		time.AfterFunc(50*time.Millisecond, func() { close(release) })
+
+		require.NoError(t, ex.Close())
+		require.NoError(t, <-ended)
+	})
+
+	t.Run("cancels executions still running at the deadline", func(t *testing.T) {
+		ex, ended := startExecution(t, 50*time.Millisecond, make(chan struct{}))
+
+		start := time.Now()
+		require.NoError(t, ex.Close())
+		require.Less(t, int64(time.Since(start)), int64(forcedShutdownTimeout))
+		require.Equal(t, context.Canceled, <-ended)
+	})
+}
+
+func TestUpkeepExecuter_Close_ExecutionCounts(t *testing.T) {
+	t.Parallel()
+
+	// pipeline runs block until Close cancels them
+	runner := new(pipelinemocks.Runner)
+	runner.On("Run", mock.Anything, mock.Anything, mock.Anything, true, mock.Anything).
+		Run(func(args mock.Arguments) { <-args.Get(0).(context.Context).Done() }).
+		Return(false, context.Canceled)
+	ex := newTestExecuter(t, testExecuterDeps{db: pgtest.NewGormDB(t), ethClient: newEligibleClient(), runner: runner}, func(c *configtest.TestGeneralConfig) {
+		c.Overrides.KeeperMaximumConcurrentExecutions = null.IntFrom(1)
+		var deadline time.Duration
+		c.Overrides.KeeperShutdownDeadline = &deadline
+	})
+	require.NoError(t, ex.StartOnce("UpkeepExecuter", func() error { return nil }))
+
+	upkeeps := []UpkeepRegistration{
+		{UpkeepID: 1, ExecuteGas: 100_000},
+		{UpkeepID: 2, ExecuteGas: 100_000},
+		{UpkeepID: 3, ExecuteGas: 100_000},
+	}
+	executed := make(chan struct{})
+	go func() {
+		defer close(executed)
+		ex.executeUpkeeps(context.Background(), eth.Head{Number: 20}, time.Now(), false, upkeeps, nil, new(headSummary))
+	}()
+
+	// one upkeep executes while the others wait for its slot
+	require.Eventually(t, func() bool {
+		return ex.inFlight.Load() == 1 && ex.queued.Load() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Zero(t, ex.abandoned.Load())
+
+	require.NoError(t, ex.Close())
+	select {
+	case <-executed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("upkeeps were still being executed after Close")
+	}
+	require.Equal(t, int64(1), ex.abandoned.Load())
+	require.Zero(t, ex.inFlight.Load())
+	require.Zero(t, ex.queued.Load())
+	runner.AssertNumberOfCalls(t, "Run", 1)
+}
@@ -23,6 +23,31 @@ func TestGeneralConfig_Defaults(t *testing.T) {
 	assert.Equal(t, 15*time.Minute, config.SessionTimeout().Duration())
 }
 
+func TestGeneralConfig_ValidateKeeperExecutionTimeout(t *testing.T) {
+	config := NewGeneralConfig().(*generalConfig)
+
+	config.viper.Set(EnvVarName("KeeperExecutionTimeout"), "0s")
+	require.Error(t, config.Validate())
+
+	config.viper.Set(EnvVarName("KeeperExecutionTimeout"), "-1s")
+	require.Error(t, config.Validate())
+}
+
+func TestGeneralConfig_ValidateKeeperGasPriceSpeed(t *testing.T) {
+	config := NewGeneralConfig().(*generalConfig)
+
+	for _, speed := range []string{"economy", "standard", "fast"} {
+		config.viper.Set(EnvVarName("KeeperGasPriceSpeed"), speed)
+		require.NoError(t, config.Validate(), speed)
+	}
+
+	config.viper.Set(EnvVarName("KeeperGasPriceSpeed"), "slow")
+	require.Error(t, config.Validate())
+
+	config.viper.Set(EnvVarName("KeeperGasPriceSpeed"), "")
+	require.Error(t, config.Validate())
+}
+
 func TestGeneralConfig_sessionSecret(t *testing.T) {
 	t.Parallel()
 	config := NewGeneralConfig()
@@ -21,6 +21,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/gas"
 	"github.com/smartcontractkit/chainlink/core/services/keystore"
 	"github.com/smartcontractkit/chainlink/core/services/keystore/keys/ethkey"
 	"github.com/smartcontractkit/chainlink/core/services/keystore/keys/p2pkey"
@@ -97,13 +98,80 @@ type GeneralOnlyConfig interface {
 	JobPipelineReaperInterval() time.Duration
 	JobPipelineReaperThreshold() time.Duration
 	JobPipelineResultWriteQueueDepth() uint64
+	KeeperAuditDecisions() bool
+	KeeperAverageBlockTime() time.Duration
+	KeeperBaseFeeOverpaymentFactor() uint32
+	KeeperBatchCheckUpkeep() bool
+	KeeperBatchCheckUpkeepSize() uint32
+	KeeperBatchMetricUpdates() bool
+	KeeperBlockGasLimit() uint64
+	KeeperCheckBlockTag() string
+	KeeperClampToRegistryMaxGasPrice() bool
+	KeeperClockSkewTolerance() time.Duration
+	KeeperCompactHeadLog() bool
 	KeeperDefaultTransactionQueueDepth() uint32
+	KeeperDryRun() bool
+	KeeperEligibilityConfirmations() uint32
+	KeeperEligibilityQueryRPS() uint32
+	KeeperEligibilityQueryRetries() uint32
+	KeeperEligibilityQueryTimeout() time.Duration
+	KeeperEligibilityStaleFallback() bool
+	KeeperEstimateConfirmationBlocks() bool
+	KeeperExcludePendingPerforms() bool
+	KeeperExecutionChunkSize() uint32
+	KeeperExecutionTimeout() time.Duration
+	KeeperGasEstimationRetries() uint32
+	KeeperGasEstimationRetryBackoff() time.Duration
 	KeeperGasPriceBufferPercent() uint32
+	KeeperGasPriceSanityMaxWei() (*big.Int, bool)
+	KeeperGasPriceSanityMinWei() (*big.Int, bool)
+	KeeperGasPriceSpeed() string
+	KeeperGasReconcileEstimatorWeight() uint32
+	KeeperGasReconcileStrategy() string
+	KeeperGracePeriodLagMultiplier() float32
+	KeeperGracePeriodLagThreshold() uint32
+	KeeperHeadPollFallbackInterval() time.Duration
+	KeeperIdleHeadThreshold() uint32
+	KeeperIntervalCooldown() bool
+	KeeperLogTriggerBatchSize() uint32
+	KeeperLogTriggerBatchWindow() time.Duration
+	KeeperLowBalanceProjectionWindow() uint32
+	KeeperLowBalanceThreshold() *big.Int
+	KeeperMailboxCapacity() uint32
+	KeeperMaxIndexLag() uint32
+	KeeperMaxPerformDataSize() uint32
+	KeeperMaxPerformGasLimit() uint64
+	KeeperMaxProcessedHeadAge() time.Duration
+	KeeperMaxUpkeepStateEntries() uint32
+	KeeperMaxUpkeepsPerBlock() uint32
+	KeeperMaximumBackoffBlocks() uint32
+	KeeperMaximumConcurrentExecutions() uint32
+	KeeperMaximumGasPrice() *big.Int
 	KeeperMaximumGracePeriod() int64
+	KeeperMaximumGracePeriodDuration() time.Duration
+	KeeperMinConfirmations() uint32
+	KeeperMinHeadInterval() time.Duration
+	KeeperMinimumBalance() *big.Int
 	KeeperMinimumRequiredConfirmations() uint64
+	KeeperNonceGapTolerance() uint32
+	KeeperOverdueOrdering() bool
+	KeeperOverdueWarnHeads() uint32
+	KeeperPerformCadenceBlocks() uint32
+	KeeperRecoverPipelinePanics() bool
 	KeeperRegistryCheckGasOverhead() uint64
+	KeeperRegistryConcurrency() uint32
 	KeeperRegistryPerformGasOverhead() uint64
 	KeeperRegistrySyncInterval() time.Duration
+	KeeperReportMaxBlocks() uint32
+	KeeperShareHeadGasPrice() bool
+	KeeperShutdownDeadline() time.Duration
+	KeeperSimRevertAutoDisable() bool
+	KeeperSimRevertStreakThreshold() uint32
+	KeeperSimulatePerformUpkeep() bool
+	KeeperSimulationConcurrency() uint32
+	KeeperSkipDuplicateHeads() bool
+	KeeperUseEstimatedGasLimit() bool
+	KeeperValueWeightedOrdering() bool
 	KeyFile() string
 	LogLevel() LogLevel
 	LogSQLMigrations() bool
@@ -211,6 +279,10 @@ type GlobalConfig interface {
 	GlobalEvmRPCDefaultBatchSize() (uint32, bool)
 	GlobalFlagsContractAddress() (string, bool)
 	GlobalGasEstimatorMode() (string, bool)
+	GlobalKeeperGasPriceBufferPercent() (uint32, bool)
+	GlobalKeeperGasPriceSpeed() (string, bool)
+	GlobalKeeperRegistryCheckGasOverhead() (uint64, bool)
+	GlobalKeeperRegistryPerformGasOverhead() (uint64, bool)
 	GlobalLinkContractAddress() (string, bool)
 	GlobalMinIncomingConfirmations() (uint32, bool)
 	GlobalMinRequiredOutgoingConfirmations() (uint64, bool)
@@ -316,6 +388,12 @@ func (c *generalConfig) Validate() error {
 			return errors.Wrapf(err, "invalid monitoring url: %s", me)
 		}
 	}
+	if timeout := c.KeeperExecutionTimeout(); timeout <= 0 {
+		return errors.Errorf("KEEPER_EXECUTION_TIMEOUT must be greater than 0, got %v", timeout)
+	}
+	if speed := c.KeeperGasPriceSpeed(); !gas.Speed(speed).IsValid() {
+		return errors.Errorf("KEEPER_GAS_PRICE_SPEED must be one of %s, %s or %s, got %q", gas.SpeedEconomy, gas.SpeedStandard, gas.SpeedFast, speed)
+	}
 	return nil
 }
 
@@ -647,6 +725,422 @@ func (c *generalConfig) KeeperMaximumGracePeriod() int64 {
 	return c.viper.GetInt64(EnvVarName("KeeperMaximumGracePeriod"))
 }
 
+// KeeperGasReconcileStrategy controls how the keeper reconciles the gas estimator price with the
+// base fee of the head that triggered the upkeep on EIP-1559 chains. One of "max" (use the
+// higher of the two), "estimator" (ignore the base fee) or "blend" (weighted average)
+func (c *generalConfig) KeeperGasReconcileStrategy() string {
+	return c.viper.GetString(EnvVarName("KeeperGasReconcileStrategy"))
+}
+
+// KeeperGasReconcileEstimatorWeight is the percentage weight given to the gas estimator price
+// when KeeperGasReconcileStrategy is "blend". The head base fee receives the remainder
+func (c *generalConfig) KeeperGasReconcileEstimatorWeight() uint32 {
+	return c.viper.GetUint32(EnvVarName("KeeperGasReconcileEstimatorWeight"))
+}
+
+// KeeperGasPriceSpeed is the default gas price speed tier (economy, standard or fast) used for
+// upkeeps that do not set gasPriceSpeed in the upkeepOverrides of their job spec
+func (c *generalConfig) KeeperGasPriceSpeed() string {
+	return c.viper.GetString(EnvVarName("KeeperGasPriceSpeed"))
+}
+
+// KeeperLogTriggerBatchWindow is how long trigger logs for log-triggered upkeeps are collected
+// per registry before being performed together. Logs for the same upkeep within the window are
+// deduplicated. Set to 0 to perform each trigger log immediately
+func (c *generalConfig) KeeperLogTriggerBatchWindow() time.Duration {
+	return c.getWithFallback("KeeperLogTriggerBatchWindow", ParseDuration).(time.Duration)
+}
+
+// KeeperEligibilityConfirmations is the number of blocks behind the latest head at which upkeep
+// eligibility is evaluated, giving the registry synchronizer time to index recent events
+func (c *generalConfig) KeeperEligibilityConfirmations() uint32 {
+	return c.viper.GetUint32(EnvVarName("KeeperEligibilityConfirmations"))
+}
+
+// KeeperMaxPerformDataSize is the maximum length in bytes of the performData returned by checkUpkeep.
+// Upkeeps returning more are skipped before gas is estimated. Set to 0 to disable the check.
+func (c *generalConfig) KeeperMaxPerformDataSize() uint32 {
+	return c.viper.GetUint32(EnvVarName("KeeperMaxPerformDataSize"))
+}
+
+// KeeperHeadPollFallbackInterval is how often the upkeep executer polls the eth client for the latest head
+// if it cannot subscribe to the head broadcaster. Set to 0 to fail startup instead.
+func (c *generalConfig) KeeperHeadPollFallbackInterval() time.Duration {
+	return c.getWithFallback("KeeperHeadPollFallbackInterval", ParseDuration).(time.Duration)
+}
+
+// KeeperShutdownDeadline is how long closing the upkeep executer waits for in-flight executions
+// to finish before cancelling them. Set to 0 to cancel them immediately.
+func (c *generalConfig) KeeperShutdownDeadline() time.Duration {
+	return c.getWithFallback("KeeperShutdownDeadline", ParseDuration).(time.Duration)
+}
+
+// KeeperValueWeightedOrdering makes the upkeep executer process the eligible upkeeps with the
+// highest LINK payment reported by their last checkUpkeep first
+func (c *generalConfig) KeeperValueWeightedOrdering() bool {
+	return c.viper.GetBool(EnvVarName("KeeperValueWeightedOrdering"))
+}
+
+// KeeperClockSkewTolerance is how far head timestamps may differ from the node clock before
+// the upkeep executer considers the clocks skewed
+func (c *generalConfig) KeeperClockSkewTolerance() time.Duration {
+	return c.getWithFallback("KeeperClockSkewTolerance", ParseDuration).(time.Duration)
+}
+
+// KeeperMaxPerformGasLimit caps the gas limit of performUpkeep transactions. Set to 0 to disable the cap.
+func (c *generalConfig) KeeperMaxPerformGasLimit() uint64 {
+	return c.getWithFallback("KeeperMaxPerformGasLimit", ParseUint64).(uint64)
+}
+
+// KeeperIntervalCooldown makes the upkeep executer skip time-based upkeeps it performed less than
+// the interval reported by their target contract's interval() function ago
+func (c *generalConfig) KeeperIntervalCooldown() bool {
+	return c.viper.GetBool(EnvVarName("KeeperIntervalCooldown"))
+}
+
+// KeeperEligibilityQueryRPS is the aggregate rate per second at which the keeper executers of this node may query
+// the database for eligible upkeeps. Queries over the rate are spaced out rather than dropped. 0 disables pacing.
+func (c *generalConfig) KeeperEligibilityQueryRPS() uint32 {
+	return c.viper.GetUint32(EnvVarName("KeeperEligibilityQueryRPS"))
+}
+
+// KeeperNonceGapTolerance is the number of transactions from a sending key that may be broadcast past
+// a nonce gap before the keeper stops performing upkeeps until the gap is filled. 0 stops at any gap.
+func (c *generalConfig) KeeperNonceGapTolerance() uint32 {
+	return c.viper.GetUint32(EnvVarName("KeeperNonceGapTolerance"))
+}
+
+// KeeperCompactHeadLog logs a single line summarising the upkeeps executed for each head
+func (c *generalConfig) KeeperCompactHeadLog() bool {
+	return c.viper.GetBool(EnvVarName("KeeperCompactHeadLog"))
+}
+
+// KeeperSimRevertStreakThreshold is the number of consecutive heads an eligible upkeep's checkUpkeep
+// simulation may revert for an unexpected reason before an alert is raised. 0 disables the alert.
+func (c *generalConfig) KeeperSimRevertStreakThreshold() uint32 {
+	return c.viper.GetUint32(EnvVarName("KeeperSimRevertStreakThreshold"))
+}
+
+// KeeperSimRevertAutoDisable stops executing an upkeep once its simulation has reverted for
+// KeeperSimRevertStreakThreshold consecutive heads, until the keeper caches are flushed or the node restarts
+func (c *generalConfig) KeeperSimRevertAutoDisable() bool {
+	return c.viper.GetBool(EnvVarName("KeeperSimRevertAutoDisable"))
+}
+
+// KeeperBatchMetricUpdates buffers the metric updates of the upkeep executer and applies them once
+// per head, reducing contention on the metrics registry at the cost of slightly delayed metrics
+func (c *generalConfig) KeeperBatchMetricUpdates() bool {
+	return c.viper.GetBool(EnvVarName("KeeperBatchMetricUpdates"))
+}
+
+// KeeperMinHeadInterval is the minimum time between processed heads. A head arriving sooner after
+// the last processed head is ignored unless it advances the chain by more than one block. 0 processes every head.
+func (c *generalConfig) KeeperMinHeadInterval() time.Duration {
+	return c.getWithFallback("KeeperMinHeadInterval", ParseDuration).(time.Duration)
+}
+
+// KeeperCheckBlockTag is the block (latest or pending) the keeper simulates checkUpkeep against.
+// The pending block gives a more current view on fast chains but is less stable.
+func (c *generalConfig) KeeperCheckBlockTag() string {
+	return c.viper.GetString(EnvVarName("KeeperCheckBlockTag"))
+}
+
+// KeeperLowBalanceThreshold is the upkeep balance in juels below which a low balance event is emitted
+// after a perform, for operators to trigger top-ups. 0 disables low balance events.
+func (c *generalConfig) KeeperLowBalanceThreshold() *big.Int {
+	return c.getWithFallback("KeeperLowBalanceThreshold", ParseBigInt).(*big.Int)
+}
+
+// KeeperLowBalanceProjectionWindow is the number of recent performs of an upkeep from which its
+// time until depletion is projected in low balance events
+func (c *generalConfig) KeeperLowBalanceProjectionWindow() uint32 {
+	return c.viper.GetUint32(EnvVarName("KeeperLowBalanceProjectionWindow"))
+}
+
+// KeeperPerformCadenceBlocks limits each upkeep to one perform per window of this many blocks,
+// while it is still checked on every head. Windows are offset by upkeep ID to spread performs. 0 disables the cadence.
+func (c *generalConfig) KeeperPerformCadenceBlocks() uint32 {
+	return c.viper.GetUint32(EnvVarName("KeeperPerformCadenceBlocks"))
+}
+
+// KeeperMaxIndexLag is the number of blocks the highest registry log indexed for a keeper job may lag
+// behind the head before the keeper stops performing and reports itself unhealthy. 0 disables the check.
+func (c *generalConfig) KeeperMaxIndexLag() uint32 {
+	return c.viper.GetUint32(EnvVarName("KeeperMaxIndexLag"))
+}
+
+// KeeperAuditDecisions emits a decision event for every upkeep the keeper considers on every head,
+// recording why it was skipped or how its perform went
+func (c *generalConfig) KeeperAuditDecisions() bool {
+	return c.viper.GetBool(EnvVarName("KeeperAuditDecisions"))
+}
+
+// KeeperSimulationConcurrency limits the number of concurrent simulation eth_calls, checkUpkeep, batched
+// checkUpkeep and performUpkeep alike, independently of the execution queue. 0 means no limit
+func (c *generalConfig) KeeperSimulationConcurrency() uint32 {
+	return c.viper.GetUint32(EnvVarName("KeeperSimulationConcurrency"))
+}
+
+// KeeperBaseFeeOverpaymentFactor warns when the buffered gas price of a perform exceeds the head's
+// base fee by more than this factor. 0 disables the check
+func (c *generalConfig) KeeperBaseFeeOverpaymentFactor() uint32 {
+	return c.viper.GetUint32(EnvVarName("KeeperBaseFeeOverpaymentFactor"))
+}
+
+// KeeperEligibilityQueryRetries is the number of times a failed eligibility query is retried
+// with a short backoff at the same head before giving up on the head
+func (c *generalConfig) KeeperEligibilityQueryRetries() uint32 {
+	return c.viper.GetUint32(EnvVarName("KeeperEligibilityQueryRetries"))
+}
+
+// KeeperEligibilityStaleFallback allows loading the eligible upkeeps at the previously processed
+// head if the eligibility query keeps failing at the current head
+func (c *generalConfig) KeeperEligibilityStaleFallback() bool {
+	return c.viper.GetBool(EnvVarName("KeeperEligibilityStaleFallback"))
+}
+
+// KeeperExcludePendingPerforms excludes upkeeps with an unconfirmed perform transaction from this node
+// from the eligible upkeeps of a head
+func (c *generalConfig) KeeperExcludePendingPerforms() bool {
+	return c.viper.GetBool(EnvVarName("KeeperExcludePendingPerforms"))
+}
+
+// KeeperGracePeriodLagThreshold is the number of heads the executer may skip between two processed
+// heads before it considers itself behind and widens the grace period. 0 disables it
+func (c *generalConfig) KeeperGracePeriodLagThreshold() uint32 {
+	return c.viper.GetUint32(EnvVarName("KeeperGracePeriodLagThreshold"))
+}
+
+// KeeperGracePeriodLagMultiplier is what KeeperMaximumGracePeriod is multiplied by while the executer
+// is behind because of KeeperGracePeriodLagThreshold
+func (c *generalConfig) KeeperGracePeriodLagMultiplier() float32 {
+	return c.getWithFallback("KeeperGracePeriodLagMultiplier", ParseF32).(float32)
+}
+
+// KeeperMaximumConcurrentExecutions is the maximum number of upkeeps an upkeep executer
+// executes concurrently
+func (c *generalConfig) KeeperMaximumConcurrentExecutions() uint32 {
+	return c.viper.GetUint32(EnvVarName("KeeperMaximumConcurrentExecutions"))
+}
+
+// KeeperBlockGasLimit is the block gas limit performs are checked against.
+// If 0, the gas limit reported with the triggering head is used.
+// Upkeeps whose perform gas limit exceeds it are skipped, as their perform tx could never be included.
+func (c *generalConfig) KeeperBlockGasLimit() uint64 {
+	return c.getWithFallback("KeeperBlockGasLimit", ParseUint64).(uint64)
+}
+
+// KeeperExecutionTimeout is the deadline for a single upkeep execution, from checking the upkeep to broadcasting its perform tx.
+// Executions still running at the deadline are cancelled and counted as timed out.
+func (c *generalConfig) KeeperExecutionTimeout() time.Duration {
+	return c.getWithFallback("KeeperExecutionTimeout", ParseDuration).(time.Duration)
+}
+
+// KeeperSimulatePerformUpkeep enables simulating performUpkeep with eth_call against the latest block right before the perform tx is broadcast.
+// Upkeeps whose simulation reverts, for example because another keeper already performed them, are skipped.
+func (c *generalConfig) KeeperSimulatePerformUpkeep() bool {
+	return c.viper.GetBool(EnvVarName("KeeperSimulatePerformUpkeep"))
+}
+
+// KeeperExecutionChunkSize is the number of eligible upkeeps executed before waiting for
+// all of them to finish and starting on the next ones. If 0, all eligible upkeeps of a head are started at once,
+// bounded only by KeeperMaximumConcurrentExecutions.
+func (c *generalConfig) KeeperExecutionChunkSize() uint32 {
+	return c.viper.GetUint32(EnvVarName("KeeperExecutionChunkSize"))
+}
+
+// KeeperMaximumGasPrice is the highest gas price in wei keepers pay for a perform tx.
+// Buffered gas prices above it are clamped to it, and upkeeps are skipped if even the unbuffered estimate exceeds it.
+// 0 means no cap.
+func (c *generalConfig) KeeperMaximumGasPrice() *big.Int {
+	return c.getWithFallback("KeeperMaximumGasPrice", ParseBigInt).(*big.Int)
+}
+
+// KeeperIdleHeadThreshold is the number of consecutive processed heads without eligible upkeeps after which
+// the keeper_idle gauge reports the executer as idle. 0 disables the gauge.
+func (c *generalConfig) KeeperIdleHeadThreshold() uint32 {
+	return c.viper.GetUint32(EnvVarName("KeeperIdleHeadThreshold"))
+}
+
+// KeeperOverdueOrdering enables executing the eligible upkeeps that ran longest ago first, so that no upkeep starves
+// when a node can't perform all of them on a head. KeeperValueWeightedOrdering takes precedence over it.
+func (c *generalConfig) KeeperOverdueOrdering() bool {
+	return c.viper.GetBool(EnvVarName("KeeperOverdueOrdering"))
+}
+
+// KeeperMaximumBackoffBlocks caps the number of blocks keepers back off from an upkeep after failed executions.
+// The backoff doubles with every consecutive failure, starting at 1 block. 0 disables the backoff.
+func (c *generalConfig) KeeperMaximumBackoffBlocks() uint32 {
+	return c.viper.GetUint32(EnvVarName("KeeperMaximumBackoffBlocks"))
+}
+
+// KeeperBatchCheckUpkeep batches the checkUpkeep calls of the eligible upkeeps of a head into JSON-RPC batch
+// requests before executing them, only upkeeps whose check passed are executed
+func (c *generalConfig) KeeperBatchCheckUpkeep() bool {
+	return c.viper.GetBool(EnvVarName("KeeperBatchCheckUpkeep"))
+}
+
+// KeeperBatchCheckUpkeepSize is the maximum number of checkUpkeep calls in one batch request when
+// KeeperBatchCheckUpkeep is enabled
+func (c *generalConfig) KeeperBatchCheckUpkeepSize() uint32 {
+	return c.viper.GetUint32(EnvVarName("KeeperBatchCheckUpkeepSize"))
+}
+
+// KeeperUseEstimatedGasLimit performs upkeeps with the gas limit returned by the gas estimator
+// if it is higher than the one derived from the upkeep execute gas and KeeperRegistryPerformGasOverhead
+func (c *generalConfig) KeeperUseEstimatedGasLimit() bool {
+	return c.viper.GetBool(EnvVarName("KeeperUseEstimatedGasLimit"))
+}
+
+// KeeperEstimateConfirmationBlocks logs the number of blocks the gas estimator expects a perform to take to confirm
+// at its gas price, and exposes it to the pipeline as $(jobSpec.estimatedConfirmationBlocks)
+func (c *generalConfig) KeeperEstimateConfirmationBlocks() bool {
+	return c.viper.GetBool(EnvVarName("KeeperEstimateConfirmationBlocks"))
+}
+
+// KeeperSkipDuplicateHeads ignores heads with the same number and hash as the last processed head
+func (c *generalConfig) KeeperSkipDuplicateHeads() bool {
+	return c.viper.GetBool(EnvVarName("KeeperSkipDuplicateHeads"))
+}
+
+// KeeperMaxProcessedHeadAge is how long the upkeep executer may go without processing a head
+// before it reports itself unhealthy, e.g. because the head tracker stalled. 0 disables the check.
+func (c *generalConfig) KeeperMaxProcessedHeadAge() time.Duration {
+	return c.getWithFallback("KeeperMaxProcessedHeadAge", ParseDuration).(time.Duration)
+}
+
+// KeeperOverdueWarnHeads is the number of consecutive heads an upkeep can be eligible on without this node
+// performing it before a warning is logged. 0 disables the warning.
+func (c *generalConfig) KeeperOverdueWarnHeads() uint32 {
+	return c.viper.GetUint32(EnvVarName("KeeperOverdueWarnHeads"))
+}
+
+// KeeperMaxUpkeepStateEntries is the maximum number of upkeeps the executer holds each kind of in-memory state for.
+// The least recently used upkeep is evicted from a full cache. 0 disables the limit.
+func (c *generalConfig) KeeperMaxUpkeepStateEntries() uint32 {
+	return c.viper.GetUint32(EnvVarName("KeeperMaxUpkeepStateEntries"))
+}
+
+// KeeperRecoverPipelinePanics treats a panic in the pipeline run of an upkeep as a failed run instead of
+// crashing the node. Disabling it lets the panic propagate, e.g. to debug it.
+func (c *generalConfig) KeeperRecoverPipelinePanics() bool {
+	return c.viper.GetBool(EnvVarName("KeeperRecoverPipelinePanics"))
+}
+
+// KeeperDryRun makes keepers check upkeeps and estimate the gas of their performs, logging what would be
+// broadcast without running the pipeline. No perform transactions are sent.
+func (c *generalConfig) KeeperDryRun() bool {
+	return c.viper.GetBool(EnvVarName("KeeperDryRun"))
+}
+
+// KeeperShareHeadGasPrice estimates the gas price of performs once per head and gas price speed, sharing it
+// between the upkeeps executed on the head. It must stay disabled with estimators pricing the calldata of
+// transactions, e.g. for L1 data costs. It has no effect with KeeperUseEstimatedGasLimit.
+func (c *generalConfig) KeeperShareHeadGasPrice() bool {
+	return c.viper.GetBool(EnvVarName("KeeperShareHeadGasPrice"))
+}
+
+// KeeperMinConfirmations is the number of blocks the last run of an upkeep must be deep before the upkeep
+// is eligible again, so that it is not performed again before its perform transaction is confirmed.
+// It applies on top of KeeperMaximumGracePeriod, 0 disables it.
+func (c *generalConfig) KeeperMinConfirmations() uint32 {
+	return c.viper.GetUint32(EnvVarName("KeeperMinConfirmations"))
+}
+
+// KeeperGasEstimationRetries is the number of times the gas price estimate of a perform is retried
+// after a transient error of the gas estimator, e.g. a network error, before the upkeep is skipped
+func (c *generalConfig) KeeperGasEstimationRetries() uint32 {
+	return c.viper.GetUint32(EnvVarName("KeeperGasEstimationRetries"))
+}
+
+// KeeperGasEstimationRetryBackoff is how long the keeper waits before the first retry of a gas price
+// estimate, each further retry waits one backoff longer
+func (c *generalConfig) KeeperGasEstimationRetryBackoff() time.Duration {
+	return c.getWithFallback("KeeperGasEstimationRetryBackoff", ParseDuration).(time.Duration)
+}
+
+// KeeperClampToRegistryMaxGasPrice clamps the gas price of a perform to the maximum gas price the registry
+// reimburses it with, instead of skipping the upkeep
+func (c *generalConfig) KeeperClampToRegistryMaxGasPrice() bool {
+	return c.viper.GetBool(EnvVarName("KeeperClampToRegistryMaxGasPrice"))
+}
+
+// KeeperEligibilityQueryTimeout is the timeout of the query loading the upkeeps eligible on a head.
+// If unset, the default query timeout applies.
+func (c *generalConfig) KeeperEligibilityQueryTimeout() time.Duration {
+	return c.getWithFallback("KeeperEligibilityQueryTimeout", ParseDuration).(time.Duration)
+}
+
+// KeeperMailboxCapacity is the number of heads the upkeep executer buffers while it is busy processing a head.
+// Older heads are dropped once it is full, 1 keeps only the latest head.
+func (c *generalConfig) KeeperMailboxCapacity() uint32 {
+	return c.viper.GetUint32(EnvVarName("KeeperMailboxCapacity"))
+}
+
+// KeeperMaxUpkeepsPerBlock is the maximum number of upkeeps of a registry executed on a head, the remaining
+// eligible upkeeps are deferred to the next head. 0 disables the limit.
+func (c *generalConfig) KeeperMaxUpkeepsPerBlock() uint32 {
+	return c.viper.GetUint32(EnvVarName("KeeperMaxUpkeepsPerBlock"))
+}
+
+// KeeperMaximumGracePeriodDuration is KeeperMaximumGracePeriod expressed in time, the executer converts it to
+// blocks using the average block time. KeeperMaximumGracePeriod applies if it's unset or the block time is unknown.
+func (c *generalConfig) KeeperMaximumGracePeriodDuration() time.Duration {
+	return c.getWithFallback("KeeperMaximumGracePeriodDuration", ParseDuration).(time.Duration)
+}
+
+// KeeperAverageBlockTime is the block time the executer converts KeeperMaximumGracePeriodDuration to blocks with.
+// If unset, it is averaged over the timestamps of the processed heads.
+func (c *generalConfig) KeeperAverageBlockTime() time.Duration {
+	return c.getWithFallback("KeeperAverageBlockTime", ParseDuration).(time.Duration)
+}
+
+// KeeperMinimumBalance is the balance in wei the keeper fromAddress needs for the executer to perform upkeeps.
+// Heads are skipped while the balance is below it, since the perform transactions would fail to be submitted.
+// 0 disables the check.
+func (c *generalConfig) KeeperMinimumBalance() *big.Int {
+	return c.getWithFallback("KeeperMinimumBalance", ParseBigInt).(*big.Int)
+}
+
+// KeeperLogTriggerBatchSize flushes the trigger logs of a registry as soon as this many upkeeps are pending,
+// without waiting for KeeperLogTriggerBatchWindow to expire. 0 disables it
+func (c *generalConfig) KeeperLogTriggerBatchSize() uint32 {
+	return c.viper.GetUint32(EnvVarName("KeeperLogTriggerBatchSize"))
+}
+
+// KeeperRegistryConcurrency is the number of registries of a keeper job whose eligible upkeeps are
+// loaded, checked and enqueued concurrently for a head. 0 or 1 processes the upkeeps of every registry together
+func (c *generalConfig) KeeperRegistryConcurrency() uint32 {
+	return c.viper.GetUint32(EnvVarName("KeeperRegistryConcurrency"))
+}
+
+// KeeperReportMaxBlocks is the maximum number of blocks GenerateReport replays
+func (c *generalConfig) KeeperReportMaxBlocks() uint32 {
+	return c.viper.GetUint32(EnvVarName("KeeperReportMaxBlocks"))
+}
+
+// KeeperGasPriceSanityMinWei is the lowest gas price estimate the keeper treats as plausible.
+// Lower estimates are assumed to come from a malfunctioning estimator and are raised to it.
+// If unset or 0, estimates are not raised.
+func (*generalConfig) KeeperGasPriceSanityMinWei() (*big.Int, bool) {
+	val, ok := lookupEnv(EnvVarName("KeeperGasPriceSanityMinWei"), ParseBigInt)
+	if val == nil {
+		return nil, false
+	}
+	return val.(*big.Int), ok
+}
+
+// KeeperGasPriceSanityMaxWei is the highest gas price estimate the keeper treats as plausible.
+// Higher estimates are assumed to come from a malfunctioning estimator and are lowered to it.
+// If unset, a chain specific default is used.
+func (*generalConfig) KeeperGasPriceSanityMaxWei() (*big.Int, bool) {
+	val, ok := lookupEnv(EnvVarName("KeeperGasPriceSanityMaxWei"), ParseBigInt)
+	if val == nil {
+		return nil, false
+	}
+	return val.(*big.Int), ok
+}
+
 // JSONConsole when set to true causes logging to be made in JSON format
 // If set to false, logs in console format
 func (c *generalConfig) JSONConsole() bool {
@@ -1447,6 +1941,34 @@ func (*generalConfig) GlobalLinkContractAddress() (string, bool) {
 	}
 	return val.(string), ok
 }
+func (*generalConfig) GlobalKeeperGasPriceBufferPercent() (uint32, bool) {
+	val, ok := lookupEnv(EnvVarName("KeeperGasPriceBufferPercent"), ParseUint32)
+	if val == nil {
+		return 0, false
+	}
+	return val.(uint32), ok
+}
+func (*generalConfig) GlobalKeeperGasPriceSpeed() (string, bool) {
+	val, ok := lookupEnv(EnvVarName("KeeperGasPriceSpeed"), ParseString)
+	if val == nil {
+		return "", false
+	}
+	return val.(string), ok
+}
+func (*generalConfig) GlobalKeeperRegistryCheckGasOverhead() (uint64, bool) {
+	val, ok := lookupEnv(EnvVarName("KeeperRegistryCheckGasOverhead"), ParseUint64)
+	if val == nil {
+		return 0, false
+	}
+	return val.(uint64), ok
+}
+func (*generalConfig) GlobalKeeperRegistryPerformGasOverhead() (uint64, bool) {
+	val, ok := lookupEnv(EnvVarName("KeeperRegistryPerformGasOverhead"), ParseUint64)
+	if val == nil {
+		return 0, false
+	}
+	return val.(uint64), ok
+}
 func (*generalConfig) GlobalMinIncomingConfirmations() (uint32, bool) {
 	val, ok := lookupEnv(EnvVarName("MinIncomingConfirmations"), ParseUint32)
 	if val == nil {
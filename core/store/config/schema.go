@@ -95,13 +95,80 @@ type ConfigSchema struct {
 	JobPipelineReaperInterval                  time.Duration                 `env:"JOB_PIPELINE_REAPER_INTERVAL" default:"1h"`
 	JobPipelineReaperThreshold                 time.Duration                 `env:"JOB_PIPELINE_REAPER_THRESHOLD" default:"24h"`
 	JobPipelineResultWriteQueueDepth           uint64                        `env:"JOB_PIPELINE_RESULT_WRITE_QUEUE_DEPTH" default:"100"`
+	KeeperAuditDecisions                       bool                          `env:"KEEPER_AUDIT_DECISIONS" default:"false"`
+	KeeperAverageBlockTime                     time.Duration                 `env:"KEEPER_AVERAGE_BLOCK_TIME" default:"0s"`
+	KeeperBaseFeeOverpaymentFactor             uint32                        `env:"KEEPER_BASE_FEE_OVERPAYMENT_FACTOR" default:"0"`
+	KeeperBatchCheckUpkeep                     bool                          `env:"KEEPER_BATCH_CHECK_UPKEEP" default:"false"`
+	KeeperBatchCheckUpkeepSize                 uint32                        `env:"KEEPER_BATCH_CHECK_UPKEEP_SIZE" default:"50"`
+	KeeperBatchMetricUpdates                   bool                          `env:"KEEPER_BATCH_METRIC_UPDATES" default:"false"`
+	KeeperBlockGasLimit                        uint64                        `env:"KEEPER_BLOCK_GAS_LIMIT" default:"0"`
+	KeeperCheckBlockTag                        string                        `env:"KEEPER_CHECK_BLOCK_TAG" default:"latest"`
+	KeeperClampToRegistryMaxGasPrice           bool                          `env:"KEEPER_CLAMP_TO_REGISTRY_MAX_GAS_PRICE" default:"true"`
+	KeeperClockSkewTolerance                   time.Duration                 `env:"KEEPER_CLOCK_SKEW_TOLERANCE" default:"30s"`
+	KeeperCompactHeadLog                       bool                          `env:"KEEPER_COMPACT_HEAD_LOG" default:"false"`
 	KeeperDefaultTransactionQueueDepth         uint32                        `env:"KEEPER_DEFAULT_TRANSACTION_QUEUE_DEPTH" default:"1"`
+	KeeperDryRun                               bool                          `env:"KEEPER_DRY_RUN" default:"false"`
+	KeeperEligibilityConfirmations             uint32                        `env:"KEEPER_ELIGIBILITY_CONFIRMATIONS" default:"0"`
+	KeeperEligibilityQueryRPS                  uint32                        `env:"KEEPER_ELIGIBILITY_QUERY_RPS" default:"0"`
+	KeeperEligibilityQueryRetries              uint32                        `env:"KEEPER_ELIGIBILITY_QUERY_RETRIES" default:"0"`
+	KeeperEligibilityQueryTimeout              time.Duration                 `env:"KEEPER_ELIGIBILITY_QUERY_TIMEOUT" default:"0s"`
+	KeeperEligibilityStaleFallback             bool                          `env:"KEEPER_ELIGIBILITY_STALE_FALLBACK" default:"false"`
+	KeeperEstimateConfirmationBlocks           bool                          `env:"KEEPER_ESTIMATE_CONFIRMATION_BLOCKS" default:"false"`
+	KeeperExcludePendingPerforms               bool                          `env:"KEEPER_EXCLUDE_PENDING_PERFORMS" default:"false"`
+	KeeperExecutionChunkSize                   uint32                        `env:"KEEPER_EXECUTION_CHUNK_SIZE" default:"0"`
+	KeeperExecutionTimeout                     time.Duration                 `env:"KEEPER_EXECUTION_TIMEOUT" default:"1m"`
+	KeeperGasEstimationRetries                 uint32                        `env:"KEEPER_GAS_ESTIMATION_RETRIES" default:"2"`
+	KeeperGasEstimationRetryBackoff            time.Duration                 `env:"KEEPER_GAS_ESTIMATION_RETRY_BACKOFF" default:"100ms"`
 	KeeperGasPriceBufferPercent                uint32                        `env:"KEEPER_GAS_PRICE_BUFFER_PERCENT" default:"20"`
+	KeeperGasPriceSanityMaxWei                 *big.Int                      `env:"KEEPER_GAS_PRICE_SANITY_MAX_WEI"`
+	KeeperGasPriceSanityMinWei                 *big.Int                      `env:"KEEPER_GAS_PRICE_SANITY_MIN_WEI"`
+	KeeperGasPriceSpeed                        string                        `env:"KEEPER_GAS_PRICE_SPEED" default:"standard"`
+	KeeperGasReconcileEstimatorWeight          uint32                        `env:"KEEPER_GAS_RECONCILE_ESTIMATOR_WEIGHT" default:"50"`
+	KeeperGasReconcileStrategy                 string                        `env:"KEEPER_GAS_RECONCILE_STRATEGY" default:"max"`
+	KeeperGracePeriodLagMultiplier             float32                       `env:"KEEPER_GRACE_PERIOD_LAG_MULTIPLIER" default:"2"`
+	KeeperGracePeriodLagThreshold              uint32                        `env:"KEEPER_GRACE_PERIOD_LAG_THRESHOLD" default:"0"`
+	KeeperHeadPollFallbackInterval             time.Duration                 `env:"KEEPER_HEAD_POLL_FALLBACK_INTERVAL" default:"0s"`
+	KeeperIdleHeadThreshold                    uint32                        `env:"KEEPER_IDLE_HEAD_THRESHOLD" default:"10"`
+	KeeperIntervalCooldown                     bool                          `env:"KEEPER_INTERVAL_COOLDOWN" default:"false"`
+	KeeperLogTriggerBatchSize                  uint32                        `env:"KEEPER_LOG_TRIGGER_BATCH_SIZE" default:"0"`
+	KeeperLogTriggerBatchWindow                time.Duration                 `env:"KEEPER_LOG_TRIGGER_BATCH_WINDOW" default:"0s"`
+	KeeperLowBalanceProjectionWindow           uint32                        `env:"KEEPER_LOW_BALANCE_PROJECTION_WINDOW" default:"10"`
+	KeeperLowBalanceThreshold                  *big.Int                      `env:"KEEPER_LOW_BALANCE_THRESHOLD" default:"0"`
+	KeeperMailboxCapacity                      uint32                        `env:"KEEPER_MAILBOX_CAPACITY" default:"1"`
+	KeeperMaxIndexLag                          uint32                        `env:"KEEPER_MAX_INDEX_LAG" default:"0"`
+	KeeperMaxPerformDataSize                   uint32                        `env:"KEEPER_MAX_PERFORM_DATA_SIZE" default:"0"`
+	KeeperMaxPerformGasLimit                   uint64                        `env:"KEEPER_MAX_PERFORM_GAS_LIMIT" default:"0"`
+	KeeperMaxProcessedHeadAge                  time.Duration                 `env:"KEEPER_MAX_PROCESSED_HEAD_AGE" default:"0s"`
+	KeeperMaxUpkeepStateEntries                uint32                        `env:"KEEPER_MAX_UPKEEP_STATE_ENTRIES" default:"10000"`
+	KeeperMaxUpkeepsPerBlock                   uint32                        `env:"KEEPER_MAX_UPKEEPS_PER_BLOCK" default:"0"`
+	KeeperMaximumBackoffBlocks                 uint32                        `env:"KEEPER_MAXIMUM_BACKOFF_BLOCKS" default:"0"`
+	KeeperMaximumConcurrentExecutions          uint32                        `env:"KEEPER_MAXIMUM_CONCURRENT_EXECUTIONS" default:"10"`
+	KeeperMaximumGasPrice                      *big.Int                      `env:"KEEPER_MAXIMUM_GAS_PRICE" default:"0"`
 	KeeperMaximumGracePeriod                   int64                         `env:"KEEPER_MAXIMUM_GRACE_PERIOD" default:"100"`
+	KeeperMaximumGracePeriodDuration           time.Duration                 `env:"KEEPER_MAXIMUM_GRACE_PERIOD_DURATION" default:"0s"`
+	KeeperMinConfirmations                     uint32                        `env:"KEEPER_MIN_CONFIRMATIONS" default:"0"`
+	KeeperMinHeadInterval                      time.Duration                 `env:"KEEPER_MIN_HEAD_INTERVAL" default:"0s"`
+	KeeperMinimumBalance                       *big.Int                      `env:"KEEPER_MINIMUM_BALANCE" default:"0"`
 	KeeperMinimumRequiredConfirmations         uint64                        `env:"KEEPER_MINIMUM_REQUIRED_CONFIRMATIONS" default:"12"`
+	KeeperNonceGapTolerance                    uint32                        `env:"KEEPER_NONCE_GAP_TOLERANCE" default:"0"`
+	KeeperOverdueOrdering                      bool                          `env:"KEEPER_OVERDUE_ORDERING" default:"false"`
+	KeeperOverdueWarnHeads                     uint32                        `env:"KEEPER_OVERDUE_WARN_HEADS" default:"0"`
+	KeeperPerformCadenceBlocks                 uint32                        `env:"KEEPER_PERFORM_CADENCE_BLOCKS" default:"0"`
+	KeeperRecoverPipelinePanics                bool                          `env:"KEEPER_RECOVER_PIPELINE_PANICS" default:"true"`
 	KeeperRegistryCheckGasOverhead             uint64                        `env:"KEEPER_REGISTRY_CHECK_GAS_OVERHEAD" default:"200000"`
+	KeeperRegistryConcurrency                  uint32                        `env:"KEEPER_REGISTRY_CONCURRENCY" default:"0"`
 	KeeperRegistryPerformGasOverhead           uint64                        `env:"KEEPER_REGISTRY_PERFORM_GAS_OVERHEAD" default:"150000"`
 	KeeperRegistrySyncInterval                 time.Duration                 `env:"KEEPER_REGISTRY_SYNC_INTERVAL" default:"30m"`
+	KeeperReportMaxBlocks                      uint32                        `env:"KEEPER_REPORT_MAX_BLOCKS" default:"1000"`
+	KeeperShareHeadGasPrice                    bool                          `env:"KEEPER_SHARE_HEAD_GAS_PRICE" default:"false"`
+	KeeperShutdownDeadline                     time.Duration                 `env:"KEEPER_SHUTDOWN_DEADLINE" default:"10s"`
+	KeeperSimRevertAutoDisable                 bool                          `env:"KEEPER_SIM_REVERT_AUTO_DISABLE" default:"false"`
+	KeeperSimRevertStreakThreshold             uint32                        `env:"KEEPER_SIM_REVERT_STREAK_THRESHOLD" default:"20"`
+	KeeperSimulatePerformUpkeep                bool                          `env:"KEEPER_SIMULATE_PERFORM_UPKEEP" default:"false"`
+	KeeperSimulationConcurrency                uint32                        `env:"KEEPER_SIMULATION_CONCURRENCY" default:"0"`
+	KeeperSkipDuplicateHeads                   bool                          `env:"KEEPER_SKIP_DUPLICATE_HEADS" default:"true"`
+	KeeperUseEstimatedGasLimit                 bool                          `env:"KEEPER_USE_ESTIMATED_GAS_LIMIT" default:"false"`
+	KeeperValueWeightedOrdering                bool                          `env:"KEEPER_VALUE_WEIGHTED_ORDERING" default:"false"`
 	LinkContractAddress                        string                        `env:"LINK_CONTRACT_ADDRESS"`
 	LogLevel                                   LogLevel                      `env:"LOG_LEVEL"`
 	LogSQLMigrations                           bool                          `env:"LOG_SQL_MIGRATIONS" default:"true"`
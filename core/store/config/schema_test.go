@@ -94,13 +94,80 @@ func TestConfigSchema(t *testing.T) {
 		"JobPipelineReaperInterval":                  "JOB_PIPELINE_REAPER_INTERVAL",
 		"JobPipelineReaperThreshold":                 "JOB_PIPELINE_REAPER_THRESHOLD",
 		"JobPipelineResultWriteQueueDepth":           "JOB_PIPELINE_RESULT_WRITE_QUEUE_DEPTH",
+		"KeeperAuditDecisions":                       "KEEPER_AUDIT_DECISIONS",
+		"KeeperAverageBlockTime":                     "KEEPER_AVERAGE_BLOCK_TIME",
+		"KeeperBaseFeeOverpaymentFactor":             "KEEPER_BASE_FEE_OVERPAYMENT_FACTOR",
+		"KeeperBatchCheckUpkeep":                     "KEEPER_BATCH_CHECK_UPKEEP",
+		"KeeperBatchCheckUpkeepSize":                 "KEEPER_BATCH_CHECK_UPKEEP_SIZE",
+		"KeeperBatchMetricUpdates":                   "KEEPER_BATCH_METRIC_UPDATES",
+		"KeeperBlockGasLimit":                        "KEEPER_BLOCK_GAS_LIMIT",
+		"KeeperCheckBlockTag":                        "KEEPER_CHECK_BLOCK_TAG",
+		"KeeperClampToRegistryMaxGasPrice":           "KEEPER_CLAMP_TO_REGISTRY_MAX_GAS_PRICE",
+		"KeeperClockSkewTolerance":                   "KEEPER_CLOCK_SKEW_TOLERANCE",
+		"KeeperCompactHeadLog":                       "KEEPER_COMPACT_HEAD_LOG",
 		"KeeperDefaultTransactionQueueDepth":         "KEEPER_DEFAULT_TRANSACTION_QUEUE_DEPTH",
+		"KeeperDryRun":                               "KEEPER_DRY_RUN",
+		"KeeperEligibilityConfirmations":             "KEEPER_ELIGIBILITY_CONFIRMATIONS",
+		"KeeperEligibilityQueryRPS":                  "KEEPER_ELIGIBILITY_QUERY_RPS",
+		"KeeperEligibilityQueryRetries":              "KEEPER_ELIGIBILITY_QUERY_RETRIES",
+		"KeeperEligibilityQueryTimeout":              "KEEPER_ELIGIBILITY_QUERY_TIMEOUT",
+		"KeeperEligibilityStaleFallback":             "KEEPER_ELIGIBILITY_STALE_FALLBACK",
+		"KeeperEstimateConfirmationBlocks":           "KEEPER_ESTIMATE_CONFIRMATION_BLOCKS",
+		"KeeperExcludePendingPerforms":               "KEEPER_EXCLUDE_PENDING_PERFORMS",
+		"KeeperExecutionChunkSize":                   "KEEPER_EXECUTION_CHUNK_SIZE",
+		"KeeperExecutionTimeout":                     "KEEPER_EXECUTION_TIMEOUT",
+		"KeeperGasEstimationRetries":                 "KEEPER_GAS_ESTIMATION_RETRIES",
+		"KeeperGasEstimationRetryBackoff":            "KEEPER_GAS_ESTIMATION_RETRY_BACKOFF",
 		"KeeperGasPriceBufferPercent":                "KEEPER_GAS_PRICE_BUFFER_PERCENT",
+		"KeeperGasPriceSanityMaxWei":                 "KEEPER_GAS_PRICE_SANITY_MAX_WEI",
+		"KeeperGasPriceSanityMinWei":                 "KEEPER_GAS_PRICE_SANITY_MIN_WEI",
+		"KeeperGasPriceSpeed":                        "KEEPER_GAS_PRICE_SPEED",
+		"KeeperGasReconcileEstimatorWeight":          "KEEPER_GAS_RECONCILE_ESTIMATOR_WEIGHT",
+		"KeeperGasReconcileStrategy":                 "KEEPER_GAS_RECONCILE_STRATEGY",
+		"KeeperGracePeriodLagMultiplier":             "KEEPER_GRACE_PERIOD_LAG_MULTIPLIER",
+		"KeeperGracePeriodLagThreshold":              "KEEPER_GRACE_PERIOD_LAG_THRESHOLD",
+		"KeeperHeadPollFallbackInterval":             "KEEPER_HEAD_POLL_FALLBACK_INTERVAL",
+		"KeeperIdleHeadThreshold":                    "KEEPER_IDLE_HEAD_THRESHOLD",
+		"KeeperIntervalCooldown":                     "KEEPER_INTERVAL_COOLDOWN",
+		"KeeperLogTriggerBatchSize":                  "KEEPER_LOG_TRIGGER_BATCH_SIZE",
+		"KeeperLogTriggerBatchWindow":                "KEEPER_LOG_TRIGGER_BATCH_WINDOW",
+		"KeeperLowBalanceProjectionWindow":           "KEEPER_LOW_BALANCE_PROJECTION_WINDOW",
+		"KeeperLowBalanceThreshold":                  "KEEPER_LOW_BALANCE_THRESHOLD",
+		"KeeperMailboxCapacity":                      "KEEPER_MAILBOX_CAPACITY",
+		"KeeperMaxIndexLag":                          "KEEPER_MAX_INDEX_LAG",
+		"KeeperMaxPerformDataSize":                   "KEEPER_MAX_PERFORM_DATA_SIZE",
+		"KeeperMaxPerformGasLimit":                   "KEEPER_MAX_PERFORM_GAS_LIMIT",
+		"KeeperMaxProcessedHeadAge":                  "KEEPER_MAX_PROCESSED_HEAD_AGE",
+		"KeeperMaxUpkeepStateEntries":                "KEEPER_MAX_UPKEEP_STATE_ENTRIES",
+		"KeeperMaxUpkeepsPerBlock":                   "KEEPER_MAX_UPKEEPS_PER_BLOCK",
+		"KeeperMaximumBackoffBlocks":                 "KEEPER_MAXIMUM_BACKOFF_BLOCKS",
+		"KeeperMaximumConcurrentExecutions":          "KEEPER_MAXIMUM_CONCURRENT_EXECUTIONS",
+		"KeeperMaximumGasPrice":                      "KEEPER_MAXIMUM_GAS_PRICE",
 		"KeeperMaximumGracePeriod":                   "KEEPER_MAXIMUM_GRACE_PERIOD",
+		"KeeperMaximumGracePeriodDuration":           "KEEPER_MAXIMUM_GRACE_PERIOD_DURATION",
+		"KeeperMinConfirmations":                     "KEEPER_MIN_CONFIRMATIONS",
+		"KeeperMinHeadInterval":                      "KEEPER_MIN_HEAD_INTERVAL",
+		"KeeperMinimumBalance":                       "KEEPER_MINIMUM_BALANCE",
 		"KeeperMinimumRequiredConfirmations":         "KEEPER_MINIMUM_REQUIRED_CONFIRMATIONS",
+		"KeeperNonceGapTolerance":                    "KEEPER_NONCE_GAP_TOLERANCE",
+		"KeeperOverdueOrdering":                      "KEEPER_OVERDUE_ORDERING",
+		"KeeperOverdueWarnHeads":                     "KEEPER_OVERDUE_WARN_HEADS",
+		"KeeperPerformCadenceBlocks":                 "KEEPER_PERFORM_CADENCE_BLOCKS",
+		"KeeperRecoverPipelinePanics":                "KEEPER_RECOVER_PIPELINE_PANICS",
 		"KeeperRegistryCheckGasOverhead":             "KEEPER_REGISTRY_CHECK_GAS_OVERHEAD",
+		"KeeperRegistryConcurrency":                  "KEEPER_REGISTRY_CONCURRENCY",
 		"KeeperRegistryPerformGasOverhead":           "KEEPER_REGISTRY_PERFORM_GAS_OVERHEAD",
 		"KeeperRegistrySyncInterval":                 "KEEPER_REGISTRY_SYNC_INTERVAL",
+		"KeeperReportMaxBlocks":                      "KEEPER_REPORT_MAX_BLOCKS",
+		"KeeperShareHeadGasPrice":                    "KEEPER_SHARE_HEAD_GAS_PRICE",
+		"KeeperShutdownDeadline":                     "KEEPER_SHUTDOWN_DEADLINE",
+		"KeeperSimRevertAutoDisable":                 "KEEPER_SIM_REVERT_AUTO_DISABLE",
+		"KeeperSimRevertStreakThreshold":             "KEEPER_SIM_REVERT_STREAK_THRESHOLD",
+		"KeeperSimulatePerformUpkeep":                "KEEPER_SIMULATE_PERFORM_UPKEEP",
+		"KeeperSimulationConcurrency":                "KEEPER_SIMULATION_CONCURRENCY",
+		"KeeperSkipDuplicateHeads":                   "KEEPER_SKIP_DUPLICATE_HEADS",
+		"KeeperUseEstimatedGasLimit":                 "KEEPER_USE_ESTIMATED_GAS_LIMIT",
+		"KeeperValueWeightedOrdering":                "KEEPER_VALUE_WEIGHTED_ORDERING",
 		"LinkContractAddress":                        "LINK_CONTRACT_ADDRESS",
 		"LogLevel":                                   "LOG_LEVEL",
 		"LogSQLMigrations":                           "LOG_SQL_MIGRATIONS",
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE heads ADD COLUMN base_fee_per_gas numeric(78,0);
+
+-- +goose Down
+ALTER TABLE heads DROP COLUMN base_fee_per_gas;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE keeper_specs ADD COLUMN upkeep_overrides jsonb;
+
+-- +goose Down
+ALTER TABLE keeper_specs DROP COLUMN upkeep_overrides;
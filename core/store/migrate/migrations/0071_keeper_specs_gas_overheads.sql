@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE keeper_specs ADD COLUMN registry_check_gas_overhead bigint, ADD COLUMN registry_perform_gas_overhead bigint;
+
+-- +goose Down
+ALTER TABLE keeper_specs DROP COLUMN registry_check_gas_overhead, DROP COLUMN registry_perform_gas_overhead;
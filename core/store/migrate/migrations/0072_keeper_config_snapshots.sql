@@ -0,0 +1,9 @@
+-- +goose Up
+CREATE TABLE keeper_config_snapshots (
+    job_id int PRIMARY KEY REFERENCES jobs(id) ON DELETE CASCADE DEFERRABLE INITIALLY IMMEDIATE,
+    snapshot jsonb NOT NULL,
+    updated_at timestamptz NOT NULL
+);
+
+-- +goose Down
+DROP TABLE keeper_config_snapshots;
@@ -0,0 +1,11 @@
+-- +goose Up
+ALTER TABLE upkeep_registrations
+    ADD COLUMN last_error text,
+    ADD COLUMN last_error_block_height bigint,
+    ADD COLUMN failure_count int NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE upkeep_registrations
+    DROP COLUMN last_error,
+    DROP COLUMN last_error_block_height,
+    DROP COLUMN failure_count;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE keeper_registries ADD COLUMN paused boolean NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE keeper_registries DROP COLUMN paused;
@@ -0,0 +1,27 @@
+-- +goose Up
+ALTER TABLE keeper_specs ADD COLUMN contract_addresses jsonb;
+
+ALTER TABLE keeper_registries DROP CONSTRAINT keeper_registries_job_id_key;
+CREATE INDEX idx_keeper_registries_job_id ON keeper_registries(job_id);
+
+-- +goose Down
+-- jobs servicing several registries keep the registry of their contract_address, or their oldest one,
+-- the upkeeps of the other registries are deleted along with them
+DELETE FROM keeper_registries
+WHERE id IN (
+    SELECT id FROM (
+        SELECT keeper_registries.id, row_number() OVER (
+            PARTITION BY keeper_registries.job_id
+            ORDER BY (keeper_registries.contract_address = keeper_specs.contract_address) DESC, keeper_registries.id ASC
+        ) AS registry_rank
+        FROM keeper_registries
+        INNER JOIN jobs ON jobs.id = keeper_registries.job_id
+        INNER JOIN keeper_specs ON keeper_specs.id = jobs.keeper_spec_id
+    ) ranked
+    WHERE registry_rank > 1
+);
+
+DROP INDEX idx_keeper_registries_job_id;
+ALTER TABLE keeper_registries ADD CONSTRAINT keeper_registries_job_id_key UNIQUE (job_id);
+
+ALTER TABLE keeper_specs DROP COLUMN contract_addresses;
@@ -0,0 +1,11 @@
+-- +goose Up
+ALTER TABLE upkeep_registrations
+    ADD COLUMN check_count bigint NOT NULL DEFAULT 0,
+    ADD COLUMN perform_count bigint NOT NULL DEFAULT 0,
+    ADD COLUMN skip_count bigint NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE upkeep_registrations
+    DROP COLUMN check_count,
+    DROP COLUMN perform_count,
+    DROP COLUMN skip_count;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE keeper_specs ADD COLUMN registry_version text;
+ALTER TABLE keeper_registries ADD COLUMN version text NOT NULL DEFAULT '1.1';
+
+-- +goose Down
+ALTER TABLE keeper_registries DROP COLUMN version;
+ALTER TABLE keeper_specs DROP COLUMN registry_version;
@@ -0,0 +1,52 @@
+-- +goose Up
+-- the executer checks upkeeps before the pipeline run, keeper jobs checking them again in the pipeline
+-- are migrated to the observation source performing the checked performData. Customized observation sources
+-- not performing $(decode_check_upkeep_tx.performData) are left unchanged, keeper jobs still checking their upkeeps
+-- in the pipeline log a warning when they start.
+UPDATE pipeline_specs
+SET dot_dag_source = 'encode_perform_upkeep_tx [type=ethabiencode
+                          abi="performUpkeep(uint256 id, bytes calldata performData)"
+                          data="{\"id\": $(jobSpec.upkeepID),\"performData\":$(jobSpec.performData)}"]
+perform_upkeep_tx        [type=ethtx
+                          minConfirmations=0
+                          to="$(jobSpec.contractAddress)"
+                          data="$(encode_perform_upkeep_tx)"
+                          gasLimit="$(jobSpec.performUpkeepGasLimit)"
+                          txMeta="{\"jobID\":$(jobSpec.jobID)}"]
+encode_perform_upkeep_tx -> perform_upkeep_tx'
+WHERE id IN (
+    SELECT pipeline_spec_id
+    FROM jobs
+    WHERE type = 'keeper'
+) AND dot_dag_source LIKE '%$(decode_check_upkeep_tx.performData)%';
+
+-- +goose Down
+-- the observation source checking the upkeep in the pipeline is valid on every earlier version
+UPDATE pipeline_specs
+SET dot_dag_source = 'encode_check_upkeep_tx   [type=ethabiencode
+                          abi="checkUpkeep(uint256 id, address from)"
+                          data="{\"id\":$(jobSpec.upkeepID),\"from\":$(jobSpec.fromAddress)}"]
+check_upkeep_tx          [type=ethcall
+                          failEarly=true
+                          extractRevertReason=true
+                          contract="$(jobSpec.contractAddress)"
+                          gas="$(jobSpec.checkUpkeepGasLimit)"
+                          gasPrice="$(jobSpec.gasPrice)"
+                          data="$(encode_check_upkeep_tx)"]
+decode_check_upkeep_tx   [type=ethabidecode
+                          abi="bytes memory performData, uint256 maxLinkPayment, uint256 gasLimit, uint256 adjustedGasWei, uint256 linkEth"]
+encode_perform_upkeep_tx [type=ethabiencode
+                          abi="performUpkeep(uint256 id, bytes calldata performData)"
+                          data="{\"id\": $(jobSpec.upkeepID),\"performData\":$(decode_check_upkeep_tx.performData)}"]
+perform_upkeep_tx        [type=ethtx
+                          minConfirmations=0
+                          to="$(jobSpec.contractAddress)"
+                          data="$(encode_perform_upkeep_tx)"
+                          gasLimit="$(jobSpec.performUpkeepGasLimit)"
+                          txMeta="{\"jobID\":$(jobSpec.jobID)}"]
+encode_check_upkeep_tx -> check_upkeep_tx -> decode_check_upkeep_tx -> encode_perform_upkeep_tx -> perform_upkeep_tx'
+WHERE id IN (
+    SELECT pipeline_spec_id
+    FROM jobs
+    WHERE type = 'keeper'
+) AND dot_dag_source LIKE '%$(jobSpec.performData)%';
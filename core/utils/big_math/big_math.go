@@ -19,6 +19,20 @@ func Mul(multiplicand, multiplier interface{}) *big.Int {
 }
 func Mod(dividend, divisor interface{}) *big.Int   { return I().Mod(bnIfy(dividend), bnIfy(divisor)) }
 func Sub(minuend, subtrahend interface{}) *big.Int { return I().Sub(bnIfy(minuend), bnIfy(subtrahend)) }
+func Max(x, y interface{}) *big.Int {
+	xb, yb := bnIfy(x), bnIfy(y)
+	if xb.Cmp(yb) >= 0 {
+		return I().Set(xb)
+	}
+	return I().Set(yb)
+}
+func Min(x, y interface{}) *big.Int {
+	xb, yb := bnIfy(x), bnIfy(y)
+	if xb.Cmp(yb) <= 0 {
+		return I().Set(xb)
+	}
+	return I().Set(yb)
+}
 
 func bnIfy(val interface{}) *big.Int {
 	switch v := val.(type) {
@@ -57,6 +57,13 @@ func (m *Mailbox) Deliver(x interface{}) (wasOverCapacity bool) {
 	return
 }
 
+// Len returns the number of interfaces in the queue
+func (m *Mailbox) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.queue)
+}
+
 // Retrieve fetches an interface from the queue
 func (m *Mailbox) Retrieve() (interface{}, bool) {
 	m.mu.Lock()
@@ -117,3 +117,16 @@ func TestMailbox_NoEmptyReceivesWhenCapacityIsTwo(t *testing.T) {
 	<-chDone
 	require.Len(t, emptyReceives, 0)
 }
+
+func TestMailbox_Len(t *testing.T) {
+	m := utils.NewMailbox(2)
+	require.Equal(t, 0, m.Len())
+
+	m.Deliver(1)
+	m.Deliver(2)
+	m.Deliver(3)
+	require.Equal(t, 2, m.Len())
+
+	m.Retrieve()
+	require.Equal(t, 1, m.Len())
+}